@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/internal/audit"
+	"github.com/mdhender/ottomat/internal/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditAction string
+	auditActor  string
+	auditFollow bool
+	auditSince  time.Duration
+	auditTarget string
+)
+
+var cmdAudit = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit log commands",
+	Long:  `Read the log of logins, logouts, changes to users and revoked sessions.`,
+}
+
+var cmdAuditTail = &cobra.Command{
+	Use:   "tail",
+	Short: "Print recent audit events",
+	Long: `Print the audit events since --since ago, oldest first, optionally
+filtered by actor, target or action. With --follow, keep printing new
+events as they are recorded until interrupted.
+
+Changes made with the command line are recorded with the actor "cli:" and
+the name of the operating system user.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if auditAction != "" && !slices.Contains(audit.Actions, auditAction) {
+			return fmt.Errorf("invalid action %q", auditAction)
+		}
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+		f := audit.Filter{Actor: auditActor, Target: auditTarget, Action: auditAction}
+		if auditSince > 0 {
+			f.Since = time.Now().Add(-auditSince)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TIME\tACTION\tACTOR\tTARGET\tIP\tDETAIL")
+		for {
+			events, err := audit.List(ctx, client, f)
+			if err != nil {
+				return err
+			}
+			// events are newest first
+			for _, e := range slices.Backward(events) {
+				printAuditEvent(tw, e)
+				f.After = max(f.After, e.ID)
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+			if !auditFollow {
+				return nil
+			}
+			time.Sleep(2 * time.Second)
+		}
+	},
+}
+
+func printAuditEvent(tw *tabwriter.Writer, e *ent.AuditEvent) {
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", e.CreatedAt.UTC().Format(time.RFC3339), e.Action, e.Actor, e.Target, e.IP, e.Detail)
+}
+
+// recordAudit writes an audit event for a change made with the command
+// line. A failure to record is logged but does not fail the command.
+func recordAudit(ctx context.Context, client *ent.Client, action, target, detail string) {
+	e := audit.Event{Action: action, Actor: audit.CLIActor(), Target: target, Detail: detail}
+	if err := audit.Record(ctx, client, e); err != nil {
+		log.Printf("audit: %v", err)
+	}
+}
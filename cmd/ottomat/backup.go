@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/mdhender/ottomat/internal/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupTo    string
+	restoreFrom string
+)
+
+var cmdDbBackup = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the database",
+	Long: `Write a consistent copy of the database using VACUUM INTO.
+This is safe to run while the server is running.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.OpenDB(dbPath)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		if err := database.Backup(context.Background(), db, backupTo); err != nil {
+			return err
+		}
+		log.Printf("backed up %s to %s\n", dbPath, backupTo)
+		return nil
+	},
+}
+
+var cmdDbRestore = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore the database from a backup",
+	Long: `Replace the database with a backup created by "db backup".
+Stop the server before restoring. The backup is checked for integrity first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := os.Stat(dbPath); err == nil {
+			log.Printf("replacing existing database at %s\n", dbPath)
+		}
+		if err := database.Restore(context.Background(), restoreFrom, dbPath); err != nil {
+			return err
+		}
+		log.Printf("restored %s from %s\n", dbPath, restoreFrom)
+
+		// the backup may predate the binary's schema
+		db, err := database.OpenDB(dbPath)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		if err := database.Check(context.Background(), db); err != nil {
+			log.Printf("warning: %v: run \"ottomat db migrate up --db %s\"\n", err, dbPath)
+		}
+		return nil
+	},
+}
@@ -5,24 +5,44 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/game"
 	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/audit"
 	"github.com/mdhender/ottomat/internal/database"
+	"github.com/mdhender/ottomat/internal/resets"
+	"github.com/mdhender/ottomat/internal/throttle"
+	"github.com/mdhender/ottomat/internal/turns"
+	"github.com/mdhender/ottomat/internal/users"
 	"github.com/mdhender/phrases/v2"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/bcrypt"
 )
 
 var (
-	dbPath         string
-	adminUsername  string
-	adminPassword  string
-	createPassword string
-	createRole     string
-	createClanID   int
-	updatePassword string
-	updateRole     string
-	updateClanID   int
+	dbPath           string
+	adminUsername    string
+	adminPassword    string
+	createClanGame   string
+	createClanName   string
+	createGameName   string
+	createTurnDue    string
+	createTurnGame   string
+	resetLinkBaseURL string
+	resetLinkExpires time.Duration
+	createPassword   string
+	createRole       string
+	createClanID     int
+	updatePassword   string
+	updateRole       string
+	updateClanID     int
+	disableReason    string
+	deletePurge      bool
 )
 
 var cmdDb = &cobra.Command{
@@ -49,26 +69,6 @@ var cmdDbInit = &cobra.Command{
 	},
 }
 
-var cmdDbMigrate = &cobra.Command{
-	Use:   "migrate",
-	Short: "Run database migrations",
-	Long:  `Apply schema migrations to the database.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := database.Open(dbPath)
-		if err != nil {
-			return err
-		}
-		defer client.Close()
-
-		ctx := context.Background()
-		if err := database.Migrate(ctx, client); err != nil {
-			return err
-		}
-		log.Println("migrations completed successfully")
-		return nil
-	},
-}
-
 var cmdDbSeed = &cobra.Command{
 	Use:   "seed",
 	Short: "Seed the database with initial data",
@@ -121,6 +121,7 @@ var cmdDbSeed = &cobra.Command{
 		}
 
 		log.Printf("created admin user (username: %s, password: %s)", username, password)
+		recordAudit(ctx, client, audit.UserCreate, username, "role admin, clan none")
 		return nil
 	},
 }
@@ -134,10 +135,16 @@ var cmdDbCreate = &cobra.Command{
 var cmdDbCreateUser = &cobra.Command{
 	Use:   "user <username>",
 	Short: "Create a new user",
-	Long:  `Create a new user with specified username and optional password, role, and clan ID.`,
+	Long:  `Create a new user with specified username and optional password, role, and clan number.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		username := args[0]
+		role := createRole
+		if role == "" {
+			role = "guest"
+		} else if err := checkRole(role); err != nil {
+			return err
+		}
 
 		client, err := database.Open(dbPath)
 		if err != nil {
@@ -159,6 +166,14 @@ var cmdDbCreateUser = &cobra.Command{
 			return fmt.Errorf("user '%s' already exists", username)
 		}
 
+		var userClan *ent.Clan
+		if createClanID != 0 {
+			userClan, err = findClan(ctx, client, createClanID)
+			if err != nil {
+				return err
+			}
+		}
+
 		password := createPassword
 		if password == "" {
 			password = phrases.Generate(6)
@@ -170,32 +185,157 @@ var cmdDbCreateUser = &cobra.Command{
 			return fmt.Errorf("failed to hash password: %w", err)
 		}
 
-		role := createRole
-		if role == "" {
-			role = "guest"
-		}
-
 		create := client.User.
 			Create().
 			SetUsername(username).
 			SetPasswordHash(string(passwordHash)).
 			SetRole(user.Role(role))
 
-		if createClanID != 0 {
-			create.SetClanID(createClanID)
+		clanInfo := "none"
+		if userClan != nil {
+			create.SetClan(userClan)
+			clanInfo = fmt.Sprintf("%04d", userClan.Number)
 		}
 
-		newUser, err := create.Save(ctx)
+		_, err = create.Save(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to create user: %w", err)
 		}
 
-		clanInfo := "none"
-		if newUser.ClanID != nil {
-			clanInfo = fmt.Sprintf("%d", *newUser.ClanID)
+		log.Printf("created user '%s' (role: %s, clan: %s, password: %s)", username, role, clanInfo, password)
+		recordAudit(ctx, client, audit.UserCreate, username, fmt.Sprintf("role %s, clan %s", role, clanInfo))
+		return nil
+	},
+}
+
+var cmdDbCreateClan = &cobra.Command{
+	Use:   "clan <number>",
+	Short: "Create a new clan",
+	Long:  `Create a new clan with the specified clan number and optional name and game code.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		number, err := strconv.Atoi(args[0])
+		if err != nil || number < 1 || number > 9999 {
+			return fmt.Errorf("invalid clan number %q: must be 1 to 9999", args[0])
 		}
 
-		log.Printf("created user '%s' (role: %s, clan: %s, password: %s)", username, role, clanInfo, password)
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+
+		exists, err := client.Clan.
+			Query().
+			Where(clan.Number(number)).
+			Exist(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check for existing clan: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("clan %04d already exists", number)
+		}
+
+		create := client.Clan.
+			Create().
+			SetNumber(number).
+			SetName(createClanName)
+		if createClanGame != "" {
+			g, err := findGame(ctx, client, createClanGame)
+			if err != nil {
+				return err
+			}
+			create.SetGame(g)
+		}
+
+		_, err = create.Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create clan: %w", err)
+		}
+
+		log.Printf("created clan %04d (name: %q, game: %q)", number, createClanName, createClanGame)
+		return nil
+	},
+}
+
+var cmdDbCreateGame = &cobra.Command{
+	Use:   "game <code>",
+	Short: "Create a new game",
+	Long:  `Create a new game with the specified game code and optional name.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		code := args[0]
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+
+		exists, err := client.Game.
+			Query().
+			Where(game.Code(code)).
+			Exist(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check for existing game: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("game %q already exists", code)
+		}
+
+		_, err = client.Game.
+			Create().
+			SetCode(code).
+			SetName(createGameName).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create game: %w", err)
+		}
+
+		log.Printf("created game %q (name: %q)", code, createGameName)
+		return nil
+	},
+}
+
+var cmdDbCreateTurn = &cobra.Command{
+	Use:   "turn <turn-id>",
+	Short: "Create a new turn",
+	Long: `Create a new turn (e.g. 0901-04) for a game. The new turn becomes the
+current turn and is open for reports; the previous turn is closed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		turnID := args[0]
+		if createTurnGame == "" {
+			return fmt.Errorf("--game is required")
+		}
+		dueAt, err := parseDueDate(createTurnDue)
+		if err != nil {
+			return err
+		}
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+
+		g, err := findGame(ctx, client, createTurnGame)
+		if err != nil {
+			return err
+		}
+
+		t, err := turns.Create(ctx, client, g, turnID, dueAt)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("created turn %s for game %q (due: %s)", t.TurnID, g.Code, formatDueDate(t.DueAt))
 		return nil
 	},
 }
@@ -222,6 +362,11 @@ var cmdDbUpdateUser = &cobra.Command{
 		if !passwordSet && !roleSet && !clanIDSet {
 			return fmt.Errorf("at least one update flag must be provided (--password, --role, --clan-id)")
 		}
+		if roleSet {
+			if err := checkRole(updateRole); err != nil {
+				return err
+			}
+		}
 
 		client, err := database.Open(dbPath)
 		if err != nil {
@@ -241,6 +386,7 @@ var cmdDbUpdateUser = &cobra.Command{
 
 		update := targetUser.Update()
 		updates := []string{}
+		changes := []string{} // for the audit log, without the password
 
 		if passwordSet {
 			password := updatePassword
@@ -256,9 +402,15 @@ var cmdDbUpdateUser = &cobra.Command{
 
 			update.SetPasswordHash(string(passwordHash))
 			updates = append(updates, fmt.Sprintf("password: %s", password))
+			changes = append(changes, "password set")
 		}
 
 		if roleSet {
+			if user.Role(updateRole) != user.RoleAdmin {
+				if err := users.CheckLastAdmin(ctx, client, targetUser); err != nil {
+					return fmt.Errorf("failed to update user '%s': %w", username, err)
+				}
+			}
 			update.SetRole(user.Role(updateRole))
 			updates = append(updates, fmt.Sprintf("role: %s", updateRole))
 		}
@@ -267,9 +419,15 @@ var cmdDbUpdateUser = &cobra.Command{
 			if updateClanID == 0 {
 				update.ClearClanID()
 				updates = append(updates, "clan_id: cleared")
+				changes = append(changes, "clan cleared")
 			} else {
-				update.SetClanID(updateClanID)
-				updates = append(updates, fmt.Sprintf("clan_id: %d", updateClanID))
+				c, err := findClan(ctx, client, updateClanID)
+				if err != nil {
+					return err
+				}
+				update.SetClan(c)
+				updates = append(updates, fmt.Sprintf("clan_id: %04d", c.Number))
+				changes = append(changes, fmt.Sprintf("clan %04d", c.Number))
 			}
 		}
 
@@ -279,6 +437,274 @@ var cmdDbUpdateUser = &cobra.Command{
 		}
 
 		log.Printf("updated user '%s': %v", username, updates)
+		if roleSet && user.Role(updateRole) != targetUser.Role {
+			recordAudit(ctx, client, audit.UserRole, username, fmt.Sprintf("%s to %s", targetUser.Role, updateRole))
+		}
+		if len(changes) != 0 {
+			recordAudit(ctx, client, audit.UserUpdate, username, strings.Join(changes, ", "))
+		}
+		return nil
+	},
+}
+
+var cmdDbDisable = &cobra.Command{
+	Use:   "disable",
+	Short: "Disable database records",
+	Long:  `Disable records without deleting them.`,
+}
+
+var cmdDbDisableUser = &cobra.Command{
+	Use:   "user <username>",
+	Short: "Disable a user",
+	Long: `Stop a user from signing in and sign them out of every session. Their
+API tokens and reset links stop working. The user is kept and can be
+re-enabled with "db enable user". The last admin can't be disabled.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username := args[0]
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+		targetUser, err := client.User.Query().Where(user.Username(username)).Only(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to find user '%s': %w", username, err)
+		}
+		if _, err := users.Disable(ctx, client, targetUser, disableReason); err != nil {
+			return fmt.Errorf("failed to disable user '%s': %w", username, err)
+		}
+
+		log.Printf("disabled user '%s'", username)
+		recordAudit(ctx, client, audit.UserDisable, username, disableReason)
+		return nil
+	},
+}
+
+var cmdDbEnable = &cobra.Command{
+	Use:   "enable",
+	Short: "Enable database records",
+	Long:  `Enable records that were disabled.`,
+}
+
+var cmdDbEnableUser = &cobra.Command{
+	Use:   "user <username>",
+	Short: "Enable a disabled user",
+	Long:  `Let a disabled user sign in again.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username := args[0]
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+		targetUser, err := client.User.Query().Where(user.Username(username)).Only(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to find user '%s': %w", username, err)
+		} else if targetUser.DisabledAt == nil {
+			return fmt.Errorf("user '%s' is not disabled", username)
+		}
+		if _, err := users.Enable(ctx, client, targetUser); err != nil {
+			return err
+		}
+
+		log.Printf("enabled user '%s'", username)
+		recordAudit(ctx, client, audit.UserEnable, username, "")
+		return nil
+	},
+}
+
+var cmdDbDelete = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete database records",
+	Long:  `Permanently delete records.`,
+}
+
+var cmdDbDeleteUser = &cobra.Command{
+	Use:   "user <username>",
+	Short: "Permanently delete a user",
+	Long: `Permanently delete a user with their sessions, API tokens and reset links.
+This can't be undone, so --purge is required. To stop a user from signing
+in while keeping the account, use "db disable user" instead. The last admin
+can't be deleted.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username := args[0]
+		if !deletePurge {
+			return fmt.Errorf("deleting a user can't be undone: use --purge to delete '%s', or \"db disable user\" to disable them", username)
+		}
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+		targetUser, err := client.User.Query().Where(user.Username(username)).Only(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to find user '%s': %w", username, err)
+		}
+		if err := users.Purge(ctx, client, targetUser); err != nil {
+			return fmt.Errorf("failed to delete user '%s': %w", username, err)
+		}
+
+		log.Printf("deleted user '%s'", username)
+		recordAudit(ctx, client, audit.UserDelete, username, "")
+		return nil
+	},
+}
+
+var cmdDbResetLink = &cobra.Command{
+	Use:   "reset-link",
+	Short: "Generate password reset links",
+	Long:  `Generate one-time password reset links.`,
+}
+
+var cmdDbResetLinkUser = &cobra.Command{
+	Use:   "user <username>",
+	Short: "Generate a password reset link for a user",
+	Long: `Generate a single-use, expiring password reset link for a user.
+Any earlier unused link for the user stops working.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if resetLinkExpires <= 0 {
+			return fmt.Errorf("--expires must be positive")
+		}
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+		u, err := findUser(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		token, expiresAt, err := resets.Create(ctx, client, u, resetLinkExpires)
+		if err != nil {
+			return err
+		}
+		log.Printf("reset link for %q expires %s\n", u.Username, expiresAt.Format(time.RFC3339))
+		fmt.Println(strings.TrimSuffix(resetLinkBaseURL, "/") + resets.Path(token))
+		return nil
+	},
+}
+
+var cmdDbUnlock = &cobra.Command{
+	Use:   "unlock",
+	Short: "Clear login lockouts",
+	Long:  `Clear failed login attempts and lockouts.`,
+}
+
+var cmdDbUnlockUser = &cobra.Command{
+	Use:   "user <username>",
+	Short: "Clear failed logins for a user",
+	Long:  `Clear the failed login count and any lockout for a user.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+		u, err := findUser(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		if err := throttle.Unlock(ctx, client, u.Username); err != nil {
+			return err
+		}
+		log.Printf("unlocked user %q\n", u.Username)
 		return nil
 	},
 }
+
+// findUser returns the user with the given username.
+func findUser(ctx context.Context, client *ent.Client, username string) (*ent.User, error) {
+	u, err := client.User.
+		Query().
+		Where(user.Username(username)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, fmt.Errorf("user %q does not exist", username)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find user %q: %w", username, err)
+	}
+	return u, nil
+}
+
+// findClan returns the clan with the given clan number.
+// It returns an error if the clan does not exist or is not active.
+func findClan(ctx context.Context, client *ent.Client, number int) (*ent.Clan, error) {
+	c, err := client.Clan.
+		Query().
+		Where(clan.Number(number)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, fmt.Errorf("clan %04d does not exist", number)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find clan %04d: %w", number, err)
+	} else if !c.Active {
+		return nil, fmt.Errorf("clan %04d is not active", number)
+	}
+	return c, nil
+}
+
+// findGame returns the game with the given game code.
+func findGame(ctx context.Context, client *ent.Client, code string) (*ent.Game, error) {
+	g, err := client.Game.
+		Query().
+		Where(game.Code(code)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, fmt.Errorf("game %q does not exist", code)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find game %q: %w", code, err)
+	}
+	return g, nil
+}
+
+// parseDueDate parses an optional due date in YYYY-MM-DD or RFC 3339 format.
+// Dates without a time are due at the end of the day, UTC.
+func parseDueDate(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid due date %q: expected YYYY-MM-DD", s)
+	}
+	t = t.Add(24*time.Hour - time.Second)
+	return &t, nil
+}
+
+func formatDueDate(t *time.Time) string {
+	if t == nil {
+		return "not set"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// checkRole returns an error if role is not one of the user roles.
+func checkRole(role string) error {
+	if err := user.RoleValidator(user.Role(role)); err != nil {
+		return fmt.Errorf("invalid role %q (guest, chief, referee, admin)", role)
+	}
+	return nil
+}
@@ -3,7 +3,9 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
+	"github.com/mdhender/ottomat/internal/resets"
 	"github.com/spf13/cobra"
 )
 
@@ -17,32 +19,114 @@ func main() {
 	}
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
+	rootCmd.AddCommand(cmdAudit)
+	cmdAudit.AddCommand(cmdAuditTail)
+	cmdAudit.PersistentFlags().StringVar(&dbPath, "db", "ottomat.db", "path to the database file")
+	cmdAuditTail.Flags().StringVar(&auditAction, "action", "", "only events with this action (e.g. login.failed)")
+	cmdAuditTail.Flags().StringVar(&auditActor, "actor", "", "only events by this username")
+	cmdAuditTail.Flags().BoolVar(&auditFollow, "follow", false, "keep printing new events")
+	cmdAuditTail.Flags().DurationVar(&auditSince, "since", 24*time.Hour, "how far back to start (0 prints everything)")
+	cmdAuditTail.Flags().StringVar(&auditTarget, "target", "", "only events on this username")
+
 	rootCmd.AddCommand(cmdDb)
+	cmdDb.AddCommand(cmdDb2FA)
+	cmdDb.AddCommand(cmdDbBackup)
 	cmdDb.AddCommand(cmdDbCreate)
+	cmdDb.AddCommand(cmdDbDelete)
+	cmdDb.AddCommand(cmdDbDisable)
+	cmdDb.AddCommand(cmdDbEnable)
+	cmdDb.AddCommand(cmdDbExport)
+	cmdDb.AddCommand(cmdDbImport)
 	cmdDb.AddCommand(cmdDbInit)
 	cmdDb.AddCommand(cmdDbMigrate)
+	cmdDb.AddCommand(cmdDbResetLink)
+	cmdDb.AddCommand(cmdDbRestore)
 	cmdDb.AddCommand(cmdDbSeed)
+	cmdDb.AddCommand(cmdDbSessions)
+	cmdDb.AddCommand(cmdDbUnlock)
 	cmdDb.AddCommand(cmdDbUpdate)
+	cmdDb2FA.AddCommand(cmdDb2FAReset)
+	cmdDb2FAReset.AddCommand(cmdDb2FAResetUser)
+	cmdDbCreate.AddCommand(cmdDbCreateClan)
+	cmdDbCreate.AddCommand(cmdDbCreateGame)
+	cmdDbCreate.AddCommand(cmdDbCreateTurn)
 	cmdDbCreate.AddCommand(cmdDbCreateUser)
+	cmdDbDelete.AddCommand(cmdDbDeleteUser)
+	cmdDbDisable.AddCommand(cmdDbDisableUser)
+	cmdDbEnable.AddCommand(cmdDbEnableUser)
+	cmdDbExport.AddCommand(cmdDbExportUsers)
+	cmdDbImport.AddCommand(cmdDbImportUsers)
+	cmdDbMigrate.AddCommand(cmdDbMigrateDown)
+	cmdDbMigrate.AddCommand(cmdDbMigrateStatus)
+	cmdDbMigrate.AddCommand(cmdDbMigrateUp)
+	cmdDbResetLink.AddCommand(cmdDbResetLinkUser)
+	cmdDbSessions.AddCommand(cmdDbSessionsPrune)
+	cmdDbUnlock.AddCommand(cmdDbUnlockUser)
 	cmdDbUpdate.AddCommand(cmdDbUpdateUser)
 
 	cmdDb.PersistentFlags().StringVar(&dbPath, "db", "ottomat.db", "path to the database file")
-	cmdDbCreateUser.Flags().IntVar(&createClanID, "clan-id", 0, "clan ID for user")
+	cmdDbBackup.Flags().StringVar(&backupTo, "to", "", "path of the backup file (must not exist)")
+	_ = cmdDbBackup.MarkFlagRequired("to")
+	cmdDbResetLinkUser.Flags().DurationVar(&resetLinkExpires, "expires", resets.DefaultTTL, "how long the link is valid")
+	cmdDbResetLinkUser.Flags().StringVar(&resetLinkBaseURL, "base-url", "", "site URL to prefix the link with (e.g. https://ottomat.example.com)")
+	cmdDbRestore.Flags().StringVar(&restoreFrom, "from", "", "path of the backup file")
+	_ = cmdDbRestore.MarkFlagRequired("from")
+	cmdDbCreateClan.Flags().StringVar(&createClanGame, "game", "", "code of the game the clan is playing in (game must exist)")
+	cmdDbCreateGame.Flags().StringVar(&createGameName, "name", "", "name of the game")
+	cmdDbCreateTurn.Flags().StringVar(&createTurnDue, "due", "", "report due date (YYYY-MM-DD or RFC 3339)")
+	cmdDbCreateTurn.Flags().StringVar(&createTurnGame, "game", "", "code of the game")
+	cmdDbCreateClan.Flags().StringVar(&createClanName, "name", "", "name of the clan")
+	cmdDbCreateUser.Flags().IntVar(&createClanID, "clan-id", 0, "clan number for user (clan must exist)")
 	cmdDbCreateUser.Flags().StringVar(&createPassword, "password", "", "password for user (generates random if not provided)")
-	cmdDbCreateUser.Flags().StringVar(&createRole, "role", "guest", "role for user (guest, chief, admin)")
+	cmdDbCreateUser.Flags().StringVar(&createRole, "role", "guest", "role for user (guest, chief, referee, admin)")
+	cmdDbDeleteUser.Flags().BoolVar(&deletePurge, "purge", false, "confirm that the user is deleted permanently")
+	cmdDbDisableUser.Flags().StringVar(&disableReason, "reason", "", "why the user is disabled")
+	cmdDbExportUsers.Flags().StringVar(&exportFormat, "format", "csv", "output format (csv, json)")
+	cmdDbExportUsers.Flags().StringVar(&exportOutput, "output", "", "output file (defaults to stdout)")
+	cmdDbImportUsers.Flags().BoolVar(&importDryRun, "dry-run", false, "check the roster without creating users")
+	cmdDbImportUsers.Flags().StringVar(&importFile, "file", "", "roster file (CSV, or JSON if it ends in .json)")
+	_ = cmdDbImportUsers.MarkFlagRequired("file")
+	cmdDbImportUsers.Flags().StringVar(&importOutput, "output", "", "file for generated passwords (must not exist)")
+	cmdDbMigrateDown.Flags().StringVar(&migrateTo, "to", "", "version to revert to (0 reverts everything)")
+	cmdDbMigrateUp.Flags().StringVar(&migrateTo, "to", "", "last version to apply (default all)")
 	cmdDbSeed.Flags().StringVar(&adminPassword, "password", "", "password for admin user (generates random if not provided)")
 	cmdDbSeed.Flags().StringVar(&adminUsername, "username", "admin", "username for admin user")
-	cmdDbUpdateUser.Flags().IntVar(&updateClanID, "clan-id", 0, "new clan ID for user (0 to clear)")
+	cmdDbUpdateUser.Flags().IntVar(&updateClanID, "clan-id", 0, "new clan number for user (0 to clear)")
 	cmdDbUpdateUser.Flags().StringVar(&updatePassword, "password", "", "new password for user (generates random if not provided)")
-	cmdDbUpdateUser.Flags().StringVar(&updateRole, "role", "", "new role for user (guest, chief, admin)")
+	cmdDbUpdateUser.Flags().StringVar(&updateRole, "role", "", "new role for user (guest, chief, referee, admin)")
+
+	rootCmd.AddCommand(cmdMap)
+	cmdMap.AddCommand(cmdMapExport)
+	cmdMap.PersistentFlags().StringVar(&dbPath, "db", "ottomat.db", "path to the database file")
+	cmdMapExport.Flags().IntVar(&mapClanID, "clan", 0, "clan number to export")
+	cmdMapExport.Flags().StringVar(&mapFormat, "format", "wxx", "output format (wxx, svg)")
+	cmdMapExport.Flags().StringVar(&mapOutput, "output", "", "output file (defaults to CLAN.TURN.FORMAT)")
+	cmdMapExport.Flags().StringVar(&mapTurnID, "turn", "", "turn to export (e.g. 0901-04)")
+
+	rootCmd.AddCommand(cmdReport)
+	cmdReport.AddCommand(cmdReportParse)
+
+	rootCmd.AddCommand(cmdRoutes)
+	cmdRoutes.Flags().BoolVar(&routesOpenAPI, "openapi", false, "print the OpenAPI document instead of the table")
 
 	rootCmd.AddCommand(cmdServer)
+	cmdServer.Flags().DurationVar(&backupEvery, "backup-every", 0, "write a database snapshot at this interval (0 disables)")
+	cmdServer.Flags().IntVar(&backupKeep, "backup-keep", 14, "number of snapshots to keep (0 keeps all)")
+	cmdServer.Flags().StringVar(&backupDir, "backup-dir", "", "directory for snapshots (default: backups next to the database)")
 	cmdServer.Flags().BoolVar(&devMode, "dev", false, "enable development mode (disables password managers)")
 	cmdServer.Flags().BoolVar(&visiblePasswords, "visible-passwords", false, "show passwords as plain text (requires --dev)")
 	cmdServer.Flags().DurationVar(&serverTimeout, "timeout", 0, "automatically shutdown after duration (for testing)")
 	cmdServer.Flags().StringVar(&dbPath, "db", "./ottomat.db", "path to the database file")
+	cmdServer.Flags().DurationVar(&pruneEvery, "prune-every", time.Hour, "delete expired sessions and tokens at this interval (0 disables)")
 	cmdServer.Flags().StringVar(&serverPort, "port", "8080", "port to listen on")
 
+	rootCmd.AddCommand(cmdTurn)
+	cmdTurn.AddCommand(cmdTurnAdvance)
+	cmdTurn.PersistentFlags().StringVar(&dbPath, "db", "ottomat.db", "path to the database file")
+	cmdTurnAdvance.Flags().StringVar(&turnAdvanceDue, "due", "", "report due date for the new turn (YYYY-MM-DD or RFC 3339)")
+	cmdTurnAdvance.Flags().StringVar(&turnAdvanceGame, "game", "", "code of the game")
+	_ = cmdTurnAdvance.MarkFlagRequired("game")
+
 	rootCmd.AddCommand(cmdVersion)
 	cmdVersion.Flags().BoolVar(&buildInfo, "build-info", false, "show build information")
 
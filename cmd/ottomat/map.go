@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/mdhender/ottomat/internal/database"
+	"github.com/mdhender/ottomat/internal/hexmap"
+	"github.com/mdhender/ottomat/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mapClanID int
+	mapFormat string
+	mapOutput string
+	mapTurnID string
+)
+
+var cmdMap = &cobra.Command{
+	Use:   "map",
+	Short: "Map commands",
+	Long:  `Build clan maps from uploaded turn reports.`,
+}
+
+var cmdMapExport = &cobra.Command{
+	Use:   "export",
+	Short: "Export a clan map",
+	Long: `Export the map for a clan as of the end of a turn.
+The map is built from every report the clan uploaded up to and including the turn.
+Supported formats are wxx (Worldographer) and svg.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if mapClanID == 0 {
+			return fmt.Errorf("--clan is required")
+		} else if !report.IsTurnID(mapTurnID) {
+			return fmt.Errorf("invalid turn %q: expected YYYY-MM, e.g. 0901-04", mapTurnID)
+		} else if mapFormat != "wxx" && mapFormat != "svg" {
+			return fmt.Errorf("invalid format %q: must be wxx or svg", mapFormat)
+		}
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+
+		c, err := findClan(ctx, client, mapClanID)
+		if err != nil {
+			return err
+		}
+
+		m, err := hexmap.Load(ctx, c, mapTurnID)
+		if err != nil {
+			return err
+		}
+		for _, warning := range m.Warnings {
+			log.Printf("warning: %s\n", warning)
+		}
+
+		buf := &bytes.Buffer{}
+		switch mapFormat {
+		case "svg":
+			title := fmt.Sprintf("Clan %04d - Turn %s", c.Number, mapTurnID)
+			err = hexmap.RenderSVG(buf, m, hexmap.RenderOptions{Title: title})
+		case "wxx":
+			err = hexmap.RenderWXX(buf, m)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to render map: %w", err)
+		}
+
+		output := mapOutput
+		if output == "" {
+			output = fmt.Sprintf("%04d.%s.%s", c.Number, mapTurnID, mapFormat)
+		}
+		if err := os.WriteFile(output, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("failed to write map: %w", err)
+		}
+
+		log.Printf("exported %d hexes for clan %04d turn %s to %s\n", len(m.Hexes), c.Number, mapTurnID, output)
+		return nil
+	},
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/mdhender/ottomat/internal/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateTo string
+)
+
+var cmdDbMigrate = &cobra.Command{
+	Use:   "migrate",
+	Short: "Run database migrations",
+	Long:  `Apply pending schema migrations to the database. Same as "migrate up".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return migrateUp(0)
+	},
+}
+
+var cmdDbMigrateStatus = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.OpenDB(dbPath)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		list, err := database.Status(context.Background(), db)
+		if err != nil {
+			return err
+		}
+		pending := 0
+		for _, ms := range list {
+			state := "pending"
+			if ms.AppliedAt != nil {
+				state = "applied " + ms.AppliedAt.Format("2006-01-02 15:04:05")
+			} else {
+				pending++
+			}
+			fmt.Printf("%d  %-24s  %s\n", ms.Version, ms.Name, state)
+		}
+		if pending == 0 {
+			log.Println("database is up to date")
+		} else {
+			log.Printf("%d pending migration(s)\n", pending)
+		}
+		return nil
+	},
+}
+
+var cmdDbMigrateUp = &cobra.Command{
+	Use:   "up",
+	Short: "Apply pending migrations",
+	Long:  `Apply pending migrations in order, stopping after --to when it is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		to, err := parseMigrationVersion(migrateTo)
+		if err != nil {
+			return err
+		}
+		return migrateUp(to)
+	},
+}
+
+var cmdDbMigrateDown = &cobra.Command{
+	Use:   "down",
+	Short: "Revert applied migrations",
+	Long: `Revert applied migrations, newest first, until --to is the latest applied version.
+Without --to only the latest migration is reverted. Use --to 0 to revert every migration.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		to, err := parseMigrationVersion(migrateTo)
+		if err != nil {
+			return err
+		}
+
+		db, err := database.OpenDB(dbPath)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		ctx := context.Background()
+		if !cmd.Flags().Changed("to") {
+			list, err := database.Status(ctx, db)
+			if err != nil {
+				return err
+			}
+			// step back to the applied migration before the latest one
+			var applied []int64
+			for _, ms := range list {
+				if ms.AppliedAt != nil {
+					applied = append(applied, ms.Version)
+				}
+			}
+			if len(applied) == 0 {
+				log.Println("no migrations to revert")
+				return nil
+			} else if len(applied) > 1 {
+				to = applied[len(applied)-2]
+			}
+		}
+
+		done, err := database.Down(ctx, db, to)
+		for _, m := range done {
+			log.Printf("reverted %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		log.Printf("%d migration(s) reverted\n", len(done))
+		return nil
+	},
+}
+
+func migrateUp(to int64) error {
+	db, err := database.OpenDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	done, err := database.Up(context.Background(), db, to)
+	for _, m := range done {
+		log.Printf("applied %d_%s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("migrations completed successfully (%d applied)\n", len(done))
+	return nil
+}
+
+func parseMigrationVersion(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	version, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid migration version %q", s)
+	}
+	return version, nil
+}
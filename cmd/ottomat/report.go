@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mdhender/ottomat/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var cmdReport = &cobra.Command{
+	Use:   "report",
+	Short: "Turn report commands",
+	Long:  `Work with TribeNet turn report files.`,
+}
+
+var cmdReportParse = &cobra.Command{
+	Use:   "parse <file>",
+	Short: "Parse a turn report",
+	Long: `Parse a turn report (.txt or .docx) and print the result as JSON.
+Problems found in the report are printed to stderr with line numbers.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("failed to read report: %w", err)
+		}
+		text, err := report.Text(name, data)
+		if err != nil {
+			return fmt.Errorf("failed to read report: %w", err)
+		}
+
+		rpt := report.Parse(text)
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rpt); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+
+		for _, e := range rpt.Errors {
+			fmt.Fprintf(os.Stderr, "%s:%d: %s\n", name, e.Line, e.Message)
+		}
+		if len(rpt.Errors) != 0 {
+			return fmt.Errorf("%s: %d errors", name, len(rpt.Errors))
+		}
+		return nil
+	},
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mdhender/ottomat/internal/audit"
+	"github.com/mdhender/ottomat/internal/database"
+	"github.com/mdhender/ottomat/internal/users"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importDryRun bool
+	importFile   string
+	importOutput string
+	exportFormat string
+	exportOutput string
+)
+
+var cmdDbImport = &cobra.Command{
+	Use:   "import",
+	Short: "Import database records",
+	Long:  `Import records from files.`,
+}
+
+var cmdDbImportUsers = &cobra.Command{
+	Use:   "users",
+	Short: "Create users from a roster file",
+	Long: `Create users from a CSV or JSON roster. A CSV roster starts with a header
+row naming its columns: username (required), role, clan and password. A
+JSON roster is an array of objects with the same fields. Files ending in
+.json are read as JSON; anything else is read as CSV.
+
+An empty role means guest, and an empty clan means none. Users without a
+password get a generated passphrase; these are written to the --output
+file, which must not exist, as a CSV roster to hand out to the players.
+The file is deleted again if the users can't be created.
+
+Every row is checked before any user is created. If any row has a problem,
+the problems are listed and no users are created. With --dry-run the rows
+are only checked.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fd, err := os.Open(importFile)
+		if err != nil {
+			return err
+		}
+		defer fd.Close()
+		var entries []users.RosterEntry
+		if strings.EqualFold(filepath.Ext(importFile), ".json") {
+			entries, err = users.ReadRosterJSON(fd)
+		} else {
+			entries, err = users.ReadRosterCSV(fd)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", importFile, err)
+		}
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+		list, problems, err := users.PrepareImport(ctx, client, entries)
+		if err != nil {
+			return err
+		}
+		for _, p := range problems {
+			log.Printf("%s: %v\n", importFile, p)
+		}
+		if len(problems) != 0 {
+			return fmt.Errorf("%s: %d of %d rows have problems, no users created", importFile, len(problems), len(entries))
+		}
+
+		var generated []users.RosterEntry
+		for _, nu := range list {
+			if nu.Generated {
+				generated = append(generated, users.RosterEntry{Username: nu.Username, Role: nu.Role.String(), Clan: nu.Clan, Password: nu.Password})
+			}
+		}
+		if importDryRun {
+			log.Printf("%s: dry run: %d users would be created (%d with generated passwords)\n", importFile, len(list), len(generated))
+			return nil
+		}
+		if len(generated) != 0 && importOutput == "" {
+			return fmt.Errorf("%d users need generated passwords: --output is required", len(generated))
+		}
+
+		// the passwords are saved before the users are created so that they
+		// can't be lost, and removed again if the users aren't created
+		if len(generated) != 0 {
+			out, err := os.OpenFile(importOutput, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+			if err != nil {
+				return err
+			}
+			if err := users.WriteRosterCSV(out, generated); err != nil {
+				_ = out.Close()
+				_ = os.Remove(importOutput)
+				return fmt.Errorf("%s: %w", importOutput, err)
+			} else if err := out.Close(); err != nil {
+				_ = os.Remove(importOutput)
+				return fmt.Errorf("%s: %w", importOutput, err)
+			}
+		}
+
+		if err := users.CreateUsers(ctx, client, list); err != nil {
+			if len(generated) != 0 {
+				if rmErr := os.Remove(importOutput); rmErr != nil {
+					log.Printf("%s: %v: delete it, the users were not created\n", importOutput, rmErr)
+				}
+			}
+			return err
+		}
+		log.Printf("%s: created %d users\n", importFile, len(list))
+		for _, nu := range list {
+			recordAudit(ctx, client, audit.UserCreate, nu.Username, fmt.Sprintf("role %s, clan %s, imported from %s", nu.Role, cmp.Or(nu.Clan, "none"), filepath.Base(importFile)))
+		}
+		if len(generated) != 0 {
+			log.Printf("%s: wrote %d generated passwords\n", importOutput, len(generated))
+		}
+		return nil
+	},
+}
+
+var cmdDbExport = &cobra.Command{
+	Use:   "export",
+	Short: "Export database records",
+	Long:  `Export records to files.`,
+}
+
+var cmdDbExportUsers = &cobra.Command{
+	Use:   "users",
+	Short: "Write every user to a roster file",
+	Long: `Write every user's username, role and clan as a CSV or JSON roster, in
+the format read by "db import users". Passwords are not exported.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var write func(io.Writer, []users.RosterEntry) error
+		switch exportFormat {
+		case "csv":
+			write = users.WriteRosterCSV
+		case "json":
+			write = users.WriteRosterJSON
+		default:
+			return fmt.Errorf("invalid format %q (csv, json)", exportFormat)
+		}
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		entries, err := users.Roster(context.Background(), client)
+		if err != nil {
+			return fmt.Errorf("failed to list users: %w", err)
+		}
+
+		if exportOutput == "" {
+			return write(os.Stdout, entries)
+		}
+		out, err := os.Create(exportOutput)
+		if err != nil {
+			return err
+		}
+		if err := write(out, entries); err != nil {
+			_ = out.Close()
+			return fmt.Errorf("%s: %w", exportOutput, err)
+		} else if err := out.Close(); err != nil {
+			return fmt.Errorf("%s: %w", exportOutput, err)
+		}
+		log.Printf("%s: exported %d users\n", exportOutput, len(entries))
+		return nil
+	},
+}
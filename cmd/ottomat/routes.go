@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mdhender/ottomat/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	routesOpenAPI bool
+)
+
+var cmdRoutes = &cobra.Command{
+	Use:   "routes",
+	Short: "List the server's routes",
+	Long: `List every route the web server registers, with the authentication it
+requires, the policy saying which roles may call it, and a summary. With
+--openapi, print the OpenAPI document the server publishes at /openapi.json
+instead.
+
+Routes that are missing metadata or a policy are printed to stderr and the command
+fails, so it can be used as a check before committing a new route.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		table := server.Routes()
+		errs := server.CheckRoutes(table)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "routes: %v\n", err)
+		}
+
+		if routesOpenAPI {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(server.OpenAPI(table)); err != nil {
+				return fmt.Errorf("failed to encode document: %w", err)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "METHOD\tPATH\tAUTH\tPOLICY\tSUMMARY")
+			for _, rt := range table {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", rt.Method, rt.Path, rt.Auth, rt.Policy, rt.Summary)
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+		}
+
+		if len(errs) != 0 {
+			return fmt.Errorf("%d routes are missing metadata", len(errs))
+		}
+		return nil
+	},
+}
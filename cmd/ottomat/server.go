@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -16,6 +17,10 @@ import (
 )
 
 var (
+	backupDir        string
+	backupEvery      time.Duration
+	backupKeep       int
+	pruneEvery       time.Duration
 	serverPort       string
 	serverTimeout    time.Duration
 	devMode          bool
@@ -43,12 +48,29 @@ var cmdServer = &cobra.Command{
 			return fmt.Errorf("--visible-passwords requires --dev flag")
 		}
 
+		if err := checkMigrations(dbPath); err != nil {
+			return err
+		}
+
 		client, err := database.Open(dbPath)
 		if err != nil {
 			return err
 		}
 		defer client.Close()
 
+		if backupEvery > 0 {
+			stop, err := startBackups(dbPath)
+			if err != nil {
+				return err
+			}
+			defer stop()
+		}
+
+		if pruneEvery > 0 {
+			stop := startReaper(client, pruneEvery)
+			defer stop()
+		}
+
 		fsMode := ottomat.Embedded
 		if devMode {
 			fsMode = ottomat.Live
@@ -95,3 +117,45 @@ var cmdServer = &cobra.Command{
 		return nil
 	},
 }
+
+// checkMigrations refuses to serve a database whose schema is older than
+// the binary expects.
+func checkMigrations(dbPath string) error {
+	db, err := database.OpenDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := database.Check(context.Background(), db); err != nil {
+		return fmt.Errorf("%w: run \"ottomat db migrate up --db %s\"", err, dbPath)
+	}
+	return nil
+}
+
+// startBackups runs the snapshot scheduler on its own database handle.
+// The returned function stops the scheduler and waits for it to finish.
+func startBackups(dbPath string) (func(), error) {
+	if backupKeep < 0 {
+		return nil, fmt.Errorf("--backup-keep must not be negative")
+	}
+	dir := backupDir
+	if dir == "" {
+		dir = filepath.Join(filepath.Dir(dbPath), "backups")
+	}
+	db, err := database.OpenDB(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		database.RunBackups(ctx, db, dir, backupEvery, backupKeep)
+	}()
+	log.Printf("backing up to %s every %v (keeping %d)\n", dir, backupEvery, backupKeep)
+	return func() {
+		cancel()
+		<-done
+		db.Close()
+	}, nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/internal/apitokens"
+	"github.com/mdhender/ottomat/internal/database"
+	"github.com/mdhender/ottomat/internal/resets"
+	"github.com/mdhender/ottomat/internal/sessions"
+	"github.com/mdhender/ottomat/internal/throttle"
+	"github.com/mdhender/ottomat/internal/twofactor"
+	"github.com/spf13/cobra"
+)
+
+var cmdDbSessions = &cobra.Command{
+	Use:   "sessions",
+	Short: "Session maintenance commands",
+	Long:  `Manage login sessions.`,
+}
+
+var cmdDbSessionsPrune = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete expired sessions and tokens",
+	Long: `Delete expired or idle sessions, used or expired password reset links,
+stale failed-login records, abandoned two-factor logins, and expired API
+tokens. The server does this periodically; see
+"server --prune-every".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		counts, err := pruneExpired(context.Background(), client)
+		if err != nil {
+			return err
+		}
+		log.Printf("pruned %s\n", counts)
+		return nil
+	},
+}
+
+type pruneCounts struct {
+	Sessions   int
+	Resets     int
+	Throttle   int
+	Challenges int
+	Tokens     int
+}
+
+func (c pruneCounts) String() string {
+	return fmt.Sprintf("%d session(s), %d reset link(s), %d failed-login record(s), %d 2fa challenge(s), %d api token(s)", c.Sessions, c.Resets, c.Throttle, c.Challenges, c.Tokens)
+}
+
+func (c pruneCounts) total() int {
+	return c.Sessions + c.Resets + c.Throttle + c.Challenges + c.Tokens
+}
+
+// pruneExpired deletes rows that can no longer be used.
+func pruneExpired(ctx context.Context, client *ent.Client) (pruneCounts, error) {
+	var counts pruneCounts
+	var err error
+	if counts.Sessions, err = sessions.Prune(ctx, client); err != nil {
+		return counts, err
+	}
+	if counts.Resets, err = resets.Prune(ctx, client); err != nil {
+		return counts, err
+	}
+	if counts.Throttle, err = throttle.Prune(ctx, client); err != nil {
+		return counts, err
+	}
+	if counts.Challenges, err = twofactor.Prune(ctx, client); err != nil {
+		return counts, err
+	}
+	if counts.Tokens, err = apitokens.Prune(ctx, client); err != nil {
+		return counts, err
+	}
+	return counts, nil
+}
+
+// startReaper prunes expired rows every interval until stopped. The
+// returned function stops the reaper and waits for it to finish.
+func startReaper(client *ent.Client, every time.Duration) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				counts, err := pruneExpired(ctx, client)
+				if err != nil && ctx.Err() == nil {
+					log.Printf("reaper: %v\n", err)
+				} else if counts.total() != 0 {
+					log.Printf("reaper: pruned %s\n", counts)
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
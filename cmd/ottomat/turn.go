@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/mdhender/ottomat/internal/database"
+	"github.com/mdhender/ottomat/internal/turns"
+	"github.com/spf13/cobra"
+)
+
+var (
+	turnAdvanceDue  string
+	turnAdvanceGame string
+)
+
+var cmdTurn = &cobra.Command{
+	Use:   "turn",
+	Short: "Game calendar commands",
+	Long:  `Manage the turns of a game.`,
+}
+
+var cmdTurnAdvance = &cobra.Command{
+	Use:   "advance",
+	Short: "Advance a game to the next turn",
+	Long: `Close the current turn of a game and open the next one.
+Use "db create turn" to create the first turn of a game.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dueAt, err := parseDueDate(turnAdvanceDue)
+		if err != nil {
+			return err
+		}
+
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+
+		g, err := findGame(ctx, client, turnAdvanceGame)
+		if err != nil {
+			return err
+		}
+
+		t, err := turns.Advance(ctx, client, g, dueAt)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("game %q advanced to turn %s (due: %s)", g.Code, t.TurnID, formatDueDate(t.DueAt))
+		return nil
+	},
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/mdhender/ottomat/internal/database"
+	"github.com/mdhender/ottomat/internal/twofactor"
+	"github.com/spf13/cobra"
+)
+
+var cmdDb2FA = &cobra.Command{
+	Use:   "2fa",
+	Short: "Two-factor authentication commands",
+	Long:  `Manage two-factor authentication for users.`,
+}
+
+var cmdDb2FAReset = &cobra.Command{
+	Use:   "reset",
+	Short: "Turn off two-factor authentication",
+	Long:  `Turn off two-factor authentication so a user can log in with only a password.`,
+}
+
+var cmdDb2FAResetUser = &cobra.Command{
+	Use:   "user <username>",
+	Short: "Turn off two-factor authentication for a user",
+	Long: `Turn off two-factor authentication for a user who has lost their
+authenticator and recovery codes. The secret and recovery codes are
+deleted; the user can enroll again after logging in.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := database.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		ctx := context.Background()
+		u, err := findUser(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		if err := twofactor.Reset(ctx, client, u.ID); err != nil {
+			return err
+		}
+		log.Printf("reset two-factor authentication for user %q\n", u.Username)
+		return nil
+	},
+}
@@ -0,0 +1,212 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/game"
+)
+
+// Clan is the model entity for the Clan schema.
+type Clan struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Number holds the value of the "number" field.
+	Number int `json:"number,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Active holds the value of the "active" field.
+	Active bool `json:"active,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ClanQuery when eager-loading is set.
+	Edges        ClanEdges `json:"edges"`
+	game_clans   *int
+	selectValues sql.SelectValues
+}
+
+// ClanEdges holds the relations/edges for other nodes in the graph.
+type ClanEdges struct {
+	// Users holds the value of the users edge.
+	Users []*User `json:"users,omitempty"`
+	// Reports holds the value of the reports edge.
+	Reports []*TurnReport `json:"reports,omitempty"`
+	// Game holds the value of the game edge.
+	Game *Game `json:"game,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [3]bool
+}
+
+// UsersOrErr returns the Users value or an error if the edge
+// was not loaded in eager-loading.
+func (e ClanEdges) UsersOrErr() ([]*User, error) {
+	if e.loadedTypes[0] {
+		return e.Users, nil
+	}
+	return nil, &NotLoadedError{edge: "users"}
+}
+
+// ReportsOrErr returns the Reports value or an error if the edge
+// was not loaded in eager-loading.
+func (e ClanEdges) ReportsOrErr() ([]*TurnReport, error) {
+	if e.loadedTypes[1] {
+		return e.Reports, nil
+	}
+	return nil, &NotLoadedError{edge: "reports"}
+}
+
+// GameOrErr returns the Game value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ClanEdges) GameOrErr() (*Game, error) {
+	if e.Game != nil {
+		return e.Game, nil
+	} else if e.loadedTypes[2] {
+		return nil, &NotFoundError{label: game.Label}
+	}
+	return nil, &NotLoadedError{edge: "game"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Clan) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case clan.FieldActive:
+			values[i] = new(sql.NullBool)
+		case clan.FieldID, clan.FieldNumber:
+			values[i] = new(sql.NullInt64)
+		case clan.FieldName:
+			values[i] = new(sql.NullString)
+		case clan.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case clan.ForeignKeys[0]: // game_clans
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Clan fields.
+func (_m *Clan) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case clan.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case clan.FieldNumber:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field number", values[i])
+			} else if value.Valid {
+				_m.Number = int(value.Int64)
+			}
+		case clan.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case clan.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case clan.FieldActive:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field active", values[i])
+			} else if value.Valid {
+				_m.Active = value.Bool
+			}
+		case clan.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field game_clans", value)
+			} else if value.Valid {
+				_m.game_clans = new(int)
+				*_m.game_clans = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Clan.
+// This includes values selected through modifiers, order, etc.
+func (_m *Clan) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryUsers queries the "users" edge of the Clan entity.
+func (_m *Clan) QueryUsers() *UserQuery {
+	return NewClanClient(_m.config).QueryUsers(_m)
+}
+
+// QueryReports queries the "reports" edge of the Clan entity.
+func (_m *Clan) QueryReports() *TurnReportQuery {
+	return NewClanClient(_m.config).QueryReports(_m)
+}
+
+// QueryGame queries the "game" edge of the Clan entity.
+func (_m *Clan) QueryGame() *GameQuery {
+	return NewClanClient(_m.config).QueryGame(_m)
+}
+
+// Update returns a builder for updating this Clan.
+// Note that you need to call Clan.Unwrap() before calling this method if this Clan
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Clan) Update() *ClanUpdateOne {
+	return NewClanClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Clan entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Clan) Unwrap() *Clan {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Clan is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Clan) String() string {
+	var builder strings.Builder
+	builder.WriteString("Clan(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("number=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Number))
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("active=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Active))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Clans is a parsable slice of Clan.
+type Clans []*Clan
@@ -0,0 +1,371 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/ent/turnreport"
+	"github.com/mdhender/ottomat/ent/user"
+)
+
+// ClanCreate is the builder for creating a Clan entity.
+type ClanCreate struct {
+	config
+	mutation *ClanMutation
+	hooks    []Hook
+}
+
+// SetNumber sets the "number" field.
+func (_c *ClanCreate) SetNumber(v int) *ClanCreate {
+	_c.mutation.SetNumber(v)
+	return _c
+}
+
+// SetName sets the "name" field.
+func (_c *ClanCreate) SetName(v string) *ClanCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_c *ClanCreate) SetNillableName(v *string) *ClanCreate {
+	if v != nil {
+		_c.SetName(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *ClanCreate) SetCreatedAt(v time.Time) *ClanCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *ClanCreate) SetNillableCreatedAt(v *time.Time) *ClanCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetActive sets the "active" field.
+func (_c *ClanCreate) SetActive(v bool) *ClanCreate {
+	_c.mutation.SetActive(v)
+	return _c
+}
+
+// SetNillableActive sets the "active" field if the given value is not nil.
+func (_c *ClanCreate) SetNillableActive(v *bool) *ClanCreate {
+	if v != nil {
+		_c.SetActive(*v)
+	}
+	return _c
+}
+
+// AddUserIDs adds the "users" edge to the User entity by IDs.
+func (_c *ClanCreate) AddUserIDs(ids ...int) *ClanCreate {
+	_c.mutation.AddUserIDs(ids...)
+	return _c
+}
+
+// AddUsers adds the "users" edges to the User entity.
+func (_c *ClanCreate) AddUsers(v ...*User) *ClanCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddUserIDs(ids...)
+}
+
+// AddReportIDs adds the "reports" edge to the TurnReport entity by IDs.
+func (_c *ClanCreate) AddReportIDs(ids ...int) *ClanCreate {
+	_c.mutation.AddReportIDs(ids...)
+	return _c
+}
+
+// AddReports adds the "reports" edges to the TurnReport entity.
+func (_c *ClanCreate) AddReports(v ...*TurnReport) *ClanCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddReportIDs(ids...)
+}
+
+// SetGameID sets the "game" edge to the Game entity by ID.
+func (_c *ClanCreate) SetGameID(id int) *ClanCreate {
+	_c.mutation.SetGameID(id)
+	return _c
+}
+
+// SetNillableGameID sets the "game" edge to the Game entity by ID if the given value is not nil.
+func (_c *ClanCreate) SetNillableGameID(id *int) *ClanCreate {
+	if id != nil {
+		_c = _c.SetGameID(*id)
+	}
+	return _c
+}
+
+// SetGame sets the "game" edge to the Game entity.
+func (_c *ClanCreate) SetGame(v *Game) *ClanCreate {
+	return _c.SetGameID(v.ID)
+}
+
+// Mutation returns the ClanMutation object of the builder.
+func (_c *ClanCreate) Mutation() *ClanMutation {
+	return _c.mutation
+}
+
+// Save creates the Clan in the database.
+func (_c *ClanCreate) Save(ctx context.Context) (*Clan, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *ClanCreate) SaveX(ctx context.Context) *Clan {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *ClanCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *ClanCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *ClanCreate) defaults() {
+	if _, ok := _c.mutation.Name(); !ok {
+		v := clan.DefaultName
+		_c.mutation.SetName(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := clan.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.Active(); !ok {
+		v := clan.DefaultActive
+		_c.mutation.SetActive(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *ClanCreate) check() error {
+	if _, ok := _c.mutation.Number(); !ok {
+		return &ValidationError{Name: "number", err: errors.New(`ent: missing required field "Clan.number"`)}
+	}
+	if v, ok := _c.mutation.Number(); ok {
+		if err := clan.NumberValidator(v); err != nil {
+			return &ValidationError{Name: "number", err: fmt.Errorf(`ent: validator failed for field "Clan.number": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Clan.name"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Clan.created_at"`)}
+	}
+	if _, ok := _c.mutation.Active(); !ok {
+		return &ValidationError{Name: "active", err: errors.New(`ent: missing required field "Clan.active"`)}
+	}
+	return nil
+}
+
+func (_c *ClanCreate) sqlSave(ctx context.Context) (*Clan, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *ClanCreate) createSpec() (*Clan, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Clan{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(clan.Table, sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Number(); ok {
+		_spec.SetField(clan.FieldNumber, field.TypeInt, value)
+		_node.Number = value
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(clan.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(clan.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.Active(); ok {
+		_spec.SetField(clan.FieldActive, field.TypeBool, value)
+		_node.Active = value
+	}
+	if nodes := _c.mutation.UsersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   clan.UsersTable,
+			Columns: []string{clan.UsersColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.ReportsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   clan.ReportsTable,
+			Columns: []string{clan.ReportsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(turnreport.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.GameIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   clan.GameTable,
+			Columns: []string{clan.GameColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(game.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.game_clans = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ClanCreateBulk is the builder for creating many Clan entities in bulk.
+type ClanCreateBulk struct {
+	config
+	err      error
+	builders []*ClanCreate
+}
+
+// Save creates the Clan entities in the database.
+func (_c *ClanCreateBulk) Save(ctx context.Context) ([]*Clan, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Clan, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ClanMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *ClanCreateBulk) SaveX(ctx context.Context) []*Clan {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *ClanCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *ClanCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -12,7 +12,18 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/mdhender/ottomat/ent/apitoken"
+	"github.com/mdhender/ottomat/ent/auditevent"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/ent/loginchallenge"
+	"github.com/mdhender/ottomat/ent/loginthrottle"
+	"github.com/mdhender/ottomat/ent/passwordreset"
+	"github.com/mdhender/ottomat/ent/recoverycode"
 	"github.com/mdhender/ottomat/ent/session"
+	"github.com/mdhender/ottomat/ent/setting"
+	"github.com/mdhender/ottomat/ent/turn"
+	"github.com/mdhender/ottomat/ent/turnreport"
 	"github.com/mdhender/ottomat/ent/user"
 )
 
@@ -74,8 +85,19 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			session.Table: session.ValidColumn,
-			user.Table:    user.ValidColumn,
+			apitoken.Table:       apitoken.ValidColumn,
+			auditevent.Table:     auditevent.ValidColumn,
+			clan.Table:           clan.ValidColumn,
+			game.Table:           game.ValidColumn,
+			loginchallenge.Table: loginchallenge.ValidColumn,
+			loginthrottle.Table:  loginthrottle.ValidColumn,
+			passwordreset.Table:  passwordreset.ValidColumn,
+			recoverycode.Table:   recoverycode.ValidColumn,
+			session.Table:        session.ValidColumn,
+			setting.Table:        setting.ValidColumn,
+			turn.Table:           turn.ValidColumn,
+			turnreport.Table:     turnreport.ValidColumn,
+			user.Table:           user.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -0,0 +1,170 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/mdhender/ottomat/ent/game"
+)
+
+// Game is the model entity for the Game schema.
+type Game struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Code holds the value of the "code" field.
+	Code string `json:"code,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the GameQuery when eager-loading is set.
+	Edges        GameEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// GameEdges holds the relations/edges for other nodes in the graph.
+type GameEdges struct {
+	// Clans holds the value of the clans edge.
+	Clans []*Clan `json:"clans,omitempty"`
+	// Turns holds the value of the turns edge.
+	Turns []*Turn `json:"turns,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// ClansOrErr returns the Clans value or an error if the edge
+// was not loaded in eager-loading.
+func (e GameEdges) ClansOrErr() ([]*Clan, error) {
+	if e.loadedTypes[0] {
+		return e.Clans, nil
+	}
+	return nil, &NotLoadedError{edge: "clans"}
+}
+
+// TurnsOrErr returns the Turns value or an error if the edge
+// was not loaded in eager-loading.
+func (e GameEdges) TurnsOrErr() ([]*Turn, error) {
+	if e.loadedTypes[1] {
+		return e.Turns, nil
+	}
+	return nil, &NotLoadedError{edge: "turns"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Game) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case game.FieldID:
+			values[i] = new(sql.NullInt64)
+		case game.FieldCode, game.FieldName:
+			values[i] = new(sql.NullString)
+		case game.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Game fields.
+func (_m *Game) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case game.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case game.FieldCode:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field code", values[i])
+			} else if value.Valid {
+				_m.Code = value.String
+			}
+		case game.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case game.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Game.
+// This includes values selected through modifiers, order, etc.
+func (_m *Game) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryClans queries the "clans" edge of the Game entity.
+func (_m *Game) QueryClans() *ClanQuery {
+	return NewGameClient(_m.config).QueryClans(_m)
+}
+
+// QueryTurns queries the "turns" edge of the Game entity.
+func (_m *Game) QueryTurns() *TurnQuery {
+	return NewGameClient(_m.config).QueryTurns(_m)
+}
+
+// Update returns a builder for updating this Game.
+// Note that you need to call Game.Unwrap() before calling this method if this Game
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Game) Update() *GameUpdateOne {
+	return NewGameClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Game entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Game) Unwrap() *Game {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Game is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Game) String() string {
+	var builder strings.Builder
+	builder.WriteString("Game(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("code=")
+	builder.WriteString(_m.Code)
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Games is a parsable slice of Game.
+type Games []*Game
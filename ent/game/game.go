@@ -0,0 +1,135 @@
+// Code generated by ent, DO NOT EDIT.
+
+package game
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the game type in the database.
+	Label = "game"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldCode holds the string denoting the code field in the database.
+	FieldCode = "code"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// EdgeClans holds the string denoting the clans edge name in mutations.
+	EdgeClans = "clans"
+	// EdgeTurns holds the string denoting the turns edge name in mutations.
+	EdgeTurns = "turns"
+	// Table holds the table name of the game in the database.
+	Table = "games"
+	// ClansTable is the table that holds the clans relation/edge.
+	ClansTable = "clans"
+	// ClansInverseTable is the table name for the Clan entity.
+	// It exists in this package in order to avoid circular dependency with the "clan" package.
+	ClansInverseTable = "clans"
+	// ClansColumn is the table column denoting the clans relation/edge.
+	ClansColumn = "game_clans"
+	// TurnsTable is the table that holds the turns relation/edge.
+	TurnsTable = "turns"
+	// TurnsInverseTable is the table name for the Turn entity.
+	// It exists in this package in order to avoid circular dependency with the "turn" package.
+	TurnsInverseTable = "turns"
+	// TurnsColumn is the table column denoting the turns relation/edge.
+	TurnsColumn = "game_turns"
+)
+
+// Columns holds all SQL columns for game fields.
+var Columns = []string{
+	FieldID,
+	FieldCode,
+	FieldName,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// CodeValidator is a validator for the "code" field. It is called by the builders before save.
+	CodeValidator func(string) error
+	// DefaultName holds the default value on creation for the "name" field.
+	DefaultName string
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the Game queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByCode orders the results by the code field.
+func ByCode(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCode, opts...).ToFunc()
+}
+
+// ByName orders the results by the name field.
+func ByName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldName, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByClansCount orders the results by clans count.
+func ByClansCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newClansStep(), opts...)
+	}
+}
+
+// ByClans orders the results by clans terms.
+func ByClans(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newClansStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByTurnsCount orders the results by turns count.
+func ByTurnsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newTurnsStep(), opts...)
+	}
+}
+
+// ByTurns orders the results by turns terms.
+func ByTurns(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newTurnsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+func newClansStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ClansInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, ClansTable, ClansColumn),
+	)
+}
+func newTurnsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(TurnsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, TurnsTable, TurnsColumn),
+	)
+}
@@ -0,0 +1,595 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/ent/predicate"
+	"github.com/mdhender/ottomat/ent/turn"
+)
+
+// GameUpdate is the builder for updating Game entities.
+type GameUpdate struct {
+	config
+	hooks    []Hook
+	mutation *GameMutation
+}
+
+// Where appends a list predicates to the GameUpdate builder.
+func (_u *GameUpdate) Where(ps ...predicate.Game) *GameUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetCode sets the "code" field.
+func (_u *GameUpdate) SetCode(v string) *GameUpdate {
+	_u.mutation.SetCode(v)
+	return _u
+}
+
+// SetNillableCode sets the "code" field if the given value is not nil.
+func (_u *GameUpdate) SetNillableCode(v *string) *GameUpdate {
+	if v != nil {
+		_u.SetCode(*v)
+	}
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *GameUpdate) SetName(v string) *GameUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *GameUpdate) SetNillableName(v *string) *GameUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// AddClanIDs adds the "clans" edge to the Clan entity by IDs.
+func (_u *GameUpdate) AddClanIDs(ids ...int) *GameUpdate {
+	_u.mutation.AddClanIDs(ids...)
+	return _u
+}
+
+// AddClans adds the "clans" edges to the Clan entity.
+func (_u *GameUpdate) AddClans(v ...*Clan) *GameUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddClanIDs(ids...)
+}
+
+// AddTurnIDs adds the "turns" edge to the Turn entity by IDs.
+func (_u *GameUpdate) AddTurnIDs(ids ...int) *GameUpdate {
+	_u.mutation.AddTurnIDs(ids...)
+	return _u
+}
+
+// AddTurns adds the "turns" edges to the Turn entity.
+func (_u *GameUpdate) AddTurns(v ...*Turn) *GameUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTurnIDs(ids...)
+}
+
+// Mutation returns the GameMutation object of the builder.
+func (_u *GameUpdate) Mutation() *GameMutation {
+	return _u.mutation
+}
+
+// ClearClans clears all "clans" edges to the Clan entity.
+func (_u *GameUpdate) ClearClans() *GameUpdate {
+	_u.mutation.ClearClans()
+	return _u
+}
+
+// RemoveClanIDs removes the "clans" edge to Clan entities by IDs.
+func (_u *GameUpdate) RemoveClanIDs(ids ...int) *GameUpdate {
+	_u.mutation.RemoveClanIDs(ids...)
+	return _u
+}
+
+// RemoveClans removes "clans" edges to Clan entities.
+func (_u *GameUpdate) RemoveClans(v ...*Clan) *GameUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveClanIDs(ids...)
+}
+
+// ClearTurns clears all "turns" edges to the Turn entity.
+func (_u *GameUpdate) ClearTurns() *GameUpdate {
+	_u.mutation.ClearTurns()
+	return _u
+}
+
+// RemoveTurnIDs removes the "turns" edge to Turn entities by IDs.
+func (_u *GameUpdate) RemoveTurnIDs(ids ...int) *GameUpdate {
+	_u.mutation.RemoveTurnIDs(ids...)
+	return _u
+}
+
+// RemoveTurns removes "turns" edges to Turn entities.
+func (_u *GameUpdate) RemoveTurns(v ...*Turn) *GameUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveTurnIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *GameUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *GameUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *GameUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *GameUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *GameUpdate) check() error {
+	if v, ok := _u.mutation.Code(); ok {
+		if err := game.CodeValidator(v); err != nil {
+			return &ValidationError{Name: "code", err: fmt.Errorf(`ent: validator failed for field "Game.code": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *GameUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(game.Table, game.Columns, sqlgraph.NewFieldSpec(game.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Code(); ok {
+		_spec.SetField(game.FieldCode, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(game.FieldName, field.TypeString, value)
+	}
+	if _u.mutation.ClansCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.ClansTable,
+			Columns: []string{game.ClansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedClansIDs(); len(nodes) > 0 && !_u.mutation.ClansCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.ClansTable,
+			Columns: []string{game.ClansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ClansIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.ClansTable,
+			Columns: []string{game.ClansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TurnsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.TurnsTable,
+			Columns: []string{game.TurnsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(turn.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedTurnsIDs(); len(nodes) > 0 && !_u.mutation.TurnsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.TurnsTable,
+			Columns: []string{game.TurnsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(turn.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TurnsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.TurnsTable,
+			Columns: []string{game.TurnsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(turn.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{game.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// GameUpdateOne is the builder for updating a single Game entity.
+type GameUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *GameMutation
+}
+
+// SetCode sets the "code" field.
+func (_u *GameUpdateOne) SetCode(v string) *GameUpdateOne {
+	_u.mutation.SetCode(v)
+	return _u
+}
+
+// SetNillableCode sets the "code" field if the given value is not nil.
+func (_u *GameUpdateOne) SetNillableCode(v *string) *GameUpdateOne {
+	if v != nil {
+		_u.SetCode(*v)
+	}
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *GameUpdateOne) SetName(v string) *GameUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *GameUpdateOne) SetNillableName(v *string) *GameUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// AddClanIDs adds the "clans" edge to the Clan entity by IDs.
+func (_u *GameUpdateOne) AddClanIDs(ids ...int) *GameUpdateOne {
+	_u.mutation.AddClanIDs(ids...)
+	return _u
+}
+
+// AddClans adds the "clans" edges to the Clan entity.
+func (_u *GameUpdateOne) AddClans(v ...*Clan) *GameUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddClanIDs(ids...)
+}
+
+// AddTurnIDs adds the "turns" edge to the Turn entity by IDs.
+func (_u *GameUpdateOne) AddTurnIDs(ids ...int) *GameUpdateOne {
+	_u.mutation.AddTurnIDs(ids...)
+	return _u
+}
+
+// AddTurns adds the "turns" edges to the Turn entity.
+func (_u *GameUpdateOne) AddTurns(v ...*Turn) *GameUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTurnIDs(ids...)
+}
+
+// Mutation returns the GameMutation object of the builder.
+func (_u *GameUpdateOne) Mutation() *GameMutation {
+	return _u.mutation
+}
+
+// ClearClans clears all "clans" edges to the Clan entity.
+func (_u *GameUpdateOne) ClearClans() *GameUpdateOne {
+	_u.mutation.ClearClans()
+	return _u
+}
+
+// RemoveClanIDs removes the "clans" edge to Clan entities by IDs.
+func (_u *GameUpdateOne) RemoveClanIDs(ids ...int) *GameUpdateOne {
+	_u.mutation.RemoveClanIDs(ids...)
+	return _u
+}
+
+// RemoveClans removes "clans" edges to Clan entities.
+func (_u *GameUpdateOne) RemoveClans(v ...*Clan) *GameUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveClanIDs(ids...)
+}
+
+// ClearTurns clears all "turns" edges to the Turn entity.
+func (_u *GameUpdateOne) ClearTurns() *GameUpdateOne {
+	_u.mutation.ClearTurns()
+	return _u
+}
+
+// RemoveTurnIDs removes the "turns" edge to Turn entities by IDs.
+func (_u *GameUpdateOne) RemoveTurnIDs(ids ...int) *GameUpdateOne {
+	_u.mutation.RemoveTurnIDs(ids...)
+	return _u
+}
+
+// RemoveTurns removes "turns" edges to Turn entities.
+func (_u *GameUpdateOne) RemoveTurns(v ...*Turn) *GameUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveTurnIDs(ids...)
+}
+
+// Where appends a list predicates to the GameUpdate builder.
+func (_u *GameUpdateOne) Where(ps ...predicate.Game) *GameUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *GameUpdateOne) Select(field string, fields ...string) *GameUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Game entity.
+func (_u *GameUpdateOne) Save(ctx context.Context) (*Game, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *GameUpdateOne) SaveX(ctx context.Context) *Game {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *GameUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *GameUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *GameUpdateOne) check() error {
+	if v, ok := _u.mutation.Code(); ok {
+		if err := game.CodeValidator(v); err != nil {
+			return &ValidationError{Name: "code", err: fmt.Errorf(`ent: validator failed for field "Game.code": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *GameUpdateOne) sqlSave(ctx context.Context) (_node *Game, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(game.Table, game.Columns, sqlgraph.NewFieldSpec(game.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Game.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, game.FieldID)
+		for _, f := range fields {
+			if !game.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != game.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Code(); ok {
+		_spec.SetField(game.FieldCode, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(game.FieldName, field.TypeString, value)
+	}
+	if _u.mutation.ClansCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.ClansTable,
+			Columns: []string{game.ClansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedClansIDs(); len(nodes) > 0 && !_u.mutation.ClansCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.ClansTable,
+			Columns: []string{game.ClansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ClansIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.ClansTable,
+			Columns: []string{game.ClansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TurnsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.TurnsTable,
+			Columns: []string{game.TurnsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(turn.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedTurnsIDs(); len(nodes) > 0 && !_u.mutation.TurnsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.TurnsTable,
+			Columns: []string{game.TurnsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(turn.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TurnsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   game.TurnsTable,
+			Columns: []string{game.TurnsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(turn.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Game{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{game.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
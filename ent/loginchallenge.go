@@ -0,0 +1,179 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/mdhender/ottomat/ent/loginchallenge"
+	"github.com/mdhender/ottomat/ent/user"
+)
+
+// LoginChallenge is the model entity for the LoginChallenge schema.
+type LoginChallenge struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// TokenHash holds the value of the "token_hash" field.
+	TokenHash string `json:"-"`
+	// Remember holds the value of the "remember" field.
+	Remember bool `json:"remember,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the LoginChallengeQuery when eager-loading is set.
+	Edges                 LoginChallengeEdges `json:"edges"`
+	user_login_challenges *int
+	selectValues          sql.SelectValues
+}
+
+// LoginChallengeEdges holds the relations/edges for other nodes in the graph.
+type LoginChallengeEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e LoginChallengeEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*LoginChallenge) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case loginchallenge.FieldRemember:
+			values[i] = new(sql.NullBool)
+		case loginchallenge.FieldID:
+			values[i] = new(sql.NullInt64)
+		case loginchallenge.FieldTokenHash:
+			values[i] = new(sql.NullString)
+		case loginchallenge.FieldExpiresAt, loginchallenge.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case loginchallenge.ForeignKeys[0]: // user_login_challenges
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the LoginChallenge fields.
+func (_m *LoginChallenge) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case loginchallenge.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case loginchallenge.FieldTokenHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field token_hash", values[i])
+			} else if value.Valid {
+				_m.TokenHash = value.String
+			}
+		case loginchallenge.FieldRemember:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field remember", values[i])
+			} else if value.Valid {
+				_m.Remember = value.Bool
+			}
+		case loginchallenge.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				_m.ExpiresAt = value.Time
+			}
+		case loginchallenge.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case loginchallenge.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_login_challenges", value)
+			} else if value.Valid {
+				_m.user_login_challenges = new(int)
+				*_m.user_login_challenges = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the LoginChallenge.
+// This includes values selected through modifiers, order, etc.
+func (_m *LoginChallenge) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the LoginChallenge entity.
+func (_m *LoginChallenge) QueryUser() *UserQuery {
+	return NewLoginChallengeClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this LoginChallenge.
+// Note that you need to call LoginChallenge.Unwrap() before calling this method if this LoginChallenge
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *LoginChallenge) Update() *LoginChallengeUpdateOne {
+	return NewLoginChallengeClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the LoginChallenge entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *LoginChallenge) Unwrap() *LoginChallenge {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: LoginChallenge is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *LoginChallenge) String() string {
+	var builder strings.Builder
+	builder.WriteString("LoginChallenge(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("token_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("remember=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Remember))
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(_m.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// LoginChallenges is a parsable slice of LoginChallenge.
+type LoginChallenges []*LoginChallenge
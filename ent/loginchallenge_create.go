@@ -0,0 +1,290 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mdhender/ottomat/ent/loginchallenge"
+	"github.com/mdhender/ottomat/ent/user"
+)
+
+// LoginChallengeCreate is the builder for creating a LoginChallenge entity.
+type LoginChallengeCreate struct {
+	config
+	mutation *LoginChallengeMutation
+	hooks    []Hook
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (_c *LoginChallengeCreate) SetTokenHash(v string) *LoginChallengeCreate {
+	_c.mutation.SetTokenHash(v)
+	return _c
+}
+
+// SetRemember sets the "remember" field.
+func (_c *LoginChallengeCreate) SetRemember(v bool) *LoginChallengeCreate {
+	_c.mutation.SetRemember(v)
+	return _c
+}
+
+// SetNillableRemember sets the "remember" field if the given value is not nil.
+func (_c *LoginChallengeCreate) SetNillableRemember(v *bool) *LoginChallengeCreate {
+	if v != nil {
+		_c.SetRemember(*v)
+	}
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *LoginChallengeCreate) SetExpiresAt(v time.Time) *LoginChallengeCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *LoginChallengeCreate) SetCreatedAt(v time.Time) *LoginChallengeCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *LoginChallengeCreate) SetNillableCreatedAt(v *time.Time) *LoginChallengeCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_c *LoginChallengeCreate) SetUserID(id int) *LoginChallengeCreate {
+	_c.mutation.SetUserID(id)
+	return _c
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_c *LoginChallengeCreate) SetUser(v *User) *LoginChallengeCreate {
+	return _c.SetUserID(v.ID)
+}
+
+// Mutation returns the LoginChallengeMutation object of the builder.
+func (_c *LoginChallengeCreate) Mutation() *LoginChallengeMutation {
+	return _c.mutation
+}
+
+// Save creates the LoginChallenge in the database.
+func (_c *LoginChallengeCreate) Save(ctx context.Context) (*LoginChallenge, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *LoginChallengeCreate) SaveX(ctx context.Context) *LoginChallenge {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *LoginChallengeCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *LoginChallengeCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *LoginChallengeCreate) defaults() {
+	if _, ok := _c.mutation.Remember(); !ok {
+		v := loginchallenge.DefaultRemember
+		_c.mutation.SetRemember(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := loginchallenge.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *LoginChallengeCreate) check() error {
+	if _, ok := _c.mutation.TokenHash(); !ok {
+		return &ValidationError{Name: "token_hash", err: errors.New(`ent: missing required field "LoginChallenge.token_hash"`)}
+	}
+	if v, ok := _c.mutation.TokenHash(); ok {
+		if err := loginchallenge.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "LoginChallenge.token_hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Remember(); !ok {
+		return &ValidationError{Name: "remember", err: errors.New(`ent: missing required field "LoginChallenge.remember"`)}
+	}
+	if _, ok := _c.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "LoginChallenge.expires_at"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "LoginChallenge.created_at"`)}
+	}
+	if len(_c.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "LoginChallenge.user"`)}
+	}
+	return nil
+}
+
+func (_c *LoginChallengeCreate) sqlSave(ctx context.Context) (*LoginChallenge, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *LoginChallengeCreate) createSpec() (*LoginChallenge, *sqlgraph.CreateSpec) {
+	var (
+		_node = &LoginChallenge{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(loginchallenge.Table, sqlgraph.NewFieldSpec(loginchallenge.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.TokenHash(); ok {
+		_spec.SetField(loginchallenge.FieldTokenHash, field.TypeString, value)
+		_node.TokenHash = value
+	}
+	if value, ok := _c.mutation.Remember(); ok {
+		_spec.SetField(loginchallenge.FieldRemember, field.TypeBool, value)
+		_node.Remember = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(loginchallenge.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(loginchallenge.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginchallenge.UserTable,
+			Columns: []string{loginchallenge.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_login_challenges = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// LoginChallengeCreateBulk is the builder for creating many LoginChallenge entities in bulk.
+type LoginChallengeCreateBulk struct {
+	config
+	err      error
+	builders []*LoginChallengeCreate
+}
+
+// Save creates the LoginChallenge entities in the database.
+func (_c *LoginChallengeCreateBulk) Save(ctx context.Context) ([]*LoginChallenge, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*LoginChallenge, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*LoginChallengeMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *LoginChallengeCreateBulk) SaveX(ctx context.Context) []*LoginChallenge {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *LoginChallengeCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *LoginChallengeCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
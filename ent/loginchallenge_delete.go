@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mdhender/ottomat/ent/loginchallenge"
+	"github.com/mdhender/ottomat/ent/predicate"
+)
+
+// LoginChallengeDelete is the builder for deleting a LoginChallenge entity.
+type LoginChallengeDelete struct {
+	config
+	hooks    []Hook
+	mutation *LoginChallengeMutation
+}
+
+// Where appends a list predicates to the LoginChallengeDelete builder.
+func (_d *LoginChallengeDelete) Where(ps ...predicate.LoginChallenge) *LoginChallengeDelete {
+	_d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (_d *LoginChallengeDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *LoginChallengeDelete) ExecX(ctx context.Context) int {
+	n, err := _d.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (_d *LoginChallengeDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(loginchallenge.Table, sqlgraph.NewFieldSpec(loginchallenge.FieldID, field.TypeInt))
+	if ps := _d.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, _d.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	_d.mutation.done = true
+	return affected, err
+}
+
+// LoginChallengeDeleteOne is the builder for deleting a single LoginChallenge entity.
+type LoginChallengeDeleteOne struct {
+	_d *LoginChallengeDelete
+}
+
+// Where appends a list predicates to the LoginChallengeDelete builder.
+func (_d *LoginChallengeDeleteOne) Where(ps ...predicate.LoginChallenge) *LoginChallengeDeleteOne {
+	_d._d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query.
+func (_d *LoginChallengeDeleteOne) Exec(ctx context.Context) error {
+	n, err := _d._d.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{loginchallenge.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *LoginChallengeDeleteOne) ExecX(ctx context.Context) {
+	if err := _d.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,403 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mdhender/ottomat/ent/loginchallenge"
+	"github.com/mdhender/ottomat/ent/predicate"
+	"github.com/mdhender/ottomat/ent/user"
+)
+
+// LoginChallengeUpdate is the builder for updating LoginChallenge entities.
+type LoginChallengeUpdate struct {
+	config
+	hooks    []Hook
+	mutation *LoginChallengeMutation
+}
+
+// Where appends a list predicates to the LoginChallengeUpdate builder.
+func (_u *LoginChallengeUpdate) Where(ps ...predicate.LoginChallenge) *LoginChallengeUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (_u *LoginChallengeUpdate) SetTokenHash(v string) *LoginChallengeUpdate {
+	_u.mutation.SetTokenHash(v)
+	return _u
+}
+
+// SetNillableTokenHash sets the "token_hash" field if the given value is not nil.
+func (_u *LoginChallengeUpdate) SetNillableTokenHash(v *string) *LoginChallengeUpdate {
+	if v != nil {
+		_u.SetTokenHash(*v)
+	}
+	return _u
+}
+
+// SetRemember sets the "remember" field.
+func (_u *LoginChallengeUpdate) SetRemember(v bool) *LoginChallengeUpdate {
+	_u.mutation.SetRemember(v)
+	return _u
+}
+
+// SetNillableRemember sets the "remember" field if the given value is not nil.
+func (_u *LoginChallengeUpdate) SetNillableRemember(v *bool) *LoginChallengeUpdate {
+	if v != nil {
+		_u.SetRemember(*v)
+	}
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *LoginChallengeUpdate) SetExpiresAt(v time.Time) *LoginChallengeUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *LoginChallengeUpdate) SetNillableExpiresAt(v *time.Time) *LoginChallengeUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_u *LoginChallengeUpdate) SetUserID(id int) *LoginChallengeUpdate {
+	_u.mutation.SetUserID(id)
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *LoginChallengeUpdate) SetUser(v *User) *LoginChallengeUpdate {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the LoginChallengeMutation object of the builder.
+func (_u *LoginChallengeUpdate) Mutation() *LoginChallengeMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *LoginChallengeUpdate) ClearUser() *LoginChallengeUpdate {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *LoginChallengeUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *LoginChallengeUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *LoginChallengeUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *LoginChallengeUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *LoginChallengeUpdate) check() error {
+	if v, ok := _u.mutation.TokenHash(); ok {
+		if err := loginchallenge.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "LoginChallenge.token_hash": %w`, err)}
+		}
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "LoginChallenge.user"`)
+	}
+	return nil
+}
+
+func (_u *LoginChallengeUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(loginchallenge.Table, loginchallenge.Columns, sqlgraph.NewFieldSpec(loginchallenge.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.TokenHash(); ok {
+		_spec.SetField(loginchallenge.FieldTokenHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Remember(); ok {
+		_spec.SetField(loginchallenge.FieldRemember, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(loginchallenge.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginchallenge.UserTable,
+			Columns: []string{loginchallenge.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginchallenge.UserTable,
+			Columns: []string{loginchallenge.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{loginchallenge.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// LoginChallengeUpdateOne is the builder for updating a single LoginChallenge entity.
+type LoginChallengeUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *LoginChallengeMutation
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (_u *LoginChallengeUpdateOne) SetTokenHash(v string) *LoginChallengeUpdateOne {
+	_u.mutation.SetTokenHash(v)
+	return _u
+}
+
+// SetNillableTokenHash sets the "token_hash" field if the given value is not nil.
+func (_u *LoginChallengeUpdateOne) SetNillableTokenHash(v *string) *LoginChallengeUpdateOne {
+	if v != nil {
+		_u.SetTokenHash(*v)
+	}
+	return _u
+}
+
+// SetRemember sets the "remember" field.
+func (_u *LoginChallengeUpdateOne) SetRemember(v bool) *LoginChallengeUpdateOne {
+	_u.mutation.SetRemember(v)
+	return _u
+}
+
+// SetNillableRemember sets the "remember" field if the given value is not nil.
+func (_u *LoginChallengeUpdateOne) SetNillableRemember(v *bool) *LoginChallengeUpdateOne {
+	if v != nil {
+		_u.SetRemember(*v)
+	}
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *LoginChallengeUpdateOne) SetExpiresAt(v time.Time) *LoginChallengeUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *LoginChallengeUpdateOne) SetNillableExpiresAt(v *time.Time) *LoginChallengeUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_u *LoginChallengeUpdateOne) SetUserID(id int) *LoginChallengeUpdateOne {
+	_u.mutation.SetUserID(id)
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *LoginChallengeUpdateOne) SetUser(v *User) *LoginChallengeUpdateOne {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the LoginChallengeMutation object of the builder.
+func (_u *LoginChallengeUpdateOne) Mutation() *LoginChallengeMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *LoginChallengeUpdateOne) ClearUser() *LoginChallengeUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Where appends a list predicates to the LoginChallengeUpdate builder.
+func (_u *LoginChallengeUpdateOne) Where(ps ...predicate.LoginChallenge) *LoginChallengeUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *LoginChallengeUpdateOne) Select(field string, fields ...string) *LoginChallengeUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated LoginChallenge entity.
+func (_u *LoginChallengeUpdateOne) Save(ctx context.Context) (*LoginChallenge, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *LoginChallengeUpdateOne) SaveX(ctx context.Context) *LoginChallenge {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *LoginChallengeUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *LoginChallengeUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *LoginChallengeUpdateOne) check() error {
+	if v, ok := _u.mutation.TokenHash(); ok {
+		if err := loginchallenge.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "LoginChallenge.token_hash": %w`, err)}
+		}
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "LoginChallenge.user"`)
+	}
+	return nil
+}
+
+func (_u *LoginChallengeUpdateOne) sqlSave(ctx context.Context) (_node *LoginChallenge, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(loginchallenge.Table, loginchallenge.Columns, sqlgraph.NewFieldSpec(loginchallenge.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "LoginChallenge.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, loginchallenge.FieldID)
+		for _, f := range fields {
+			if !loginchallenge.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != loginchallenge.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.TokenHash(); ok {
+		_spec.SetField(loginchallenge.FieldTokenHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Remember(); ok {
+		_spec.SetField(loginchallenge.FieldRemember, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(loginchallenge.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginchallenge.UserTable,
+			Columns: []string{loginchallenge.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginchallenge.UserTable,
+			Columns: []string{loginchallenge.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &LoginChallenge{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{loginchallenge.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
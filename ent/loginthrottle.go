@@ -0,0 +1,153 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/mdhender/ottomat/ent/loginthrottle"
+)
+
+// LoginThrottle is the model entity for the LoginThrottle schema.
+type LoginThrottle struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind loginthrottle.Kind `json:"kind,omitempty"`
+	// Key holds the value of the "key" field.
+	Key string `json:"key,omitempty"`
+	// Failures holds the value of the "failures" field.
+	Failures int `json:"failures,omitempty"`
+	// LastFailureAt holds the value of the "last_failure_at" field.
+	LastFailureAt time.Time `json:"last_failure_at,omitempty"`
+	// LockedUntil holds the value of the "locked_until" field.
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*LoginThrottle) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case loginthrottle.FieldID, loginthrottle.FieldFailures:
+			values[i] = new(sql.NullInt64)
+		case loginthrottle.FieldKind, loginthrottle.FieldKey:
+			values[i] = new(sql.NullString)
+		case loginthrottle.FieldLastFailureAt, loginthrottle.FieldLockedUntil:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the LoginThrottle fields.
+func (_m *LoginThrottle) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case loginthrottle.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case loginthrottle.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				_m.Kind = loginthrottle.Kind(value.String)
+			}
+		case loginthrottle.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				_m.Key = value.String
+			}
+		case loginthrottle.FieldFailures:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field failures", values[i])
+			} else if value.Valid {
+				_m.Failures = int(value.Int64)
+			}
+		case loginthrottle.FieldLastFailureAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_failure_at", values[i])
+			} else if value.Valid {
+				_m.LastFailureAt = value.Time
+			}
+		case loginthrottle.FieldLockedUntil:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field locked_until", values[i])
+			} else if value.Valid {
+				_m.LockedUntil = new(time.Time)
+				*_m.LockedUntil = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the LoginThrottle.
+// This includes values selected through modifiers, order, etc.
+func (_m *LoginThrottle) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this LoginThrottle.
+// Note that you need to call LoginThrottle.Unwrap() before calling this method if this LoginThrottle
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *LoginThrottle) Update() *LoginThrottleUpdateOne {
+	return NewLoginThrottleClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the LoginThrottle entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *LoginThrottle) Unwrap() *LoginThrottle {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: LoginThrottle is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *LoginThrottle) String() string {
+	var builder strings.Builder
+	builder.WriteString("LoginThrottle(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("kind=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Kind))
+	builder.WriteString(", ")
+	builder.WriteString("key=")
+	builder.WriteString(_m.Key)
+	builder.WriteString(", ")
+	builder.WriteString("failures=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Failures))
+	builder.WriteString(", ")
+	builder.WriteString("last_failure_at=")
+	builder.WriteString(_m.LastFailureAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := _m.LockedUntil; v != nil {
+		builder.WriteString("locked_until=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// LoginThrottles is a parsable slice of LoginThrottle.
+type LoginThrottles []*LoginThrottle
@@ -0,0 +1,305 @@
+// Code generated by ent, DO NOT EDIT.
+
+package loginthrottle
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/mdhender/ottomat/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldLTE(FieldID, id))
+}
+
+// Key applies equality check predicate on the "key" field. It's identical to KeyEQ.
+func Key(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldKey, v))
+}
+
+// Failures applies equality check predicate on the "failures" field. It's identical to FailuresEQ.
+func Failures(v int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldFailures, v))
+}
+
+// LastFailureAt applies equality check predicate on the "last_failure_at" field. It's identical to LastFailureAtEQ.
+func LastFailureAt(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldLastFailureAt, v))
+}
+
+// LockedUntil applies equality check predicate on the "locked_until" field. It's identical to LockedUntilEQ.
+func LockedUntil(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldLockedUntil, v))
+}
+
+// KindEQ applies the EQ predicate on the "kind" field.
+func KindEQ(v Kind) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldKind, v))
+}
+
+// KindNEQ applies the NEQ predicate on the "kind" field.
+func KindNEQ(v Kind) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNEQ(FieldKind, v))
+}
+
+// KindIn applies the In predicate on the "kind" field.
+func KindIn(vs ...Kind) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldIn(FieldKind, vs...))
+}
+
+// KindNotIn applies the NotIn predicate on the "kind" field.
+func KindNotIn(vs ...Kind) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNotIn(FieldKind, vs...))
+}
+
+// KeyEQ applies the EQ predicate on the "key" field.
+func KeyEQ(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldKey, v))
+}
+
+// KeyNEQ applies the NEQ predicate on the "key" field.
+func KeyNEQ(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNEQ(FieldKey, v))
+}
+
+// KeyIn applies the In predicate on the "key" field.
+func KeyIn(vs ...string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldIn(FieldKey, vs...))
+}
+
+// KeyNotIn applies the NotIn predicate on the "key" field.
+func KeyNotIn(vs ...string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNotIn(FieldKey, vs...))
+}
+
+// KeyGT applies the GT predicate on the "key" field.
+func KeyGT(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldGT(FieldKey, v))
+}
+
+// KeyGTE applies the GTE predicate on the "key" field.
+func KeyGTE(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldGTE(FieldKey, v))
+}
+
+// KeyLT applies the LT predicate on the "key" field.
+func KeyLT(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldLT(FieldKey, v))
+}
+
+// KeyLTE applies the LTE predicate on the "key" field.
+func KeyLTE(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldLTE(FieldKey, v))
+}
+
+// KeyContains applies the Contains predicate on the "key" field.
+func KeyContains(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldContains(FieldKey, v))
+}
+
+// KeyHasPrefix applies the HasPrefix predicate on the "key" field.
+func KeyHasPrefix(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldHasPrefix(FieldKey, v))
+}
+
+// KeyHasSuffix applies the HasSuffix predicate on the "key" field.
+func KeyHasSuffix(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldHasSuffix(FieldKey, v))
+}
+
+// KeyEqualFold applies the EqualFold predicate on the "key" field.
+func KeyEqualFold(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEqualFold(FieldKey, v))
+}
+
+// KeyContainsFold applies the ContainsFold predicate on the "key" field.
+func KeyContainsFold(v string) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldContainsFold(FieldKey, v))
+}
+
+// FailuresEQ applies the EQ predicate on the "failures" field.
+func FailuresEQ(v int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldFailures, v))
+}
+
+// FailuresNEQ applies the NEQ predicate on the "failures" field.
+func FailuresNEQ(v int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNEQ(FieldFailures, v))
+}
+
+// FailuresIn applies the In predicate on the "failures" field.
+func FailuresIn(vs ...int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldIn(FieldFailures, vs...))
+}
+
+// FailuresNotIn applies the NotIn predicate on the "failures" field.
+func FailuresNotIn(vs ...int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNotIn(FieldFailures, vs...))
+}
+
+// FailuresGT applies the GT predicate on the "failures" field.
+func FailuresGT(v int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldGT(FieldFailures, v))
+}
+
+// FailuresGTE applies the GTE predicate on the "failures" field.
+func FailuresGTE(v int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldGTE(FieldFailures, v))
+}
+
+// FailuresLT applies the LT predicate on the "failures" field.
+func FailuresLT(v int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldLT(FieldFailures, v))
+}
+
+// FailuresLTE applies the LTE predicate on the "failures" field.
+func FailuresLTE(v int) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldLTE(FieldFailures, v))
+}
+
+// LastFailureAtEQ applies the EQ predicate on the "last_failure_at" field.
+func LastFailureAtEQ(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldLastFailureAt, v))
+}
+
+// LastFailureAtNEQ applies the NEQ predicate on the "last_failure_at" field.
+func LastFailureAtNEQ(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNEQ(FieldLastFailureAt, v))
+}
+
+// LastFailureAtIn applies the In predicate on the "last_failure_at" field.
+func LastFailureAtIn(vs ...time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldIn(FieldLastFailureAt, vs...))
+}
+
+// LastFailureAtNotIn applies the NotIn predicate on the "last_failure_at" field.
+func LastFailureAtNotIn(vs ...time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNotIn(FieldLastFailureAt, vs...))
+}
+
+// LastFailureAtGT applies the GT predicate on the "last_failure_at" field.
+func LastFailureAtGT(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldGT(FieldLastFailureAt, v))
+}
+
+// LastFailureAtGTE applies the GTE predicate on the "last_failure_at" field.
+func LastFailureAtGTE(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldGTE(FieldLastFailureAt, v))
+}
+
+// LastFailureAtLT applies the LT predicate on the "last_failure_at" field.
+func LastFailureAtLT(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldLT(FieldLastFailureAt, v))
+}
+
+// LastFailureAtLTE applies the LTE predicate on the "last_failure_at" field.
+func LastFailureAtLTE(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldLTE(FieldLastFailureAt, v))
+}
+
+// LockedUntilEQ applies the EQ predicate on the "locked_until" field.
+func LockedUntilEQ(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldEQ(FieldLockedUntil, v))
+}
+
+// LockedUntilNEQ applies the NEQ predicate on the "locked_until" field.
+func LockedUntilNEQ(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNEQ(FieldLockedUntil, v))
+}
+
+// LockedUntilIn applies the In predicate on the "locked_until" field.
+func LockedUntilIn(vs ...time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldIn(FieldLockedUntil, vs...))
+}
+
+// LockedUntilNotIn applies the NotIn predicate on the "locked_until" field.
+func LockedUntilNotIn(vs ...time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNotIn(FieldLockedUntil, vs...))
+}
+
+// LockedUntilGT applies the GT predicate on the "locked_until" field.
+func LockedUntilGT(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldGT(FieldLockedUntil, v))
+}
+
+// LockedUntilGTE applies the GTE predicate on the "locked_until" field.
+func LockedUntilGTE(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldGTE(FieldLockedUntil, v))
+}
+
+// LockedUntilLT applies the LT predicate on the "locked_until" field.
+func LockedUntilLT(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldLT(FieldLockedUntil, v))
+}
+
+// LockedUntilLTE applies the LTE predicate on the "locked_until" field.
+func LockedUntilLTE(v time.Time) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldLTE(FieldLockedUntil, v))
+}
+
+// LockedUntilIsNil applies the IsNil predicate on the "locked_until" field.
+func LockedUntilIsNil() predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldIsNull(FieldLockedUntil))
+}
+
+// LockedUntilNotNil applies the NotNil predicate on the "locked_until" field.
+func LockedUntilNotNil() predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.FieldNotNull(FieldLockedUntil))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.LoginThrottle) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.LoginThrottle) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.LoginThrottle) predicate.LoginThrottle {
+	return predicate.LoginThrottle(sql.NotPredicates(p))
+}
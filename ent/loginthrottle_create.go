@@ -0,0 +1,274 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mdhender/ottomat/ent/loginthrottle"
+)
+
+// LoginThrottleCreate is the builder for creating a LoginThrottle entity.
+type LoginThrottleCreate struct {
+	config
+	mutation *LoginThrottleMutation
+	hooks    []Hook
+}
+
+// SetKind sets the "kind" field.
+func (_c *LoginThrottleCreate) SetKind(v loginthrottle.Kind) *LoginThrottleCreate {
+	_c.mutation.SetKind(v)
+	return _c
+}
+
+// SetKey sets the "key" field.
+func (_c *LoginThrottleCreate) SetKey(v string) *LoginThrottleCreate {
+	_c.mutation.SetKey(v)
+	return _c
+}
+
+// SetFailures sets the "failures" field.
+func (_c *LoginThrottleCreate) SetFailures(v int) *LoginThrottleCreate {
+	_c.mutation.SetFailures(v)
+	return _c
+}
+
+// SetNillableFailures sets the "failures" field if the given value is not nil.
+func (_c *LoginThrottleCreate) SetNillableFailures(v *int) *LoginThrottleCreate {
+	if v != nil {
+		_c.SetFailures(*v)
+	}
+	return _c
+}
+
+// SetLastFailureAt sets the "last_failure_at" field.
+func (_c *LoginThrottleCreate) SetLastFailureAt(v time.Time) *LoginThrottleCreate {
+	_c.mutation.SetLastFailureAt(v)
+	return _c
+}
+
+// SetLockedUntil sets the "locked_until" field.
+func (_c *LoginThrottleCreate) SetLockedUntil(v time.Time) *LoginThrottleCreate {
+	_c.mutation.SetLockedUntil(v)
+	return _c
+}
+
+// SetNillableLockedUntil sets the "locked_until" field if the given value is not nil.
+func (_c *LoginThrottleCreate) SetNillableLockedUntil(v *time.Time) *LoginThrottleCreate {
+	if v != nil {
+		_c.SetLockedUntil(*v)
+	}
+	return _c
+}
+
+// Mutation returns the LoginThrottleMutation object of the builder.
+func (_c *LoginThrottleCreate) Mutation() *LoginThrottleMutation {
+	return _c.mutation
+}
+
+// Save creates the LoginThrottle in the database.
+func (_c *LoginThrottleCreate) Save(ctx context.Context) (*LoginThrottle, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *LoginThrottleCreate) SaveX(ctx context.Context) *LoginThrottle {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *LoginThrottleCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *LoginThrottleCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *LoginThrottleCreate) defaults() {
+	if _, ok := _c.mutation.Failures(); !ok {
+		v := loginthrottle.DefaultFailures
+		_c.mutation.SetFailures(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *LoginThrottleCreate) check() error {
+	if _, ok := _c.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "LoginThrottle.kind"`)}
+	}
+	if v, ok := _c.mutation.Kind(); ok {
+		if err := loginthrottle.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "LoginThrottle.kind": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "LoginThrottle.key"`)}
+	}
+	if v, ok := _c.mutation.Key(); ok {
+		if err := loginthrottle.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "LoginThrottle.key": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Failures(); !ok {
+		return &ValidationError{Name: "failures", err: errors.New(`ent: missing required field "LoginThrottle.failures"`)}
+	}
+	if v, ok := _c.mutation.Failures(); ok {
+		if err := loginthrottle.FailuresValidator(v); err != nil {
+			return &ValidationError{Name: "failures", err: fmt.Errorf(`ent: validator failed for field "LoginThrottle.failures": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.LastFailureAt(); !ok {
+		return &ValidationError{Name: "last_failure_at", err: errors.New(`ent: missing required field "LoginThrottle.last_failure_at"`)}
+	}
+	return nil
+}
+
+func (_c *LoginThrottleCreate) sqlSave(ctx context.Context) (*LoginThrottle, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *LoginThrottleCreate) createSpec() (*LoginThrottle, *sqlgraph.CreateSpec) {
+	var (
+		_node = &LoginThrottle{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(loginthrottle.Table, sqlgraph.NewFieldSpec(loginthrottle.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Kind(); ok {
+		_spec.SetField(loginthrottle.FieldKind, field.TypeEnum, value)
+		_node.Kind = value
+	}
+	if value, ok := _c.mutation.Key(); ok {
+		_spec.SetField(loginthrottle.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := _c.mutation.Failures(); ok {
+		_spec.SetField(loginthrottle.FieldFailures, field.TypeInt, value)
+		_node.Failures = value
+	}
+	if value, ok := _c.mutation.LastFailureAt(); ok {
+		_spec.SetField(loginthrottle.FieldLastFailureAt, field.TypeTime, value)
+		_node.LastFailureAt = value
+	}
+	if value, ok := _c.mutation.LockedUntil(); ok {
+		_spec.SetField(loginthrottle.FieldLockedUntil, field.TypeTime, value)
+		_node.LockedUntil = &value
+	}
+	return _node, _spec
+}
+
+// LoginThrottleCreateBulk is the builder for creating many LoginThrottle entities in bulk.
+type LoginThrottleCreateBulk struct {
+	config
+	err      error
+	builders []*LoginThrottleCreate
+}
+
+// Save creates the LoginThrottle entities in the database.
+func (_c *LoginThrottleCreateBulk) Save(ctx context.Context) ([]*LoginThrottle, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*LoginThrottle, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*LoginThrottleMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *LoginThrottleCreateBulk) SaveX(ctx context.Context) []*LoginThrottle {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *LoginThrottleCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *LoginThrottleCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
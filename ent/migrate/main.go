@@ -0,0 +1,64 @@
+//go:build ignore
+
+// Generates a new versioned migration from the Ent schema.
+//
+//	go run -mod=mod ent/migrate/main.go <name>
+//
+// Atlas does not write a usable down file when it has to rebuild a SQLite
+// table. Fix those by hand and then update atlas.sum with
+//
+//	go run -mod=mod ent/migrate/main.go --hash
+//
+// The diff is computed by replaying the existing migrations into an in-memory
+// database and comparing the result to the current schema, so the new files
+// only contain the changes since the last migration.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+
+	atlas "ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqltool"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/mdhender/ottomat/ent/migrate"
+	"modernc.org/sqlite"
+)
+
+func init() {
+	// Atlas looks up the SQLite driver by the name used by mattn/go-sqlite3.
+	sql.Register("sqlite3", &sqlite.Driver{})
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatalln("migration name is required. Use: 'go run -mod=mod ent/migrate/main.go <name>'")
+	}
+	dir, err := sqltool.NewGolangMigrateDir("internal/database/migrations")
+	if err != nil {
+		log.Fatalf("failed creating atlas migration directory: %v", err)
+	}
+	if os.Args[1] == "--hash" {
+		sum, err := dir.Checksum()
+		if err != nil {
+			log.Fatalf("failed computing checksum: %v", err)
+		}
+		if err := atlas.WriteSumFile(dir, sum); err != nil {
+			log.Fatalf("failed writing atlas.sum: %v", err)
+		}
+		return
+	}
+	opts := []schema.MigrateOption{
+		schema.WithDir(dir),
+		schema.WithMigrationMode(schema.ModeReplay),
+		schema.WithDialect(dialect.SQLite),
+		schema.WithFormatter(sqltool.GolangMigrateFormatter),
+	}
+	err = migrate.NamedDiff(context.Background(), "sqlite://file?mode=memory&_pragma=foreign_keys(1)", os.Args[1], opts...)
+	if err != nil {
+		log.Fatalf("failed generating migration file: %v", err)
+	}
+}
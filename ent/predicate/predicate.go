@@ -6,8 +6,41 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// APIToken is the predicate function for apitoken builders.
+type APIToken func(*sql.Selector)
+
+// AuditEvent is the predicate function for auditevent builders.
+type AuditEvent func(*sql.Selector)
+
+// Clan is the predicate function for clan builders.
+type Clan func(*sql.Selector)
+
+// Game is the predicate function for game builders.
+type Game func(*sql.Selector)
+
+// LoginChallenge is the predicate function for loginchallenge builders.
+type LoginChallenge func(*sql.Selector)
+
+// LoginThrottle is the predicate function for loginthrottle builders.
+type LoginThrottle func(*sql.Selector)
+
+// PasswordReset is the predicate function for passwordreset builders.
+type PasswordReset func(*sql.Selector)
+
+// RecoveryCode is the predicate function for recoverycode builders.
+type RecoveryCode func(*sql.Selector)
+
 // Session is the predicate function for session builders.
 type Session func(*sql.Selector)
 
+// Setting is the predicate function for setting builders.
+type Setting func(*sql.Selector)
+
+// Turn is the predicate function for turn builders.
+type Turn func(*sql.Selector)
+
+// TurnReport is the predicate function for turnreport builders.
+type TurnReport func(*sql.Selector)
+
 // User is the predicate function for user builders.
 type User func(*sql.Selector)
@@ -5,8 +5,19 @@ package ent
 import (
 	"time"
 
+	"github.com/mdhender/ottomat/ent/apitoken"
+	"github.com/mdhender/ottomat/ent/auditevent"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/ent/loginchallenge"
+	"github.com/mdhender/ottomat/ent/loginthrottle"
+	"github.com/mdhender/ottomat/ent/passwordreset"
+	"github.com/mdhender/ottomat/ent/recoverycode"
 	"github.com/mdhender/ottomat/ent/schema"
 	"github.com/mdhender/ottomat/ent/session"
+	"github.com/mdhender/ottomat/ent/setting"
+	"github.com/mdhender/ottomat/ent/turn"
+	"github.com/mdhender/ottomat/ent/turnreport"
 	"github.com/mdhender/ottomat/ent/user"
 )
 
@@ -14,16 +25,194 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	apitokenFields := schema.APIToken{}.Fields()
+	_ = apitokenFields
+	// apitokenDescName is the schema descriptor for name field.
+	apitokenDescName := apitokenFields[0].Descriptor()
+	// apitoken.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	apitoken.NameValidator = apitokenDescName.Validators[0].(func(string) error)
+	// apitokenDescTokenHash is the schema descriptor for token_hash field.
+	apitokenDescTokenHash := apitokenFields[1].Descriptor()
+	// apitoken.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	apitoken.TokenHashValidator = apitokenDescTokenHash.Validators[0].(func(string) error)
+	// apitokenDescPrefix is the schema descriptor for prefix field.
+	apitokenDescPrefix := apitokenFields[2].Descriptor()
+	// apitoken.PrefixValidator is a validator for the "prefix" field. It is called by the builders before save.
+	apitoken.PrefixValidator = apitokenDescPrefix.Validators[0].(func(string) error)
+	// apitokenDescCreatedAt is the schema descriptor for created_at field.
+	apitokenDescCreatedAt := apitokenFields[6].Descriptor()
+	// apitoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	apitoken.DefaultCreatedAt = apitokenDescCreatedAt.Default.(func() time.Time)
+	auditeventFields := schema.AuditEvent{}.Fields()
+	_ = auditeventFields
+	// auditeventDescCreatedAt is the schema descriptor for created_at field.
+	auditeventDescCreatedAt := auditeventFields[0].Descriptor()
+	// auditevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditevent.DefaultCreatedAt = auditeventDescCreatedAt.Default.(func() time.Time)
+	// auditeventDescAction is the schema descriptor for action field.
+	auditeventDescAction := auditeventFields[1].Descriptor()
+	// auditevent.ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	auditevent.ActionValidator = auditeventDescAction.Validators[0].(func(string) error)
+	// auditeventDescActor is the schema descriptor for actor field.
+	auditeventDescActor := auditeventFields[2].Descriptor()
+	// auditevent.DefaultActor holds the default value on creation for the actor field.
+	auditevent.DefaultActor = auditeventDescActor.Default.(string)
+	// auditeventDescTarget is the schema descriptor for target field.
+	auditeventDescTarget := auditeventFields[3].Descriptor()
+	// auditevent.DefaultTarget holds the default value on creation for the target field.
+	auditevent.DefaultTarget = auditeventDescTarget.Default.(string)
+	// auditeventDescDetail is the schema descriptor for detail field.
+	auditeventDescDetail := auditeventFields[4].Descriptor()
+	// auditevent.DefaultDetail holds the default value on creation for the detail field.
+	auditevent.DefaultDetail = auditeventDescDetail.Default.(string)
+	// auditeventDescIP is the schema descriptor for ip field.
+	auditeventDescIP := auditeventFields[5].Descriptor()
+	// auditevent.DefaultIP holds the default value on creation for the ip field.
+	auditevent.DefaultIP = auditeventDescIP.Default.(string)
+	clanFields := schema.Clan{}.Fields()
+	_ = clanFields
+	// clanDescNumber is the schema descriptor for number field.
+	clanDescNumber := clanFields[0].Descriptor()
+	// clan.NumberValidator is a validator for the "number" field. It is called by the builders before save.
+	clan.NumberValidator = clanDescNumber.Validators[0].(func(int) error)
+	// clanDescName is the schema descriptor for name field.
+	clanDescName := clanFields[1].Descriptor()
+	// clan.DefaultName holds the default value on creation for the name field.
+	clan.DefaultName = clanDescName.Default.(string)
+	// clanDescCreatedAt is the schema descriptor for created_at field.
+	clanDescCreatedAt := clanFields[2].Descriptor()
+	// clan.DefaultCreatedAt holds the default value on creation for the created_at field.
+	clan.DefaultCreatedAt = clanDescCreatedAt.Default.(func() time.Time)
+	// clanDescActive is the schema descriptor for active field.
+	clanDescActive := clanFields[3].Descriptor()
+	// clan.DefaultActive holds the default value on creation for the active field.
+	clan.DefaultActive = clanDescActive.Default.(bool)
+	gameFields := schema.Game{}.Fields()
+	_ = gameFields
+	// gameDescCode is the schema descriptor for code field.
+	gameDescCode := gameFields[0].Descriptor()
+	// game.CodeValidator is a validator for the "code" field. It is called by the builders before save.
+	game.CodeValidator = gameDescCode.Validators[0].(func(string) error)
+	// gameDescName is the schema descriptor for name field.
+	gameDescName := gameFields[1].Descriptor()
+	// game.DefaultName holds the default value on creation for the name field.
+	game.DefaultName = gameDescName.Default.(string)
+	// gameDescCreatedAt is the schema descriptor for created_at field.
+	gameDescCreatedAt := gameFields[2].Descriptor()
+	// game.DefaultCreatedAt holds the default value on creation for the created_at field.
+	game.DefaultCreatedAt = gameDescCreatedAt.Default.(func() time.Time)
+	loginchallengeFields := schema.LoginChallenge{}.Fields()
+	_ = loginchallengeFields
+	// loginchallengeDescTokenHash is the schema descriptor for token_hash field.
+	loginchallengeDescTokenHash := loginchallengeFields[0].Descriptor()
+	// loginchallenge.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	loginchallenge.TokenHashValidator = loginchallengeDescTokenHash.Validators[0].(func(string) error)
+	// loginchallengeDescRemember is the schema descriptor for remember field.
+	loginchallengeDescRemember := loginchallengeFields[1].Descriptor()
+	// loginchallenge.DefaultRemember holds the default value on creation for the remember field.
+	loginchallenge.DefaultRemember = loginchallengeDescRemember.Default.(bool)
+	// loginchallengeDescCreatedAt is the schema descriptor for created_at field.
+	loginchallengeDescCreatedAt := loginchallengeFields[3].Descriptor()
+	// loginchallenge.DefaultCreatedAt holds the default value on creation for the created_at field.
+	loginchallenge.DefaultCreatedAt = loginchallengeDescCreatedAt.Default.(func() time.Time)
+	loginthrottleFields := schema.LoginThrottle{}.Fields()
+	_ = loginthrottleFields
+	// loginthrottleDescKey is the schema descriptor for key field.
+	loginthrottleDescKey := loginthrottleFields[1].Descriptor()
+	// loginthrottle.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	loginthrottle.KeyValidator = loginthrottleDescKey.Validators[0].(func(string) error)
+	// loginthrottleDescFailures is the schema descriptor for failures field.
+	loginthrottleDescFailures := loginthrottleFields[2].Descriptor()
+	// loginthrottle.DefaultFailures holds the default value on creation for the failures field.
+	loginthrottle.DefaultFailures = loginthrottleDescFailures.Default.(int)
+	// loginthrottle.FailuresValidator is a validator for the "failures" field. It is called by the builders before save.
+	loginthrottle.FailuresValidator = loginthrottleDescFailures.Validators[0].(func(int) error)
+	passwordresetFields := schema.PasswordReset{}.Fields()
+	_ = passwordresetFields
+	// passwordresetDescTokenHash is the schema descriptor for token_hash field.
+	passwordresetDescTokenHash := passwordresetFields[0].Descriptor()
+	// passwordreset.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	passwordreset.TokenHashValidator = passwordresetDescTokenHash.Validators[0].(func(string) error)
+	// passwordresetDescCreatedAt is the schema descriptor for created_at field.
+	passwordresetDescCreatedAt := passwordresetFields[3].Descriptor()
+	// passwordreset.DefaultCreatedAt holds the default value on creation for the created_at field.
+	passwordreset.DefaultCreatedAt = passwordresetDescCreatedAt.Default.(func() time.Time)
+	recoverycodeFields := schema.RecoveryCode{}.Fields()
+	_ = recoverycodeFields
+	// recoverycodeDescCodeHash is the schema descriptor for code_hash field.
+	recoverycodeDescCodeHash := recoverycodeFields[0].Descriptor()
+	// recoverycode.CodeHashValidator is a validator for the "code_hash" field. It is called by the builders before save.
+	recoverycode.CodeHashValidator = recoverycodeDescCodeHash.Validators[0].(func(string) error)
+	// recoverycodeDescCreatedAt is the schema descriptor for created_at field.
+	recoverycodeDescCreatedAt := recoverycodeFields[2].Descriptor()
+	// recoverycode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	recoverycode.DefaultCreatedAt = recoverycodeDescCreatedAt.Default.(func() time.Time)
 	sessionFields := schema.Session{}.Fields()
 	_ = sessionFields
 	// sessionDescToken is the schema descriptor for token field.
 	sessionDescToken := sessionFields[0].Descriptor()
 	// session.TokenValidator is a validator for the "token" field. It is called by the builders before save.
 	session.TokenValidator = sessionDescToken.Validators[0].(func(string) error)
+	// sessionDescCsrfToken is the schema descriptor for csrf_token field.
+	sessionDescCsrfToken := sessionFields[1].Descriptor()
+	// session.DefaultCsrfToken holds the default value on creation for the csrf_token field.
+	session.DefaultCsrfToken = sessionDescCsrfToken.Default.(string)
 	// sessionDescCreatedAt is the schema descriptor for created_at field.
-	sessionDescCreatedAt := sessionFields[2].Descriptor()
+	sessionDescCreatedAt := sessionFields[3].Descriptor()
 	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
 	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() time.Time)
+	// sessionDescIP is the schema descriptor for ip field.
+	sessionDescIP := sessionFields[5].Descriptor()
+	// session.DefaultIP holds the default value on creation for the ip field.
+	session.DefaultIP = sessionDescIP.Default.(string)
+	// sessionDescUserAgent is the schema descriptor for user_agent field.
+	sessionDescUserAgent := sessionFields[6].Descriptor()
+	// session.DefaultUserAgent holds the default value on creation for the user_agent field.
+	session.DefaultUserAgent = sessionDescUserAgent.Default.(string)
+	// sessionDescRemember is the schema descriptor for remember field.
+	sessionDescRemember := sessionFields[7].Descriptor()
+	// session.DefaultRemember holds the default value on creation for the remember field.
+	session.DefaultRemember = sessionDescRemember.Default.(bool)
+	settingFields := schema.Setting{}.Fields()
+	_ = settingFields
+	// settingDescKey is the schema descriptor for key field.
+	settingDescKey := settingFields[0].Descriptor()
+	// setting.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	setting.KeyValidator = settingDescKey.Validators[0].(func(string) error)
+	// settingDescUpdatedAt is the schema descriptor for updated_at field.
+	settingDescUpdatedAt := settingFields[2].Descriptor()
+	// setting.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	setting.DefaultUpdatedAt = settingDescUpdatedAt.Default.(func() time.Time)
+	// setting.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	setting.UpdateDefaultUpdatedAt = settingDescUpdatedAt.UpdateDefault.(func() time.Time)
+	turnFields := schema.Turn{}.Fields()
+	_ = turnFields
+	// turnDescTurnID is the schema descriptor for turn_id field.
+	turnDescTurnID := turnFields[0].Descriptor()
+	// turn.TurnIDValidator is a validator for the "turn_id" field. It is called by the builders before save.
+	turn.TurnIDValidator = turnDescTurnID.Validators[0].(func(string) error)
+	// turnDescCreatedAt is the schema descriptor for created_at field.
+	turnDescCreatedAt := turnFields[3].Descriptor()
+	// turn.DefaultCreatedAt holds the default value on creation for the created_at field.
+	turn.DefaultCreatedAt = turnDescCreatedAt.Default.(func() time.Time)
+	turnreportFields := schema.TurnReport{}.Fields()
+	_ = turnreportFields
+	// turnreportDescTurnID is the schema descriptor for turn_id field.
+	turnreportDescTurnID := turnreportFields[0].Descriptor()
+	// turnreport.TurnIDValidator is a validator for the "turn_id" field. It is called by the builders before save.
+	turnreport.TurnIDValidator = turnreportDescTurnID.Validators[0].(func(string) error)
+	// turnreportDescOriginalFilename is the schema descriptor for original_filename field.
+	turnreportDescOriginalFilename := turnreportFields[1].Descriptor()
+	// turnreport.OriginalFilenameValidator is a validator for the "original_filename" field. It is called by the builders before save.
+	turnreport.OriginalFilenameValidator = turnreportDescOriginalFilename.Validators[0].(func(string) error)
+	// turnreportDescSha256 is the schema descriptor for sha256 field.
+	turnreportDescSha256 := turnreportFields[3].Descriptor()
+	// turnreport.Sha256Validator is a validator for the "sha256" field. It is called by the builders before save.
+	turnreport.Sha256Validator = turnreportDescSha256.Validators[0].(func(string) error)
+	// turnreportDescUploadedAt is the schema descriptor for uploaded_at field.
+	turnreportDescUploadedAt := turnreportFields[4].Descriptor()
+	// turnreport.DefaultUploadedAt holds the default value on creation for the uploaded_at field.
+	turnreport.DefaultUploadedAt = turnreportDescUploadedAt.Default.(func() time.Time)
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescUsername is the schema descriptor for username field.
@@ -40,4 +229,8 @@ func init() {
 	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
 	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
 	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// userDescTotpLastStep is the schema descriptor for totp_last_step field.
+	userDescTotpLastStep := userFields[8].Descriptor()
+	// user.DefaultTotpLastStep holds the default value on creation for the totp_last_step field.
+	user.DefaultTotpLastStep = userDescTotpLastStep.Default.(int64)
 }
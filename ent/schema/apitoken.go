@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"time"
+)
+
+// APIToken holds the schema definition for the APIToken entity.
+// Users create tokens so that scripts can call the JSON API without a
+// browser session. Only the hash of the token is stored.
+type APIToken struct {
+	ent.Schema
+}
+
+// Fields of the APIToken.
+func (APIToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty(),
+		field.String("token_hash").
+			Unique().
+			NotEmpty().
+			Sensitive(),
+		field.String("prefix").
+			NotEmpty(),
+		field.Strings("scopes"),
+		field.Time("expires_at").
+			Optional().
+			Nillable(),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the APIToken.
+func (APIToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("api_tokens").
+			Unique().
+			Required(),
+	}
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditEvent holds the schema definition for the AuditEvent entity. It
+// records a security or admin action: who did it, to whom, and from where.
+// Users are stored by name rather than by edge so that events outlive the
+// users they mention.
+type AuditEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuditEvent.
+func (AuditEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.String("action").
+			NotEmpty().
+			Immutable(),
+		field.String("actor").
+			Default("").
+			Immutable(),
+		field.String("target").
+			Default("").
+			Immutable(),
+		field.String("detail").
+			Default("").
+			Immutable(),
+		field.String("ip").
+			Default("").
+			Immutable(),
+	}
+}
+
+// Indexes of the AuditEvent.
+func (AuditEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+		index.Fields("actor", "created_at"),
+		index.Fields("target", "created_at"),
+	}
+}
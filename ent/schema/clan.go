@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"time"
+)
+
+// Clan holds the schema definition for the Clan entity.
+type Clan struct {
+	ent.Schema
+}
+
+// Fields of the Clan.
+func (Clan) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("number").
+			Unique().
+			Range(1, 9999),
+		field.String("name").
+			Default(""),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Bool("active").
+			Default(true),
+	}
+}
+
+// Edges of the Clan.
+func (Clan) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("users", User.Type),
+		edge.To("reports", TurnReport.Type),
+		edge.From("game", Game.Type).
+			Ref("clans").
+			Unique(),
+	}
+}
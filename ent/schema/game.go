@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"time"
+)
+
+// Game holds the schema definition for the Game entity.
+type Game struct {
+	ent.Schema
+}
+
+// Fields of the Game.
+func (Game) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("code").
+			Unique().
+			NotEmpty(),
+		field.String("name").
+			Default(""),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Game.
+func (Game) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("clans", Clan.Type),
+		edge.To("turns", Turn.Type),
+	}
+}
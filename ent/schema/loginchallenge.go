@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"time"
+)
+
+// LoginChallenge holds the schema definition for the LoginChallenge entity.
+// It is the state between a correct password and a correct second factor.
+type LoginChallenge struct {
+	ent.Schema
+}
+
+// Fields of the LoginChallenge.
+func (LoginChallenge) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("token_hash").
+			Unique().
+			NotEmpty().
+			Sensitive(),
+		field.Bool("remember").
+			Default(false),
+		field.Time("expires_at"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the LoginChallenge.
+func (LoginChallenge) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("login_challenges").
+			Unique().
+			Required(),
+	}
+}
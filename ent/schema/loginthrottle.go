@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// LoginThrottle holds the schema definition for the LoginThrottle entity.
+// It counts recent failed logins for a username or for a client IP address.
+type LoginThrottle struct {
+	ent.Schema
+}
+
+// Fields of the LoginThrottle.
+func (LoginThrottle) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("kind").
+			Values("username", "ip"),
+		field.String("key").
+			NotEmpty(),
+		field.Int("failures").
+			Default(0).
+			NonNegative(),
+		field.Time("last_failure_at"),
+		field.Time("locked_until").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes of the LoginThrottle.
+func (LoginThrottle) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("kind", "key").
+			Unique(),
+	}
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"time"
+)
+
+// PasswordReset holds the schema definition for the PasswordReset entity.
+// Only the SHA-256 hash of the token is stored; the token itself is shown
+// once, to the admin who generated the link.
+type PasswordReset struct {
+	ent.Schema
+}
+
+// Fields of the PasswordReset.
+func (PasswordReset) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("token_hash").
+			Unique().
+			NotEmpty().
+			Sensitive(),
+		field.Time("expires_at"),
+		field.Time("used_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the PasswordReset.
+func (PasswordReset) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("password_resets").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the PasswordReset.
+func (PasswordReset) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("expires_at"),
+	}
+}
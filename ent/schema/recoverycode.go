@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"time"
+)
+
+// RecoveryCode holds the schema definition for the RecoveryCode entity.
+// Recovery codes stand in for a TOTP code when the user loses their
+// authenticator. Only the hash is stored and each code works once.
+type RecoveryCode struct {
+	ent.Schema
+}
+
+// Fields of the RecoveryCode.
+func (RecoveryCode) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("code_hash").
+			NotEmpty().
+			Sensitive(),
+		field.Time("used_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the RecoveryCode.
+func (RecoveryCode) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("recovery_codes").
+			Unique().
+			Required(),
+	}
+}
@@ -20,10 +20,29 @@ func (Session) Fields() []ent.Field {
 			Unique().
 			NotEmpty().
 			Sensitive(),
+		field.String("csrf_token").
+			Default("").
+			Sensitive(),
 		field.Time("expires_at"),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
+		field.Time("last_seen_at").
+			Optional().
+			Nillable(),
+		field.String("ip").
+			Default(""),
+		field.String("user_agent").
+			Default(""),
+		field.Bool("remember").
+			Default(false),
+		field.Time("renewed_at").
+			Optional().
+			Nillable(),
+		field.String("previous_token").
+			Optional().
+			Nillable().
+			Sensitive(),
 	}
 }
 
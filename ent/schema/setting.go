@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"time"
+)
+
+// Setting holds the schema definition for the Setting entity.
+// Settings are site-wide options that admins change at runtime.
+type Setting struct {
+	ent.Schema
+}
+
+// Fields of the Setting.
+func (Setting) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			Unique().
+			NotEmpty(),
+		field.String("value"),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"time"
+)
+
+// Turn holds the schema definition for the Turn entity.
+type Turn struct {
+	ent.Schema
+}
+
+// Fields of the Turn.
+func (Turn) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("turn_id").
+			NotEmpty(),
+		field.Enum("status").
+			Values("open", "closed").
+			Default("open"),
+		field.Time("due_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Turn.
+func (Turn) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("game", Game.Type).
+			Ref("turns").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Turn.
+func (Turn) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("turn_id").
+			Edges("game").
+			Unique(),
+	}
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"time"
+)
+
+// TurnReport holds the schema definition for the TurnReport entity.
+type TurnReport struct {
+	ent.Schema
+}
+
+// Fields of the TurnReport.
+func (TurnReport) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("turn_id").
+			NotEmpty(),
+		field.String("original_filename").
+			NotEmpty(),
+		field.Bytes("raw"),
+		field.String("sha256").
+			NotEmpty(),
+		field.Time("uploaded_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the TurnReport.
+func (TurnReport) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("clan", Clan.Type).
+			Ref("reports").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the TurnReport.
+func (TurnReport) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("sha256").
+			Edges("clan").
+			Unique(),
+		index.Fields("turn_id"),
+	}
+}
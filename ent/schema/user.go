@@ -2,6 +2,7 @@ package schema
 
 import (
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"time"
@@ -21,7 +22,7 @@ func (User) Fields() []ent.Field {
 		field.String("password_hash").
 			Sensitive(),
 		field.Enum("role").
-			Values("guest", "chief", "admin").
+			Values("guest", "chief", "referee", "admin").
 			Default("guest"),
 		field.Int("clan_id").
 			Optional().
@@ -32,12 +33,39 @@ func (User) Fields() []ent.Field {
 		field.Time("updated_at").
 			Default(time.Now).
 			UpdateDefault(time.Now),
+		field.String("totp_secret").
+			Optional().
+			Nillable().
+			Sensitive(),
+		field.Time("totp_enabled_at").
+			Optional().
+			Nillable(),
+		field.Int64("totp_last_step").
+			Default(0),
+		field.Time("disabled_at").
+			Optional().
+			Nillable(),
+		field.String("disabled_reason").
+			Optional(),
 	}
 }
 
 // Edges of the User.
 func (User) Edges() []ent.Edge {
 	return []ent.Edge{
-		edge.To("sessions", Session.Type),
+		edge.To("sessions", Session.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("password_resets", PasswordReset.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("recovery_codes", RecoveryCode.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("login_challenges", LoginChallenge.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("api_tokens", APIToken.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.From("clan", Clan.Type).
+			Ref("users").
+			Field("clan_id").
+			Unique(),
 	}
 }
@@ -16,10 +16,24 @@ const (
 	FieldID = "id"
 	// FieldToken holds the string denoting the token field in the database.
 	FieldToken = "token"
+	// FieldCsrfToken holds the string denoting the csrf_token field in the database.
+	FieldCsrfToken = "csrf_token"
 	// FieldExpiresAt holds the string denoting the expires_at field in the database.
 	FieldExpiresAt = "expires_at"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
+	// FieldLastSeenAt holds the string denoting the last_seen_at field in the database.
+	FieldLastSeenAt = "last_seen_at"
+	// FieldIP holds the string denoting the ip field in the database.
+	FieldIP = "ip"
+	// FieldUserAgent holds the string denoting the user_agent field in the database.
+	FieldUserAgent = "user_agent"
+	// FieldRemember holds the string denoting the remember field in the database.
+	FieldRemember = "remember"
+	// FieldRenewedAt holds the string denoting the renewed_at field in the database.
+	FieldRenewedAt = "renewed_at"
+	// FieldPreviousToken holds the string denoting the previous_token field in the database.
+	FieldPreviousToken = "previous_token"
 	// EdgeUser holds the string denoting the user edge name in mutations.
 	EdgeUser = "user"
 	// Table holds the table name of the session in the database.
@@ -37,8 +51,15 @@ const (
 var Columns = []string{
 	FieldID,
 	FieldToken,
+	FieldCsrfToken,
 	FieldExpiresAt,
 	FieldCreatedAt,
+	FieldLastSeenAt,
+	FieldIP,
+	FieldUserAgent,
+	FieldRemember,
+	FieldRenewedAt,
+	FieldPreviousToken,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "sessions"
@@ -65,8 +86,16 @@ func ValidColumn(column string) bool {
 var (
 	// TokenValidator is a validator for the "token" field. It is called by the builders before save.
 	TokenValidator func(string) error
+	// DefaultCsrfToken holds the default value on creation for the "csrf_token" field.
+	DefaultCsrfToken string
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
+	// DefaultIP holds the default value on creation for the "ip" field.
+	DefaultIP string
+	// DefaultUserAgent holds the default value on creation for the "user_agent" field.
+	DefaultUserAgent string
+	// DefaultRemember holds the default value on creation for the "remember" field.
+	DefaultRemember bool
 )
 
 // OrderOption defines the ordering options for the Session queries.
@@ -82,6 +111,11 @@ func ByToken(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldToken, opts...).ToFunc()
 }
 
+// ByCsrfToken orders the results by the csrf_token field.
+func ByCsrfToken(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCsrfToken, opts...).ToFunc()
+}
+
 // ByExpiresAt orders the results by the expires_at field.
 func ByExpiresAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldExpiresAt, opts...).ToFunc()
@@ -92,6 +126,36 @@ func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
 }
 
+// ByLastSeenAt orders the results by the last_seen_at field.
+func ByLastSeenAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastSeenAt, opts...).ToFunc()
+}
+
+// ByIP orders the results by the ip field.
+func ByIP(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldIP, opts...).ToFunc()
+}
+
+// ByUserAgent orders the results by the user_agent field.
+func ByUserAgent(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUserAgent, opts...).ToFunc()
+}
+
+// ByRemember orders the results by the remember field.
+func ByRemember(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRemember, opts...).ToFunc()
+}
+
+// ByRenewedAt orders the results by the renewed_at field.
+func ByRenewedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRenewedAt, opts...).ToFunc()
+}
+
+// ByPreviousToken orders the results by the previous_token field.
+func ByPreviousToken(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPreviousToken, opts...).ToFunc()
+}
+
 // ByUserField orders the results by user field.
 func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -60,6 +60,11 @@ func Token(v string) predicate.Session {
 	return predicate.Session(sql.FieldEQ(FieldToken, v))
 }
 
+// CsrfToken applies equality check predicate on the "csrf_token" field. It's identical to CsrfTokenEQ.
+func CsrfToken(v string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldCsrfToken, v))
+}
+
 // ExpiresAt applies equality check predicate on the "expires_at" field. It's identical to ExpiresAtEQ.
 func ExpiresAt(v time.Time) predicate.Session {
 	return predicate.Session(sql.FieldEQ(FieldExpiresAt, v))
@@ -70,6 +75,36 @@ func CreatedAt(v time.Time) predicate.Session {
 	return predicate.Session(sql.FieldEQ(FieldCreatedAt, v))
 }
 
+// LastSeenAt applies equality check predicate on the "last_seen_at" field. It's identical to LastSeenAtEQ.
+func LastSeenAt(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldLastSeenAt, v))
+}
+
+// IP applies equality check predicate on the "ip" field. It's identical to IPEQ.
+func IP(v string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldIP, v))
+}
+
+// UserAgent applies equality check predicate on the "user_agent" field. It's identical to UserAgentEQ.
+func UserAgent(v string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldUserAgent, v))
+}
+
+// Remember applies equality check predicate on the "remember" field. It's identical to RememberEQ.
+func Remember(v bool) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldRemember, v))
+}
+
+// RenewedAt applies equality check predicate on the "renewed_at" field. It's identical to RenewedAtEQ.
+func RenewedAt(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldRenewedAt, v))
+}
+
+// PreviousToken applies equality check predicate on the "previous_token" field. It's identical to PreviousTokenEQ.
+func PreviousToken(v string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldPreviousToken, v))
+}
+
 // TokenEQ applies the EQ predicate on the "token" field.
 func TokenEQ(v string) predicate.Session {
 	return predicate.Session(sql.FieldEQ(FieldToken, v))
@@ -135,6 +170,71 @@ func TokenContainsFold(v string) predicate.Session {
 	return predicate.Session(sql.FieldContainsFold(FieldToken, v))
 }
 
+// CsrfTokenEQ applies the EQ predicate on the "csrf_token" field.
+func CsrfTokenEQ(v string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldCsrfToken, v))
+}
+
+// CsrfTokenNEQ applies the NEQ predicate on the "csrf_token" field.
+func CsrfTokenNEQ(v string) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldCsrfToken, v))
+}
+
+// CsrfTokenIn applies the In predicate on the "csrf_token" field.
+func CsrfTokenIn(vs ...string) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldCsrfToken, vs...))
+}
+
+// CsrfTokenNotIn applies the NotIn predicate on the "csrf_token" field.
+func CsrfTokenNotIn(vs ...string) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldCsrfToken, vs...))
+}
+
+// CsrfTokenGT applies the GT predicate on the "csrf_token" field.
+func CsrfTokenGT(v string) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldCsrfToken, v))
+}
+
+// CsrfTokenGTE applies the GTE predicate on the "csrf_token" field.
+func CsrfTokenGTE(v string) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldCsrfToken, v))
+}
+
+// CsrfTokenLT applies the LT predicate on the "csrf_token" field.
+func CsrfTokenLT(v string) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldCsrfToken, v))
+}
+
+// CsrfTokenLTE applies the LTE predicate on the "csrf_token" field.
+func CsrfTokenLTE(v string) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldCsrfToken, v))
+}
+
+// CsrfTokenContains applies the Contains predicate on the "csrf_token" field.
+func CsrfTokenContains(v string) predicate.Session {
+	return predicate.Session(sql.FieldContains(FieldCsrfToken, v))
+}
+
+// CsrfTokenHasPrefix applies the HasPrefix predicate on the "csrf_token" field.
+func CsrfTokenHasPrefix(v string) predicate.Session {
+	return predicate.Session(sql.FieldHasPrefix(FieldCsrfToken, v))
+}
+
+// CsrfTokenHasSuffix applies the HasSuffix predicate on the "csrf_token" field.
+func CsrfTokenHasSuffix(v string) predicate.Session {
+	return predicate.Session(sql.FieldHasSuffix(FieldCsrfToken, v))
+}
+
+// CsrfTokenEqualFold applies the EqualFold predicate on the "csrf_token" field.
+func CsrfTokenEqualFold(v string) predicate.Session {
+	return predicate.Session(sql.FieldEqualFold(FieldCsrfToken, v))
+}
+
+// CsrfTokenContainsFold applies the ContainsFold predicate on the "csrf_token" field.
+func CsrfTokenContainsFold(v string) predicate.Session {
+	return predicate.Session(sql.FieldContainsFold(FieldCsrfToken, v))
+}
+
 // ExpiresAtEQ applies the EQ predicate on the "expires_at" field.
 func ExpiresAtEQ(v time.Time) predicate.Session {
 	return predicate.Session(sql.FieldEQ(FieldExpiresAt, v))
@@ -215,6 +315,321 @@ func CreatedAtLTE(v time.Time) predicate.Session {
 	return predicate.Session(sql.FieldLTE(FieldCreatedAt, v))
 }
 
+// LastSeenAtEQ applies the EQ predicate on the "last_seen_at" field.
+func LastSeenAtEQ(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldLastSeenAt, v))
+}
+
+// LastSeenAtNEQ applies the NEQ predicate on the "last_seen_at" field.
+func LastSeenAtNEQ(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldLastSeenAt, v))
+}
+
+// LastSeenAtIn applies the In predicate on the "last_seen_at" field.
+func LastSeenAtIn(vs ...time.Time) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldLastSeenAt, vs...))
+}
+
+// LastSeenAtNotIn applies the NotIn predicate on the "last_seen_at" field.
+func LastSeenAtNotIn(vs ...time.Time) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldLastSeenAt, vs...))
+}
+
+// LastSeenAtGT applies the GT predicate on the "last_seen_at" field.
+func LastSeenAtGT(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldLastSeenAt, v))
+}
+
+// LastSeenAtGTE applies the GTE predicate on the "last_seen_at" field.
+func LastSeenAtGTE(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldLastSeenAt, v))
+}
+
+// LastSeenAtLT applies the LT predicate on the "last_seen_at" field.
+func LastSeenAtLT(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldLastSeenAt, v))
+}
+
+// LastSeenAtLTE applies the LTE predicate on the "last_seen_at" field.
+func LastSeenAtLTE(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldLastSeenAt, v))
+}
+
+// LastSeenAtIsNil applies the IsNil predicate on the "last_seen_at" field.
+func LastSeenAtIsNil() predicate.Session {
+	return predicate.Session(sql.FieldIsNull(FieldLastSeenAt))
+}
+
+// LastSeenAtNotNil applies the NotNil predicate on the "last_seen_at" field.
+func LastSeenAtNotNil() predicate.Session {
+	return predicate.Session(sql.FieldNotNull(FieldLastSeenAt))
+}
+
+// IPEQ applies the EQ predicate on the "ip" field.
+func IPEQ(v string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldIP, v))
+}
+
+// IPNEQ applies the NEQ predicate on the "ip" field.
+func IPNEQ(v string) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldIP, v))
+}
+
+// IPIn applies the In predicate on the "ip" field.
+func IPIn(vs ...string) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldIP, vs...))
+}
+
+// IPNotIn applies the NotIn predicate on the "ip" field.
+func IPNotIn(vs ...string) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldIP, vs...))
+}
+
+// IPGT applies the GT predicate on the "ip" field.
+func IPGT(v string) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldIP, v))
+}
+
+// IPGTE applies the GTE predicate on the "ip" field.
+func IPGTE(v string) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldIP, v))
+}
+
+// IPLT applies the LT predicate on the "ip" field.
+func IPLT(v string) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldIP, v))
+}
+
+// IPLTE applies the LTE predicate on the "ip" field.
+func IPLTE(v string) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldIP, v))
+}
+
+// IPContains applies the Contains predicate on the "ip" field.
+func IPContains(v string) predicate.Session {
+	return predicate.Session(sql.FieldContains(FieldIP, v))
+}
+
+// IPHasPrefix applies the HasPrefix predicate on the "ip" field.
+func IPHasPrefix(v string) predicate.Session {
+	return predicate.Session(sql.FieldHasPrefix(FieldIP, v))
+}
+
+// IPHasSuffix applies the HasSuffix predicate on the "ip" field.
+func IPHasSuffix(v string) predicate.Session {
+	return predicate.Session(sql.FieldHasSuffix(FieldIP, v))
+}
+
+// IPEqualFold applies the EqualFold predicate on the "ip" field.
+func IPEqualFold(v string) predicate.Session {
+	return predicate.Session(sql.FieldEqualFold(FieldIP, v))
+}
+
+// IPContainsFold applies the ContainsFold predicate on the "ip" field.
+func IPContainsFold(v string) predicate.Session {
+	return predicate.Session(sql.FieldContainsFold(FieldIP, v))
+}
+
+// UserAgentEQ applies the EQ predicate on the "user_agent" field.
+func UserAgentEQ(v string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldUserAgent, v))
+}
+
+// UserAgentNEQ applies the NEQ predicate on the "user_agent" field.
+func UserAgentNEQ(v string) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldUserAgent, v))
+}
+
+// UserAgentIn applies the In predicate on the "user_agent" field.
+func UserAgentIn(vs ...string) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldUserAgent, vs...))
+}
+
+// UserAgentNotIn applies the NotIn predicate on the "user_agent" field.
+func UserAgentNotIn(vs ...string) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldUserAgent, vs...))
+}
+
+// UserAgentGT applies the GT predicate on the "user_agent" field.
+func UserAgentGT(v string) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldUserAgent, v))
+}
+
+// UserAgentGTE applies the GTE predicate on the "user_agent" field.
+func UserAgentGTE(v string) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldUserAgent, v))
+}
+
+// UserAgentLT applies the LT predicate on the "user_agent" field.
+func UserAgentLT(v string) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldUserAgent, v))
+}
+
+// UserAgentLTE applies the LTE predicate on the "user_agent" field.
+func UserAgentLTE(v string) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldUserAgent, v))
+}
+
+// UserAgentContains applies the Contains predicate on the "user_agent" field.
+func UserAgentContains(v string) predicate.Session {
+	return predicate.Session(sql.FieldContains(FieldUserAgent, v))
+}
+
+// UserAgentHasPrefix applies the HasPrefix predicate on the "user_agent" field.
+func UserAgentHasPrefix(v string) predicate.Session {
+	return predicate.Session(sql.FieldHasPrefix(FieldUserAgent, v))
+}
+
+// UserAgentHasSuffix applies the HasSuffix predicate on the "user_agent" field.
+func UserAgentHasSuffix(v string) predicate.Session {
+	return predicate.Session(sql.FieldHasSuffix(FieldUserAgent, v))
+}
+
+// UserAgentEqualFold applies the EqualFold predicate on the "user_agent" field.
+func UserAgentEqualFold(v string) predicate.Session {
+	return predicate.Session(sql.FieldEqualFold(FieldUserAgent, v))
+}
+
+// UserAgentContainsFold applies the ContainsFold predicate on the "user_agent" field.
+func UserAgentContainsFold(v string) predicate.Session {
+	return predicate.Session(sql.FieldContainsFold(FieldUserAgent, v))
+}
+
+// RememberEQ applies the EQ predicate on the "remember" field.
+func RememberEQ(v bool) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldRemember, v))
+}
+
+// RememberNEQ applies the NEQ predicate on the "remember" field.
+func RememberNEQ(v bool) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldRemember, v))
+}
+
+// RenewedAtEQ applies the EQ predicate on the "renewed_at" field.
+func RenewedAtEQ(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldRenewedAt, v))
+}
+
+// RenewedAtNEQ applies the NEQ predicate on the "renewed_at" field.
+func RenewedAtNEQ(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldRenewedAt, v))
+}
+
+// RenewedAtIn applies the In predicate on the "renewed_at" field.
+func RenewedAtIn(vs ...time.Time) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldRenewedAt, vs...))
+}
+
+// RenewedAtNotIn applies the NotIn predicate on the "renewed_at" field.
+func RenewedAtNotIn(vs ...time.Time) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldRenewedAt, vs...))
+}
+
+// RenewedAtGT applies the GT predicate on the "renewed_at" field.
+func RenewedAtGT(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldRenewedAt, v))
+}
+
+// RenewedAtGTE applies the GTE predicate on the "renewed_at" field.
+func RenewedAtGTE(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldRenewedAt, v))
+}
+
+// RenewedAtLT applies the LT predicate on the "renewed_at" field.
+func RenewedAtLT(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldRenewedAt, v))
+}
+
+// RenewedAtLTE applies the LTE predicate on the "renewed_at" field.
+func RenewedAtLTE(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldRenewedAt, v))
+}
+
+// RenewedAtIsNil applies the IsNil predicate on the "renewed_at" field.
+func RenewedAtIsNil() predicate.Session {
+	return predicate.Session(sql.FieldIsNull(FieldRenewedAt))
+}
+
+// RenewedAtNotNil applies the NotNil predicate on the "renewed_at" field.
+func RenewedAtNotNil() predicate.Session {
+	return predicate.Session(sql.FieldNotNull(FieldRenewedAt))
+}
+
+// PreviousTokenEQ applies the EQ predicate on the "previous_token" field.
+func PreviousTokenEQ(v string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldPreviousToken, v))
+}
+
+// PreviousTokenNEQ applies the NEQ predicate on the "previous_token" field.
+func PreviousTokenNEQ(v string) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldPreviousToken, v))
+}
+
+// PreviousTokenIn applies the In predicate on the "previous_token" field.
+func PreviousTokenIn(vs ...string) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldPreviousToken, vs...))
+}
+
+// PreviousTokenNotIn applies the NotIn predicate on the "previous_token" field.
+func PreviousTokenNotIn(vs ...string) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldPreviousToken, vs...))
+}
+
+// PreviousTokenGT applies the GT predicate on the "previous_token" field.
+func PreviousTokenGT(v string) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldPreviousToken, v))
+}
+
+// PreviousTokenGTE applies the GTE predicate on the "previous_token" field.
+func PreviousTokenGTE(v string) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldPreviousToken, v))
+}
+
+// PreviousTokenLT applies the LT predicate on the "previous_token" field.
+func PreviousTokenLT(v string) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldPreviousToken, v))
+}
+
+// PreviousTokenLTE applies the LTE predicate on the "previous_token" field.
+func PreviousTokenLTE(v string) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldPreviousToken, v))
+}
+
+// PreviousTokenContains applies the Contains predicate on the "previous_token" field.
+func PreviousTokenContains(v string) predicate.Session {
+	return predicate.Session(sql.FieldContains(FieldPreviousToken, v))
+}
+
+// PreviousTokenHasPrefix applies the HasPrefix predicate on the "previous_token" field.
+func PreviousTokenHasPrefix(v string) predicate.Session {
+	return predicate.Session(sql.FieldHasPrefix(FieldPreviousToken, v))
+}
+
+// PreviousTokenHasSuffix applies the HasSuffix predicate on the "previous_token" field.
+func PreviousTokenHasSuffix(v string) predicate.Session {
+	return predicate.Session(sql.FieldHasSuffix(FieldPreviousToken, v))
+}
+
+// PreviousTokenIsNil applies the IsNil predicate on the "previous_token" field.
+func PreviousTokenIsNil() predicate.Session {
+	return predicate.Session(sql.FieldIsNull(FieldPreviousToken))
+}
+
+// PreviousTokenNotNil applies the NotNil predicate on the "previous_token" field.
+func PreviousTokenNotNil() predicate.Session {
+	return predicate.Session(sql.FieldNotNull(FieldPreviousToken))
+}
+
+// PreviousTokenEqualFold applies the EqualFold predicate on the "previous_token" field.
+func PreviousTokenEqualFold(v string) predicate.Session {
+	return predicate.Session(sql.FieldEqualFold(FieldPreviousToken, v))
+}
+
+// PreviousTokenContainsFold applies the ContainsFold predicate on the "previous_token" field.
+func PreviousTokenContainsFold(v string) predicate.Session {
+	return predicate.Session(sql.FieldContainsFold(FieldPreviousToken, v))
+}
+
 // HasUser applies the HasEdge predicate on the "user" edge.
 func HasUser() predicate.Session {
 	return predicate.Session(func(s *sql.Selector) {
@@ -27,6 +27,20 @@ func (_c *SessionCreate) SetToken(v string) *SessionCreate {
 	return _c
 }
 
+// SetCsrfToken sets the "csrf_token" field.
+func (_c *SessionCreate) SetCsrfToken(v string) *SessionCreate {
+	_c.mutation.SetCsrfToken(v)
+	return _c
+}
+
+// SetNillableCsrfToken sets the "csrf_token" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableCsrfToken(v *string) *SessionCreate {
+	if v != nil {
+		_c.SetCsrfToken(*v)
+	}
+	return _c
+}
+
 // SetExpiresAt sets the "expires_at" field.
 func (_c *SessionCreate) SetExpiresAt(v time.Time) *SessionCreate {
 	_c.mutation.SetExpiresAt(v)
@@ -47,6 +61,90 @@ func (_c *SessionCreate) SetNillableCreatedAt(v *time.Time) *SessionCreate {
 	return _c
 }
 
+// SetLastSeenAt sets the "last_seen_at" field.
+func (_c *SessionCreate) SetLastSeenAt(v time.Time) *SessionCreate {
+	_c.mutation.SetLastSeenAt(v)
+	return _c
+}
+
+// SetNillableLastSeenAt sets the "last_seen_at" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableLastSeenAt(v *time.Time) *SessionCreate {
+	if v != nil {
+		_c.SetLastSeenAt(*v)
+	}
+	return _c
+}
+
+// SetIP sets the "ip" field.
+func (_c *SessionCreate) SetIP(v string) *SessionCreate {
+	_c.mutation.SetIP(v)
+	return _c
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableIP(v *string) *SessionCreate {
+	if v != nil {
+		_c.SetIP(*v)
+	}
+	return _c
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (_c *SessionCreate) SetUserAgent(v string) *SessionCreate {
+	_c.mutation.SetUserAgent(v)
+	return _c
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableUserAgent(v *string) *SessionCreate {
+	if v != nil {
+		_c.SetUserAgent(*v)
+	}
+	return _c
+}
+
+// SetRemember sets the "remember" field.
+func (_c *SessionCreate) SetRemember(v bool) *SessionCreate {
+	_c.mutation.SetRemember(v)
+	return _c
+}
+
+// SetNillableRemember sets the "remember" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableRemember(v *bool) *SessionCreate {
+	if v != nil {
+		_c.SetRemember(*v)
+	}
+	return _c
+}
+
+// SetRenewedAt sets the "renewed_at" field.
+func (_c *SessionCreate) SetRenewedAt(v time.Time) *SessionCreate {
+	_c.mutation.SetRenewedAt(v)
+	return _c
+}
+
+// SetNillableRenewedAt sets the "renewed_at" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableRenewedAt(v *time.Time) *SessionCreate {
+	if v != nil {
+		_c.SetRenewedAt(*v)
+	}
+	return _c
+}
+
+// SetPreviousToken sets the "previous_token" field.
+func (_c *SessionCreate) SetPreviousToken(v string) *SessionCreate {
+	_c.mutation.SetPreviousToken(v)
+	return _c
+}
+
+// SetNillablePreviousToken sets the "previous_token" field if the given value is not nil.
+func (_c *SessionCreate) SetNillablePreviousToken(v *string) *SessionCreate {
+	if v != nil {
+		_c.SetPreviousToken(*v)
+	}
+	return _c
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (_c *SessionCreate) SetUserID(id int) *SessionCreate {
 	_c.mutation.SetUserID(id)
@@ -93,10 +191,26 @@ func (_c *SessionCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (_c *SessionCreate) defaults() {
+	if _, ok := _c.mutation.CsrfToken(); !ok {
+		v := session.DefaultCsrfToken
+		_c.mutation.SetCsrfToken(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := session.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
 	}
+	if _, ok := _c.mutation.IP(); !ok {
+		v := session.DefaultIP
+		_c.mutation.SetIP(v)
+	}
+	if _, ok := _c.mutation.UserAgent(); !ok {
+		v := session.DefaultUserAgent
+		_c.mutation.SetUserAgent(v)
+	}
+	if _, ok := _c.mutation.Remember(); !ok {
+		v := session.DefaultRemember
+		_c.mutation.SetRemember(v)
+	}
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -109,12 +223,24 @@ func (_c *SessionCreate) check() error {
 			return &ValidationError{Name: "token", err: fmt.Errorf(`ent: validator failed for field "Session.token": %w`, err)}
 		}
 	}
+	if _, ok := _c.mutation.CsrfToken(); !ok {
+		return &ValidationError{Name: "csrf_token", err: errors.New(`ent: missing required field "Session.csrf_token"`)}
+	}
 	if _, ok := _c.mutation.ExpiresAt(); !ok {
 		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "Session.expires_at"`)}
 	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Session.created_at"`)}
 	}
+	if _, ok := _c.mutation.IP(); !ok {
+		return &ValidationError{Name: "ip", err: errors.New(`ent: missing required field "Session.ip"`)}
+	}
+	if _, ok := _c.mutation.UserAgent(); !ok {
+		return &ValidationError{Name: "user_agent", err: errors.New(`ent: missing required field "Session.user_agent"`)}
+	}
+	if _, ok := _c.mutation.Remember(); !ok {
+		return &ValidationError{Name: "remember", err: errors.New(`ent: missing required field "Session.remember"`)}
+	}
 	if len(_c.mutation.UserIDs()) == 0 {
 		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Session.user"`)}
 	}
@@ -148,6 +274,10 @@ func (_c *SessionCreate) createSpec() (*Session, *sqlgraph.CreateSpec) {
 		_spec.SetField(session.FieldToken, field.TypeString, value)
 		_node.Token = value
 	}
+	if value, ok := _c.mutation.CsrfToken(); ok {
+		_spec.SetField(session.FieldCsrfToken, field.TypeString, value)
+		_node.CsrfToken = value
+	}
 	if value, ok := _c.mutation.ExpiresAt(); ok {
 		_spec.SetField(session.FieldExpiresAt, field.TypeTime, value)
 		_node.ExpiresAt = value
@@ -156,6 +286,30 @@ func (_c *SessionCreate) createSpec() (*Session, *sqlgraph.CreateSpec) {
 		_spec.SetField(session.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
 	}
+	if value, ok := _c.mutation.LastSeenAt(); ok {
+		_spec.SetField(session.FieldLastSeenAt, field.TypeTime, value)
+		_node.LastSeenAt = &value
+	}
+	if value, ok := _c.mutation.IP(); ok {
+		_spec.SetField(session.FieldIP, field.TypeString, value)
+		_node.IP = value
+	}
+	if value, ok := _c.mutation.UserAgent(); ok {
+		_spec.SetField(session.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = value
+	}
+	if value, ok := _c.mutation.Remember(); ok {
+		_spec.SetField(session.FieldRemember, field.TypeBool, value)
+		_node.Remember = value
+	}
+	if value, ok := _c.mutation.RenewedAt(); ok {
+		_spec.SetField(session.FieldRenewedAt, field.TypeTime, value)
+		_node.RenewedAt = &value
+	}
+	if value, ok := _c.mutation.PreviousToken(); ok {
+		_spec.SetField(session.FieldPreviousToken, field.TypeString, value)
+		_node.PreviousToken = &value
+	}
 	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -43,6 +43,20 @@ func (_u *SessionUpdate) SetNillableToken(v *string) *SessionUpdate {
 	return _u
 }
 
+// SetCsrfToken sets the "csrf_token" field.
+func (_u *SessionUpdate) SetCsrfToken(v string) *SessionUpdate {
+	_u.mutation.SetCsrfToken(v)
+	return _u
+}
+
+// SetNillableCsrfToken sets the "csrf_token" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableCsrfToken(v *string) *SessionUpdate {
+	if v != nil {
+		_u.SetCsrfToken(*v)
+	}
+	return _u
+}
+
 // SetExpiresAt sets the "expires_at" field.
 func (_u *SessionUpdate) SetExpiresAt(v time.Time) *SessionUpdate {
 	_u.mutation.SetExpiresAt(v)
@@ -57,6 +71,108 @@ func (_u *SessionUpdate) SetNillableExpiresAt(v *time.Time) *SessionUpdate {
 	return _u
 }
 
+// SetLastSeenAt sets the "last_seen_at" field.
+func (_u *SessionUpdate) SetLastSeenAt(v time.Time) *SessionUpdate {
+	_u.mutation.SetLastSeenAt(v)
+	return _u
+}
+
+// SetNillableLastSeenAt sets the "last_seen_at" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableLastSeenAt(v *time.Time) *SessionUpdate {
+	if v != nil {
+		_u.SetLastSeenAt(*v)
+	}
+	return _u
+}
+
+// ClearLastSeenAt clears the value of the "last_seen_at" field.
+func (_u *SessionUpdate) ClearLastSeenAt() *SessionUpdate {
+	_u.mutation.ClearLastSeenAt()
+	return _u
+}
+
+// SetIP sets the "ip" field.
+func (_u *SessionUpdate) SetIP(v string) *SessionUpdate {
+	_u.mutation.SetIP(v)
+	return _u
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableIP(v *string) *SessionUpdate {
+	if v != nil {
+		_u.SetIP(*v)
+	}
+	return _u
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (_u *SessionUpdate) SetUserAgent(v string) *SessionUpdate {
+	_u.mutation.SetUserAgent(v)
+	return _u
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableUserAgent(v *string) *SessionUpdate {
+	if v != nil {
+		_u.SetUserAgent(*v)
+	}
+	return _u
+}
+
+// SetRemember sets the "remember" field.
+func (_u *SessionUpdate) SetRemember(v bool) *SessionUpdate {
+	_u.mutation.SetRemember(v)
+	return _u
+}
+
+// SetNillableRemember sets the "remember" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableRemember(v *bool) *SessionUpdate {
+	if v != nil {
+		_u.SetRemember(*v)
+	}
+	return _u
+}
+
+// SetRenewedAt sets the "renewed_at" field.
+func (_u *SessionUpdate) SetRenewedAt(v time.Time) *SessionUpdate {
+	_u.mutation.SetRenewedAt(v)
+	return _u
+}
+
+// SetNillableRenewedAt sets the "renewed_at" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableRenewedAt(v *time.Time) *SessionUpdate {
+	if v != nil {
+		_u.SetRenewedAt(*v)
+	}
+	return _u
+}
+
+// ClearRenewedAt clears the value of the "renewed_at" field.
+func (_u *SessionUpdate) ClearRenewedAt() *SessionUpdate {
+	_u.mutation.ClearRenewedAt()
+	return _u
+}
+
+// SetPreviousToken sets the "previous_token" field.
+func (_u *SessionUpdate) SetPreviousToken(v string) *SessionUpdate {
+	_u.mutation.SetPreviousToken(v)
+	return _u
+}
+
+// SetNillablePreviousToken sets the "previous_token" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillablePreviousToken(v *string) *SessionUpdate {
+	if v != nil {
+		_u.SetPreviousToken(*v)
+	}
+	return _u
+}
+
+// ClearPreviousToken clears the value of the "previous_token" field.
+func (_u *SessionUpdate) ClearPreviousToken() *SessionUpdate {
+	_u.mutation.ClearPreviousToken()
+	return _u
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (_u *SessionUpdate) SetUserID(id int) *SessionUpdate {
 	_u.mutation.SetUserID(id)
@@ -134,9 +250,39 @@ func (_u *SessionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.Token(); ok {
 		_spec.SetField(session.FieldToken, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.CsrfToken(); ok {
+		_spec.SetField(session.FieldCsrfToken, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.ExpiresAt(); ok {
 		_spec.SetField(session.FieldExpiresAt, field.TypeTime, value)
 	}
+	if value, ok := _u.mutation.LastSeenAt(); ok {
+		_spec.SetField(session.FieldLastSeenAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastSeenAtCleared() {
+		_spec.ClearField(session.FieldLastSeenAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.IP(); ok {
+		_spec.SetField(session.FieldIP, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UserAgent(); ok {
+		_spec.SetField(session.FieldUserAgent, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Remember(); ok {
+		_spec.SetField(session.FieldRemember, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RenewedAt(); ok {
+		_spec.SetField(session.FieldRenewedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RenewedAtCleared() {
+		_spec.ClearField(session.FieldRenewedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.PreviousToken(); ok {
+		_spec.SetField(session.FieldPreviousToken, field.TypeString, value)
+	}
+	if _u.mutation.PreviousTokenCleared() {
+		_spec.ClearField(session.FieldPreviousToken, field.TypeString)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -200,6 +346,20 @@ func (_u *SessionUpdateOne) SetNillableToken(v *string) *SessionUpdateOne {
 	return _u
 }
 
+// SetCsrfToken sets the "csrf_token" field.
+func (_u *SessionUpdateOne) SetCsrfToken(v string) *SessionUpdateOne {
+	_u.mutation.SetCsrfToken(v)
+	return _u
+}
+
+// SetNillableCsrfToken sets the "csrf_token" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableCsrfToken(v *string) *SessionUpdateOne {
+	if v != nil {
+		_u.SetCsrfToken(*v)
+	}
+	return _u
+}
+
 // SetExpiresAt sets the "expires_at" field.
 func (_u *SessionUpdateOne) SetExpiresAt(v time.Time) *SessionUpdateOne {
 	_u.mutation.SetExpiresAt(v)
@@ -214,6 +374,108 @@ func (_u *SessionUpdateOne) SetNillableExpiresAt(v *time.Time) *SessionUpdateOne
 	return _u
 }
 
+// SetLastSeenAt sets the "last_seen_at" field.
+func (_u *SessionUpdateOne) SetLastSeenAt(v time.Time) *SessionUpdateOne {
+	_u.mutation.SetLastSeenAt(v)
+	return _u
+}
+
+// SetNillableLastSeenAt sets the "last_seen_at" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableLastSeenAt(v *time.Time) *SessionUpdateOne {
+	if v != nil {
+		_u.SetLastSeenAt(*v)
+	}
+	return _u
+}
+
+// ClearLastSeenAt clears the value of the "last_seen_at" field.
+func (_u *SessionUpdateOne) ClearLastSeenAt() *SessionUpdateOne {
+	_u.mutation.ClearLastSeenAt()
+	return _u
+}
+
+// SetIP sets the "ip" field.
+func (_u *SessionUpdateOne) SetIP(v string) *SessionUpdateOne {
+	_u.mutation.SetIP(v)
+	return _u
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableIP(v *string) *SessionUpdateOne {
+	if v != nil {
+		_u.SetIP(*v)
+	}
+	return _u
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (_u *SessionUpdateOne) SetUserAgent(v string) *SessionUpdateOne {
+	_u.mutation.SetUserAgent(v)
+	return _u
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableUserAgent(v *string) *SessionUpdateOne {
+	if v != nil {
+		_u.SetUserAgent(*v)
+	}
+	return _u
+}
+
+// SetRemember sets the "remember" field.
+func (_u *SessionUpdateOne) SetRemember(v bool) *SessionUpdateOne {
+	_u.mutation.SetRemember(v)
+	return _u
+}
+
+// SetNillableRemember sets the "remember" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableRemember(v *bool) *SessionUpdateOne {
+	if v != nil {
+		_u.SetRemember(*v)
+	}
+	return _u
+}
+
+// SetRenewedAt sets the "renewed_at" field.
+func (_u *SessionUpdateOne) SetRenewedAt(v time.Time) *SessionUpdateOne {
+	_u.mutation.SetRenewedAt(v)
+	return _u
+}
+
+// SetNillableRenewedAt sets the "renewed_at" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableRenewedAt(v *time.Time) *SessionUpdateOne {
+	if v != nil {
+		_u.SetRenewedAt(*v)
+	}
+	return _u
+}
+
+// ClearRenewedAt clears the value of the "renewed_at" field.
+func (_u *SessionUpdateOne) ClearRenewedAt() *SessionUpdateOne {
+	_u.mutation.ClearRenewedAt()
+	return _u
+}
+
+// SetPreviousToken sets the "previous_token" field.
+func (_u *SessionUpdateOne) SetPreviousToken(v string) *SessionUpdateOne {
+	_u.mutation.SetPreviousToken(v)
+	return _u
+}
+
+// SetNillablePreviousToken sets the "previous_token" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillablePreviousToken(v *string) *SessionUpdateOne {
+	if v != nil {
+		_u.SetPreviousToken(*v)
+	}
+	return _u
+}
+
+// ClearPreviousToken clears the value of the "previous_token" field.
+func (_u *SessionUpdateOne) ClearPreviousToken() *SessionUpdateOne {
+	_u.mutation.ClearPreviousToken()
+	return _u
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (_u *SessionUpdateOne) SetUserID(id int) *SessionUpdateOne {
 	_u.mutation.SetUserID(id)
@@ -321,9 +583,39 @@ func (_u *SessionUpdateOne) sqlSave(ctx context.Context) (_node *Session, err er
 	if value, ok := _u.mutation.Token(); ok {
 		_spec.SetField(session.FieldToken, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.CsrfToken(); ok {
+		_spec.SetField(session.FieldCsrfToken, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.ExpiresAt(); ok {
 		_spec.SetField(session.FieldExpiresAt, field.TypeTime, value)
 	}
+	if value, ok := _u.mutation.LastSeenAt(); ok {
+		_spec.SetField(session.FieldLastSeenAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastSeenAtCleared() {
+		_spec.ClearField(session.FieldLastSeenAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.IP(); ok {
+		_spec.SetField(session.FieldIP, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UserAgent(); ok {
+		_spec.SetField(session.FieldUserAgent, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Remember(); ok {
+		_spec.SetField(session.FieldRemember, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RenewedAt(); ok {
+		_spec.SetField(session.FieldRenewedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RenewedAtCleared() {
+		_spec.ClearField(session.FieldRenewedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.PreviousToken(); ok {
+		_spec.SetField(session.FieldPreviousToken, field.TypeString, value)
+	}
+	if _u.mutation.PreviousTokenCleared() {
+		_spec.ClearField(session.FieldPreviousToken, field.TypeString)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
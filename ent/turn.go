@@ -0,0 +1,181 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/ent/turn"
+)
+
+// Turn is the model entity for the Turn schema.
+type Turn struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// TurnID holds the value of the "turn_id" field.
+	TurnID string `json:"turn_id,omitempty"`
+	// Status holds the value of the "status" field.
+	Status turn.Status `json:"status,omitempty"`
+	// DueAt holds the value of the "due_at" field.
+	DueAt *time.Time `json:"due_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the TurnQuery when eager-loading is set.
+	Edges        TurnEdges `json:"edges"`
+	game_turns   *int
+	selectValues sql.SelectValues
+}
+
+// TurnEdges holds the relations/edges for other nodes in the graph.
+type TurnEdges struct {
+	// Game holds the value of the game edge.
+	Game *Game `json:"game,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// GameOrErr returns the Game value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TurnEdges) GameOrErr() (*Game, error) {
+	if e.Game != nil {
+		return e.Game, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: game.Label}
+	}
+	return nil, &NotLoadedError{edge: "game"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Turn) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case turn.FieldID:
+			values[i] = new(sql.NullInt64)
+		case turn.FieldTurnID, turn.FieldStatus:
+			values[i] = new(sql.NullString)
+		case turn.FieldDueAt, turn.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case turn.ForeignKeys[0]: // game_turns
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Turn fields.
+func (_m *Turn) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case turn.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case turn.FieldTurnID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field turn_id", values[i])
+			} else if value.Valid {
+				_m.TurnID = value.String
+			}
+		case turn.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				_m.Status = turn.Status(value.String)
+			}
+		case turn.FieldDueAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field due_at", values[i])
+			} else if value.Valid {
+				_m.DueAt = new(time.Time)
+				*_m.DueAt = value.Time
+			}
+		case turn.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case turn.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field game_turns", value)
+			} else if value.Valid {
+				_m.game_turns = new(int)
+				*_m.game_turns = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Turn.
+// This includes values selected through modifiers, order, etc.
+func (_m *Turn) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryGame queries the "game" edge of the Turn entity.
+func (_m *Turn) QueryGame() *GameQuery {
+	return NewTurnClient(_m.config).QueryGame(_m)
+}
+
+// Update returns a builder for updating this Turn.
+// Note that you need to call Turn.Unwrap() before calling this method if this Turn
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Turn) Update() *TurnUpdateOne {
+	return NewTurnClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Turn entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Turn) Unwrap() *Turn {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Turn is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Turn) String() string {
+	var builder strings.Builder
+	builder.WriteString("Turn(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("turn_id=")
+	builder.WriteString(_m.TurnID)
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Status))
+	builder.WriteString(", ")
+	if v := _m.DueAt; v != nil {
+		builder.WriteString("due_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Turns is a parsable slice of Turn.
+type Turns []*Turn
@@ -0,0 +1,284 @@
+// Code generated by ent, DO NOT EDIT.
+
+package turn
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/mdhender/ottomat/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.Turn {
+	return predicate.Turn(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Turn {
+	return predicate.Turn(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Turn {
+	return predicate.Turn(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Turn {
+	return predicate.Turn(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Turn {
+	return predicate.Turn(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Turn {
+	return predicate.Turn(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Turn {
+	return predicate.Turn(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Turn {
+	return predicate.Turn(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Turn {
+	return predicate.Turn(sql.FieldLTE(FieldID, id))
+}
+
+// TurnID applies equality check predicate on the "turn_id" field. It's identical to TurnIDEQ.
+func TurnID(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldEQ(FieldTurnID, v))
+}
+
+// DueAt applies equality check predicate on the "due_at" field. It's identical to DueAtEQ.
+func DueAt(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldEQ(FieldDueAt, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// TurnIDEQ applies the EQ predicate on the "turn_id" field.
+func TurnIDEQ(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldEQ(FieldTurnID, v))
+}
+
+// TurnIDNEQ applies the NEQ predicate on the "turn_id" field.
+func TurnIDNEQ(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldNEQ(FieldTurnID, v))
+}
+
+// TurnIDIn applies the In predicate on the "turn_id" field.
+func TurnIDIn(vs ...string) predicate.Turn {
+	return predicate.Turn(sql.FieldIn(FieldTurnID, vs...))
+}
+
+// TurnIDNotIn applies the NotIn predicate on the "turn_id" field.
+func TurnIDNotIn(vs ...string) predicate.Turn {
+	return predicate.Turn(sql.FieldNotIn(FieldTurnID, vs...))
+}
+
+// TurnIDGT applies the GT predicate on the "turn_id" field.
+func TurnIDGT(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldGT(FieldTurnID, v))
+}
+
+// TurnIDGTE applies the GTE predicate on the "turn_id" field.
+func TurnIDGTE(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldGTE(FieldTurnID, v))
+}
+
+// TurnIDLT applies the LT predicate on the "turn_id" field.
+func TurnIDLT(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldLT(FieldTurnID, v))
+}
+
+// TurnIDLTE applies the LTE predicate on the "turn_id" field.
+func TurnIDLTE(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldLTE(FieldTurnID, v))
+}
+
+// TurnIDContains applies the Contains predicate on the "turn_id" field.
+func TurnIDContains(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldContains(FieldTurnID, v))
+}
+
+// TurnIDHasPrefix applies the HasPrefix predicate on the "turn_id" field.
+func TurnIDHasPrefix(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldHasPrefix(FieldTurnID, v))
+}
+
+// TurnIDHasSuffix applies the HasSuffix predicate on the "turn_id" field.
+func TurnIDHasSuffix(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldHasSuffix(FieldTurnID, v))
+}
+
+// TurnIDEqualFold applies the EqualFold predicate on the "turn_id" field.
+func TurnIDEqualFold(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldEqualFold(FieldTurnID, v))
+}
+
+// TurnIDContainsFold applies the ContainsFold predicate on the "turn_id" field.
+func TurnIDContainsFold(v string) predicate.Turn {
+	return predicate.Turn(sql.FieldContainsFold(FieldTurnID, v))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v Status) predicate.Turn {
+	return predicate.Turn(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v Status) predicate.Turn {
+	return predicate.Turn(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...Status) predicate.Turn {
+	return predicate.Turn(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...Status) predicate.Turn {
+	return predicate.Turn(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// DueAtEQ applies the EQ predicate on the "due_at" field.
+func DueAtEQ(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldEQ(FieldDueAt, v))
+}
+
+// DueAtNEQ applies the NEQ predicate on the "due_at" field.
+func DueAtNEQ(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldNEQ(FieldDueAt, v))
+}
+
+// DueAtIn applies the In predicate on the "due_at" field.
+func DueAtIn(vs ...time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldIn(FieldDueAt, vs...))
+}
+
+// DueAtNotIn applies the NotIn predicate on the "due_at" field.
+func DueAtNotIn(vs ...time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldNotIn(FieldDueAt, vs...))
+}
+
+// DueAtGT applies the GT predicate on the "due_at" field.
+func DueAtGT(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldGT(FieldDueAt, v))
+}
+
+// DueAtGTE applies the GTE predicate on the "due_at" field.
+func DueAtGTE(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldGTE(FieldDueAt, v))
+}
+
+// DueAtLT applies the LT predicate on the "due_at" field.
+func DueAtLT(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldLT(FieldDueAt, v))
+}
+
+// DueAtLTE applies the LTE predicate on the "due_at" field.
+func DueAtLTE(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldLTE(FieldDueAt, v))
+}
+
+// DueAtIsNil applies the IsNil predicate on the "due_at" field.
+func DueAtIsNil() predicate.Turn {
+	return predicate.Turn(sql.FieldIsNull(FieldDueAt))
+}
+
+// DueAtNotNil applies the NotNil predicate on the "due_at" field.
+func DueAtNotNil() predicate.Turn {
+	return predicate.Turn(sql.FieldNotNull(FieldDueAt))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Turn {
+	return predicate.Turn(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// HasGame applies the HasEdge predicate on the "game" edge.
+func HasGame() predicate.Turn {
+	return predicate.Turn(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, GameTable, GameColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasGameWith applies the HasEdge predicate on the "game" edge with a given conditions (other predicates).
+func HasGameWith(preds ...predicate.Game) predicate.Turn {
+	return predicate.Turn(func(s *sql.Selector) {
+		step := newGameStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Turn) predicate.Turn {
+	return predicate.Turn(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Turn) predicate.Turn {
+	return predicate.Turn(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Turn) predicate.Turn {
+	return predicate.Turn(sql.NotPredicates(p))
+}
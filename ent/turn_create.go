@@ -0,0 +1,300 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/ent/turn"
+)
+
+// TurnCreate is the builder for creating a Turn entity.
+type TurnCreate struct {
+	config
+	mutation *TurnMutation
+	hooks    []Hook
+}
+
+// SetTurnID sets the "turn_id" field.
+func (_c *TurnCreate) SetTurnID(v string) *TurnCreate {
+	_c.mutation.SetTurnID(v)
+	return _c
+}
+
+// SetStatus sets the "status" field.
+func (_c *TurnCreate) SetStatus(v turn.Status) *TurnCreate {
+	_c.mutation.SetStatus(v)
+	return _c
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_c *TurnCreate) SetNillableStatus(v *turn.Status) *TurnCreate {
+	if v != nil {
+		_c.SetStatus(*v)
+	}
+	return _c
+}
+
+// SetDueAt sets the "due_at" field.
+func (_c *TurnCreate) SetDueAt(v time.Time) *TurnCreate {
+	_c.mutation.SetDueAt(v)
+	return _c
+}
+
+// SetNillableDueAt sets the "due_at" field if the given value is not nil.
+func (_c *TurnCreate) SetNillableDueAt(v *time.Time) *TurnCreate {
+	if v != nil {
+		_c.SetDueAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *TurnCreate) SetCreatedAt(v time.Time) *TurnCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *TurnCreate) SetNillableCreatedAt(v *time.Time) *TurnCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetGameID sets the "game" edge to the Game entity by ID.
+func (_c *TurnCreate) SetGameID(id int) *TurnCreate {
+	_c.mutation.SetGameID(id)
+	return _c
+}
+
+// SetGame sets the "game" edge to the Game entity.
+func (_c *TurnCreate) SetGame(v *Game) *TurnCreate {
+	return _c.SetGameID(v.ID)
+}
+
+// Mutation returns the TurnMutation object of the builder.
+func (_c *TurnCreate) Mutation() *TurnMutation {
+	return _c.mutation
+}
+
+// Save creates the Turn in the database.
+func (_c *TurnCreate) Save(ctx context.Context) (*Turn, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *TurnCreate) SaveX(ctx context.Context) *Turn {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TurnCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TurnCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *TurnCreate) defaults() {
+	if _, ok := _c.mutation.Status(); !ok {
+		v := turn.DefaultStatus
+		_c.mutation.SetStatus(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := turn.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *TurnCreate) check() error {
+	if _, ok := _c.mutation.TurnID(); !ok {
+		return &ValidationError{Name: "turn_id", err: errors.New(`ent: missing required field "Turn.turn_id"`)}
+	}
+	if v, ok := _c.mutation.TurnID(); ok {
+		if err := turn.TurnIDValidator(v); err != nil {
+			return &ValidationError{Name: "turn_id", err: fmt.Errorf(`ent: validator failed for field "Turn.turn_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Turn.status"`)}
+	}
+	if v, ok := _c.mutation.Status(); ok {
+		if err := turn.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Turn.status": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Turn.created_at"`)}
+	}
+	if len(_c.mutation.GameIDs()) == 0 {
+		return &ValidationError{Name: "game", err: errors.New(`ent: missing required edge "Turn.game"`)}
+	}
+	return nil
+}
+
+func (_c *TurnCreate) sqlSave(ctx context.Context) (*Turn, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *TurnCreate) createSpec() (*Turn, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Turn{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(turn.Table, sqlgraph.NewFieldSpec(turn.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.TurnID(); ok {
+		_spec.SetField(turn.FieldTurnID, field.TypeString, value)
+		_node.TurnID = value
+	}
+	if value, ok := _c.mutation.Status(); ok {
+		_spec.SetField(turn.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := _c.mutation.DueAt(); ok {
+		_spec.SetField(turn.FieldDueAt, field.TypeTime, value)
+		_node.DueAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(turn.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.GameIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   turn.GameTable,
+			Columns: []string{turn.GameColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(game.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.game_turns = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// TurnCreateBulk is the builder for creating many Turn entities in bulk.
+type TurnCreateBulk struct {
+	config
+	err      error
+	builders []*TurnCreate
+}
+
+// Save creates the Turn entities in the database.
+func (_c *TurnCreateBulk) Save(ctx context.Context) ([]*Turn, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Turn, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TurnMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *TurnCreateBulk) SaveX(ctx context.Context) []*Turn {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TurnCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TurnCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
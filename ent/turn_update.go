@@ -0,0 +1,431 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/ent/predicate"
+	"github.com/mdhender/ottomat/ent/turn"
+)
+
+// TurnUpdate is the builder for updating Turn entities.
+type TurnUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TurnMutation
+}
+
+// Where appends a list predicates to the TurnUpdate builder.
+func (_u *TurnUpdate) Where(ps ...predicate.Turn) *TurnUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetTurnID sets the "turn_id" field.
+func (_u *TurnUpdate) SetTurnID(v string) *TurnUpdate {
+	_u.mutation.SetTurnID(v)
+	return _u
+}
+
+// SetNillableTurnID sets the "turn_id" field if the given value is not nil.
+func (_u *TurnUpdate) SetNillableTurnID(v *string) *TurnUpdate {
+	if v != nil {
+		_u.SetTurnID(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *TurnUpdate) SetStatus(v turn.Status) *TurnUpdate {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *TurnUpdate) SetNillableStatus(v *turn.Status) *TurnUpdate {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetDueAt sets the "due_at" field.
+func (_u *TurnUpdate) SetDueAt(v time.Time) *TurnUpdate {
+	_u.mutation.SetDueAt(v)
+	return _u
+}
+
+// SetNillableDueAt sets the "due_at" field if the given value is not nil.
+func (_u *TurnUpdate) SetNillableDueAt(v *time.Time) *TurnUpdate {
+	if v != nil {
+		_u.SetDueAt(*v)
+	}
+	return _u
+}
+
+// ClearDueAt clears the value of the "due_at" field.
+func (_u *TurnUpdate) ClearDueAt() *TurnUpdate {
+	_u.mutation.ClearDueAt()
+	return _u
+}
+
+// SetGameID sets the "game" edge to the Game entity by ID.
+func (_u *TurnUpdate) SetGameID(id int) *TurnUpdate {
+	_u.mutation.SetGameID(id)
+	return _u
+}
+
+// SetGame sets the "game" edge to the Game entity.
+func (_u *TurnUpdate) SetGame(v *Game) *TurnUpdate {
+	return _u.SetGameID(v.ID)
+}
+
+// Mutation returns the TurnMutation object of the builder.
+func (_u *TurnUpdate) Mutation() *TurnMutation {
+	return _u.mutation
+}
+
+// ClearGame clears the "game" edge to the Game entity.
+func (_u *TurnUpdate) ClearGame() *TurnUpdate {
+	_u.mutation.ClearGame()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *TurnUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TurnUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *TurnUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TurnUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TurnUpdate) check() error {
+	if v, ok := _u.mutation.TurnID(); ok {
+		if err := turn.TurnIDValidator(v); err != nil {
+			return &ValidationError{Name: "turn_id", err: fmt.Errorf(`ent: validator failed for field "Turn.turn_id": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Status(); ok {
+		if err := turn.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Turn.status": %w`, err)}
+		}
+	}
+	if _u.mutation.GameCleared() && len(_u.mutation.GameIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Turn.game"`)
+	}
+	return nil
+}
+
+func (_u *TurnUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(turn.Table, turn.Columns, sqlgraph.NewFieldSpec(turn.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.TurnID(); ok {
+		_spec.SetField(turn.FieldTurnID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(turn.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.DueAt(); ok {
+		_spec.SetField(turn.FieldDueAt, field.TypeTime, value)
+	}
+	if _u.mutation.DueAtCleared() {
+		_spec.ClearField(turn.FieldDueAt, field.TypeTime)
+	}
+	if _u.mutation.GameCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   turn.GameTable,
+			Columns: []string{turn.GameColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(game.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.GameIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   turn.GameTable,
+			Columns: []string{turn.GameColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(game.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{turn.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// TurnUpdateOne is the builder for updating a single Turn entity.
+type TurnUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TurnMutation
+}
+
+// SetTurnID sets the "turn_id" field.
+func (_u *TurnUpdateOne) SetTurnID(v string) *TurnUpdateOne {
+	_u.mutation.SetTurnID(v)
+	return _u
+}
+
+// SetNillableTurnID sets the "turn_id" field if the given value is not nil.
+func (_u *TurnUpdateOne) SetNillableTurnID(v *string) *TurnUpdateOne {
+	if v != nil {
+		_u.SetTurnID(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *TurnUpdateOne) SetStatus(v turn.Status) *TurnUpdateOne {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *TurnUpdateOne) SetNillableStatus(v *turn.Status) *TurnUpdateOne {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetDueAt sets the "due_at" field.
+func (_u *TurnUpdateOne) SetDueAt(v time.Time) *TurnUpdateOne {
+	_u.mutation.SetDueAt(v)
+	return _u
+}
+
+// SetNillableDueAt sets the "due_at" field if the given value is not nil.
+func (_u *TurnUpdateOne) SetNillableDueAt(v *time.Time) *TurnUpdateOne {
+	if v != nil {
+		_u.SetDueAt(*v)
+	}
+	return _u
+}
+
+// ClearDueAt clears the value of the "due_at" field.
+func (_u *TurnUpdateOne) ClearDueAt() *TurnUpdateOne {
+	_u.mutation.ClearDueAt()
+	return _u
+}
+
+// SetGameID sets the "game" edge to the Game entity by ID.
+func (_u *TurnUpdateOne) SetGameID(id int) *TurnUpdateOne {
+	_u.mutation.SetGameID(id)
+	return _u
+}
+
+// SetGame sets the "game" edge to the Game entity.
+func (_u *TurnUpdateOne) SetGame(v *Game) *TurnUpdateOne {
+	return _u.SetGameID(v.ID)
+}
+
+// Mutation returns the TurnMutation object of the builder.
+func (_u *TurnUpdateOne) Mutation() *TurnMutation {
+	return _u.mutation
+}
+
+// ClearGame clears the "game" edge to the Game entity.
+func (_u *TurnUpdateOne) ClearGame() *TurnUpdateOne {
+	_u.mutation.ClearGame()
+	return _u
+}
+
+// Where appends a list predicates to the TurnUpdate builder.
+func (_u *TurnUpdateOne) Where(ps ...predicate.Turn) *TurnUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *TurnUpdateOne) Select(field string, fields ...string) *TurnUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Turn entity.
+func (_u *TurnUpdateOne) Save(ctx context.Context) (*Turn, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TurnUpdateOne) SaveX(ctx context.Context) *Turn {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *TurnUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TurnUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TurnUpdateOne) check() error {
+	if v, ok := _u.mutation.TurnID(); ok {
+		if err := turn.TurnIDValidator(v); err != nil {
+			return &ValidationError{Name: "turn_id", err: fmt.Errorf(`ent: validator failed for field "Turn.turn_id": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Status(); ok {
+		if err := turn.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Turn.status": %w`, err)}
+		}
+	}
+	if _u.mutation.GameCleared() && len(_u.mutation.GameIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Turn.game"`)
+	}
+	return nil
+}
+
+func (_u *TurnUpdateOne) sqlSave(ctx context.Context) (_node *Turn, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(turn.Table, turn.Columns, sqlgraph.NewFieldSpec(turn.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Turn.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, turn.FieldID)
+		for _, f := range fields {
+			if !turn.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != turn.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.TurnID(); ok {
+		_spec.SetField(turn.FieldTurnID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(turn.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.DueAt(); ok {
+		_spec.SetField(turn.FieldDueAt, field.TypeTime, value)
+	}
+	if _u.mutation.DueAtCleared() {
+		_spec.ClearField(turn.FieldDueAt, field.TypeTime)
+	}
+	if _u.mutation.GameCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   turn.GameTable,
+			Columns: []string{turn.GameColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(game.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.GameIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   turn.GameTable,
+			Columns: []string{turn.GameColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(game.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Turn{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{turn.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
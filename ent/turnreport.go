@@ -0,0 +1,191 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/turnreport"
+)
+
+// TurnReport is the model entity for the TurnReport schema.
+type TurnReport struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// TurnID holds the value of the "turn_id" field.
+	TurnID string `json:"turn_id,omitempty"`
+	// OriginalFilename holds the value of the "original_filename" field.
+	OriginalFilename string `json:"original_filename,omitempty"`
+	// Raw holds the value of the "raw" field.
+	Raw []byte `json:"raw,omitempty"`
+	// Sha256 holds the value of the "sha256" field.
+	Sha256 string `json:"sha256,omitempty"`
+	// UploadedAt holds the value of the "uploaded_at" field.
+	UploadedAt time.Time `json:"uploaded_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the TurnReportQuery when eager-loading is set.
+	Edges        TurnReportEdges `json:"edges"`
+	clan_reports *int
+	selectValues sql.SelectValues
+}
+
+// TurnReportEdges holds the relations/edges for other nodes in the graph.
+type TurnReportEdges struct {
+	// Clan holds the value of the clan edge.
+	Clan *Clan `json:"clan,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// ClanOrErr returns the Clan value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TurnReportEdges) ClanOrErr() (*Clan, error) {
+	if e.Clan != nil {
+		return e.Clan, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: clan.Label}
+	}
+	return nil, &NotLoadedError{edge: "clan"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TurnReport) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case turnreport.FieldRaw:
+			values[i] = new([]byte)
+		case turnreport.FieldID:
+			values[i] = new(sql.NullInt64)
+		case turnreport.FieldTurnID, turnreport.FieldOriginalFilename, turnreport.FieldSha256:
+			values[i] = new(sql.NullString)
+		case turnreport.FieldUploadedAt:
+			values[i] = new(sql.NullTime)
+		case turnreport.ForeignKeys[0]: // clan_reports
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TurnReport fields.
+func (_m *TurnReport) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case turnreport.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case turnreport.FieldTurnID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field turn_id", values[i])
+			} else if value.Valid {
+				_m.TurnID = value.String
+			}
+		case turnreport.FieldOriginalFilename:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field original_filename", values[i])
+			} else if value.Valid {
+				_m.OriginalFilename = value.String
+			}
+		case turnreport.FieldRaw:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field raw", values[i])
+			} else if value != nil {
+				_m.Raw = *value
+			}
+		case turnreport.FieldSha256:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field sha256", values[i])
+			} else if value.Valid {
+				_m.Sha256 = value.String
+			}
+		case turnreport.FieldUploadedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field uploaded_at", values[i])
+			} else if value.Valid {
+				_m.UploadedAt = value.Time
+			}
+		case turnreport.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field clan_reports", value)
+			} else if value.Valid {
+				_m.clan_reports = new(int)
+				*_m.clan_reports = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TurnReport.
+// This includes values selected through modifiers, order, etc.
+func (_m *TurnReport) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryClan queries the "clan" edge of the TurnReport entity.
+func (_m *TurnReport) QueryClan() *ClanQuery {
+	return NewTurnReportClient(_m.config).QueryClan(_m)
+}
+
+// Update returns a builder for updating this TurnReport.
+// Note that you need to call TurnReport.Unwrap() before calling this method if this TurnReport
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *TurnReport) Update() *TurnReportUpdateOne {
+	return NewTurnReportClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the TurnReport entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *TurnReport) Unwrap() *TurnReport {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: TurnReport is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *TurnReport) String() string {
+	var builder strings.Builder
+	builder.WriteString("TurnReport(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("turn_id=")
+	builder.WriteString(_m.TurnID)
+	builder.WriteString(", ")
+	builder.WriteString("original_filename=")
+	builder.WriteString(_m.OriginalFilename)
+	builder.WriteString(", ")
+	builder.WriteString("raw=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Raw))
+	builder.WriteString(", ")
+	builder.WriteString("sha256=")
+	builder.WriteString(_m.Sha256)
+	builder.WriteString(", ")
+	builder.WriteString("uploaded_at=")
+	builder.WriteString(_m.UploadedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// TurnReports is a parsable slice of TurnReport.
+type TurnReports []*TurnReport
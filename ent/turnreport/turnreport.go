@@ -0,0 +1,122 @@
+// Code generated by ent, DO NOT EDIT.
+
+package turnreport
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the turnreport type in the database.
+	Label = "turn_report"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTurnID holds the string denoting the turn_id field in the database.
+	FieldTurnID = "turn_id"
+	// FieldOriginalFilename holds the string denoting the original_filename field in the database.
+	FieldOriginalFilename = "original_filename"
+	// FieldRaw holds the string denoting the raw field in the database.
+	FieldRaw = "raw"
+	// FieldSha256 holds the string denoting the sha256 field in the database.
+	FieldSha256 = "sha256"
+	// FieldUploadedAt holds the string denoting the uploaded_at field in the database.
+	FieldUploadedAt = "uploaded_at"
+	// EdgeClan holds the string denoting the clan edge name in mutations.
+	EdgeClan = "clan"
+	// Table holds the table name of the turnreport in the database.
+	Table = "turn_reports"
+	// ClanTable is the table that holds the clan relation/edge.
+	ClanTable = "turn_reports"
+	// ClanInverseTable is the table name for the Clan entity.
+	// It exists in this package in order to avoid circular dependency with the "clan" package.
+	ClanInverseTable = "clans"
+	// ClanColumn is the table column denoting the clan relation/edge.
+	ClanColumn = "clan_reports"
+)
+
+// Columns holds all SQL columns for turnreport fields.
+var Columns = []string{
+	FieldID,
+	FieldTurnID,
+	FieldOriginalFilename,
+	FieldRaw,
+	FieldSha256,
+	FieldUploadedAt,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "turn_reports"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"clan_reports",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// TurnIDValidator is a validator for the "turn_id" field. It is called by the builders before save.
+	TurnIDValidator func(string) error
+	// OriginalFilenameValidator is a validator for the "original_filename" field. It is called by the builders before save.
+	OriginalFilenameValidator func(string) error
+	// Sha256Validator is a validator for the "sha256" field. It is called by the builders before save.
+	Sha256Validator func(string) error
+	// DefaultUploadedAt holds the default value on creation for the "uploaded_at" field.
+	DefaultUploadedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the TurnReport queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByTurnID orders the results by the turn_id field.
+func ByTurnID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTurnID, opts...).ToFunc()
+}
+
+// ByOriginalFilename orders the results by the original_filename field.
+func ByOriginalFilename(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOriginalFilename, opts...).ToFunc()
+}
+
+// BySha256 orders the results by the sha256 field.
+func BySha256(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSha256, opts...).ToFunc()
+}
+
+// ByUploadedAt orders the results by the uploaded_at field.
+func ByUploadedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUploadedAt, opts...).ToFunc()
+}
+
+// ByClanField orders the results by clan field.
+func ByClanField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newClanStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newClanStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ClanInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, ClanTable, ClanColumn),
+	)
+}
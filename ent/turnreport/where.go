@@ -0,0 +1,394 @@
+// Code generated by ent, DO NOT EDIT.
+
+package turnreport
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/mdhender/ottomat/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLTE(FieldID, id))
+}
+
+// TurnID applies equality check predicate on the "turn_id" field. It's identical to TurnIDEQ.
+func TurnID(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldTurnID, v))
+}
+
+// OriginalFilename applies equality check predicate on the "original_filename" field. It's identical to OriginalFilenameEQ.
+func OriginalFilename(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldOriginalFilename, v))
+}
+
+// Raw applies equality check predicate on the "raw" field. It's identical to RawEQ.
+func Raw(v []byte) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldRaw, v))
+}
+
+// Sha256 applies equality check predicate on the "sha256" field. It's identical to Sha256EQ.
+func Sha256(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldSha256, v))
+}
+
+// UploadedAt applies equality check predicate on the "uploaded_at" field. It's identical to UploadedAtEQ.
+func UploadedAt(v time.Time) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldUploadedAt, v))
+}
+
+// TurnIDEQ applies the EQ predicate on the "turn_id" field.
+func TurnIDEQ(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldTurnID, v))
+}
+
+// TurnIDNEQ applies the NEQ predicate on the "turn_id" field.
+func TurnIDNEQ(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNEQ(FieldTurnID, v))
+}
+
+// TurnIDIn applies the In predicate on the "turn_id" field.
+func TurnIDIn(vs ...string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldIn(FieldTurnID, vs...))
+}
+
+// TurnIDNotIn applies the NotIn predicate on the "turn_id" field.
+func TurnIDNotIn(vs ...string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNotIn(FieldTurnID, vs...))
+}
+
+// TurnIDGT applies the GT predicate on the "turn_id" field.
+func TurnIDGT(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGT(FieldTurnID, v))
+}
+
+// TurnIDGTE applies the GTE predicate on the "turn_id" field.
+func TurnIDGTE(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGTE(FieldTurnID, v))
+}
+
+// TurnIDLT applies the LT predicate on the "turn_id" field.
+func TurnIDLT(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLT(FieldTurnID, v))
+}
+
+// TurnIDLTE applies the LTE predicate on the "turn_id" field.
+func TurnIDLTE(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLTE(FieldTurnID, v))
+}
+
+// TurnIDContains applies the Contains predicate on the "turn_id" field.
+func TurnIDContains(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldContains(FieldTurnID, v))
+}
+
+// TurnIDHasPrefix applies the HasPrefix predicate on the "turn_id" field.
+func TurnIDHasPrefix(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldHasPrefix(FieldTurnID, v))
+}
+
+// TurnIDHasSuffix applies the HasSuffix predicate on the "turn_id" field.
+func TurnIDHasSuffix(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldHasSuffix(FieldTurnID, v))
+}
+
+// TurnIDEqualFold applies the EqualFold predicate on the "turn_id" field.
+func TurnIDEqualFold(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEqualFold(FieldTurnID, v))
+}
+
+// TurnIDContainsFold applies the ContainsFold predicate on the "turn_id" field.
+func TurnIDContainsFold(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldContainsFold(FieldTurnID, v))
+}
+
+// OriginalFilenameEQ applies the EQ predicate on the "original_filename" field.
+func OriginalFilenameEQ(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldOriginalFilename, v))
+}
+
+// OriginalFilenameNEQ applies the NEQ predicate on the "original_filename" field.
+func OriginalFilenameNEQ(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNEQ(FieldOriginalFilename, v))
+}
+
+// OriginalFilenameIn applies the In predicate on the "original_filename" field.
+func OriginalFilenameIn(vs ...string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldIn(FieldOriginalFilename, vs...))
+}
+
+// OriginalFilenameNotIn applies the NotIn predicate on the "original_filename" field.
+func OriginalFilenameNotIn(vs ...string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNotIn(FieldOriginalFilename, vs...))
+}
+
+// OriginalFilenameGT applies the GT predicate on the "original_filename" field.
+func OriginalFilenameGT(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGT(FieldOriginalFilename, v))
+}
+
+// OriginalFilenameGTE applies the GTE predicate on the "original_filename" field.
+func OriginalFilenameGTE(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGTE(FieldOriginalFilename, v))
+}
+
+// OriginalFilenameLT applies the LT predicate on the "original_filename" field.
+func OriginalFilenameLT(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLT(FieldOriginalFilename, v))
+}
+
+// OriginalFilenameLTE applies the LTE predicate on the "original_filename" field.
+func OriginalFilenameLTE(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLTE(FieldOriginalFilename, v))
+}
+
+// OriginalFilenameContains applies the Contains predicate on the "original_filename" field.
+func OriginalFilenameContains(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldContains(FieldOriginalFilename, v))
+}
+
+// OriginalFilenameHasPrefix applies the HasPrefix predicate on the "original_filename" field.
+func OriginalFilenameHasPrefix(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldHasPrefix(FieldOriginalFilename, v))
+}
+
+// OriginalFilenameHasSuffix applies the HasSuffix predicate on the "original_filename" field.
+func OriginalFilenameHasSuffix(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldHasSuffix(FieldOriginalFilename, v))
+}
+
+// OriginalFilenameEqualFold applies the EqualFold predicate on the "original_filename" field.
+func OriginalFilenameEqualFold(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEqualFold(FieldOriginalFilename, v))
+}
+
+// OriginalFilenameContainsFold applies the ContainsFold predicate on the "original_filename" field.
+func OriginalFilenameContainsFold(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldContainsFold(FieldOriginalFilename, v))
+}
+
+// RawEQ applies the EQ predicate on the "raw" field.
+func RawEQ(v []byte) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldRaw, v))
+}
+
+// RawNEQ applies the NEQ predicate on the "raw" field.
+func RawNEQ(v []byte) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNEQ(FieldRaw, v))
+}
+
+// RawIn applies the In predicate on the "raw" field.
+func RawIn(vs ...[]byte) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldIn(FieldRaw, vs...))
+}
+
+// RawNotIn applies the NotIn predicate on the "raw" field.
+func RawNotIn(vs ...[]byte) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNotIn(FieldRaw, vs...))
+}
+
+// RawGT applies the GT predicate on the "raw" field.
+func RawGT(v []byte) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGT(FieldRaw, v))
+}
+
+// RawGTE applies the GTE predicate on the "raw" field.
+func RawGTE(v []byte) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGTE(FieldRaw, v))
+}
+
+// RawLT applies the LT predicate on the "raw" field.
+func RawLT(v []byte) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLT(FieldRaw, v))
+}
+
+// RawLTE applies the LTE predicate on the "raw" field.
+func RawLTE(v []byte) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLTE(FieldRaw, v))
+}
+
+// Sha256EQ applies the EQ predicate on the "sha256" field.
+func Sha256EQ(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldSha256, v))
+}
+
+// Sha256NEQ applies the NEQ predicate on the "sha256" field.
+func Sha256NEQ(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNEQ(FieldSha256, v))
+}
+
+// Sha256In applies the In predicate on the "sha256" field.
+func Sha256In(vs ...string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldIn(FieldSha256, vs...))
+}
+
+// Sha256NotIn applies the NotIn predicate on the "sha256" field.
+func Sha256NotIn(vs ...string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNotIn(FieldSha256, vs...))
+}
+
+// Sha256GT applies the GT predicate on the "sha256" field.
+func Sha256GT(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGT(FieldSha256, v))
+}
+
+// Sha256GTE applies the GTE predicate on the "sha256" field.
+func Sha256GTE(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGTE(FieldSha256, v))
+}
+
+// Sha256LT applies the LT predicate on the "sha256" field.
+func Sha256LT(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLT(FieldSha256, v))
+}
+
+// Sha256LTE applies the LTE predicate on the "sha256" field.
+func Sha256LTE(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLTE(FieldSha256, v))
+}
+
+// Sha256Contains applies the Contains predicate on the "sha256" field.
+func Sha256Contains(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldContains(FieldSha256, v))
+}
+
+// Sha256HasPrefix applies the HasPrefix predicate on the "sha256" field.
+func Sha256HasPrefix(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldHasPrefix(FieldSha256, v))
+}
+
+// Sha256HasSuffix applies the HasSuffix predicate on the "sha256" field.
+func Sha256HasSuffix(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldHasSuffix(FieldSha256, v))
+}
+
+// Sha256EqualFold applies the EqualFold predicate on the "sha256" field.
+func Sha256EqualFold(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEqualFold(FieldSha256, v))
+}
+
+// Sha256ContainsFold applies the ContainsFold predicate on the "sha256" field.
+func Sha256ContainsFold(v string) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldContainsFold(FieldSha256, v))
+}
+
+// UploadedAtEQ applies the EQ predicate on the "uploaded_at" field.
+func UploadedAtEQ(v time.Time) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldEQ(FieldUploadedAt, v))
+}
+
+// UploadedAtNEQ applies the NEQ predicate on the "uploaded_at" field.
+func UploadedAtNEQ(v time.Time) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNEQ(FieldUploadedAt, v))
+}
+
+// UploadedAtIn applies the In predicate on the "uploaded_at" field.
+func UploadedAtIn(vs ...time.Time) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldIn(FieldUploadedAt, vs...))
+}
+
+// UploadedAtNotIn applies the NotIn predicate on the "uploaded_at" field.
+func UploadedAtNotIn(vs ...time.Time) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldNotIn(FieldUploadedAt, vs...))
+}
+
+// UploadedAtGT applies the GT predicate on the "uploaded_at" field.
+func UploadedAtGT(v time.Time) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGT(FieldUploadedAt, v))
+}
+
+// UploadedAtGTE applies the GTE predicate on the "uploaded_at" field.
+func UploadedAtGTE(v time.Time) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldGTE(FieldUploadedAt, v))
+}
+
+// UploadedAtLT applies the LT predicate on the "uploaded_at" field.
+func UploadedAtLT(v time.Time) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLT(FieldUploadedAt, v))
+}
+
+// UploadedAtLTE applies the LTE predicate on the "uploaded_at" field.
+func UploadedAtLTE(v time.Time) predicate.TurnReport {
+	return predicate.TurnReport(sql.FieldLTE(FieldUploadedAt, v))
+}
+
+// HasClan applies the HasEdge predicate on the "clan" edge.
+func HasClan() predicate.TurnReport {
+	return predicate.TurnReport(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, ClanTable, ClanColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasClanWith applies the HasEdge predicate on the "clan" edge with a given conditions (other predicates).
+func HasClanWith(preds ...predicate.Clan) predicate.TurnReport {
+	return predicate.TurnReport(func(s *sql.Selector) {
+		step := newClanStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.TurnReport) predicate.TurnReport {
+	return predicate.TurnReport(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.TurnReport) predicate.TurnReport {
+	return predicate.TurnReport(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.TurnReport) predicate.TurnReport {
+	return predicate.TurnReport(sql.NotPredicates(p))
+}
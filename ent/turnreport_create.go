@@ -0,0 +1,301 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/turnreport"
+)
+
+// TurnReportCreate is the builder for creating a TurnReport entity.
+type TurnReportCreate struct {
+	config
+	mutation *TurnReportMutation
+	hooks    []Hook
+}
+
+// SetTurnID sets the "turn_id" field.
+func (_c *TurnReportCreate) SetTurnID(v string) *TurnReportCreate {
+	_c.mutation.SetTurnID(v)
+	return _c
+}
+
+// SetOriginalFilename sets the "original_filename" field.
+func (_c *TurnReportCreate) SetOriginalFilename(v string) *TurnReportCreate {
+	_c.mutation.SetOriginalFilename(v)
+	return _c
+}
+
+// SetRaw sets the "raw" field.
+func (_c *TurnReportCreate) SetRaw(v []byte) *TurnReportCreate {
+	_c.mutation.SetRaw(v)
+	return _c
+}
+
+// SetSha256 sets the "sha256" field.
+func (_c *TurnReportCreate) SetSha256(v string) *TurnReportCreate {
+	_c.mutation.SetSha256(v)
+	return _c
+}
+
+// SetUploadedAt sets the "uploaded_at" field.
+func (_c *TurnReportCreate) SetUploadedAt(v time.Time) *TurnReportCreate {
+	_c.mutation.SetUploadedAt(v)
+	return _c
+}
+
+// SetNillableUploadedAt sets the "uploaded_at" field if the given value is not nil.
+func (_c *TurnReportCreate) SetNillableUploadedAt(v *time.Time) *TurnReportCreate {
+	if v != nil {
+		_c.SetUploadedAt(*v)
+	}
+	return _c
+}
+
+// SetClanID sets the "clan" edge to the Clan entity by ID.
+func (_c *TurnReportCreate) SetClanID(id int) *TurnReportCreate {
+	_c.mutation.SetClanID(id)
+	return _c
+}
+
+// SetClan sets the "clan" edge to the Clan entity.
+func (_c *TurnReportCreate) SetClan(v *Clan) *TurnReportCreate {
+	return _c.SetClanID(v.ID)
+}
+
+// Mutation returns the TurnReportMutation object of the builder.
+func (_c *TurnReportCreate) Mutation() *TurnReportMutation {
+	return _c.mutation
+}
+
+// Save creates the TurnReport in the database.
+func (_c *TurnReportCreate) Save(ctx context.Context) (*TurnReport, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *TurnReportCreate) SaveX(ctx context.Context) *TurnReport {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TurnReportCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TurnReportCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *TurnReportCreate) defaults() {
+	if _, ok := _c.mutation.UploadedAt(); !ok {
+		v := turnreport.DefaultUploadedAt()
+		_c.mutation.SetUploadedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *TurnReportCreate) check() error {
+	if _, ok := _c.mutation.TurnID(); !ok {
+		return &ValidationError{Name: "turn_id", err: errors.New(`ent: missing required field "TurnReport.turn_id"`)}
+	}
+	if v, ok := _c.mutation.TurnID(); ok {
+		if err := turnreport.TurnIDValidator(v); err != nil {
+			return &ValidationError{Name: "turn_id", err: fmt.Errorf(`ent: validator failed for field "TurnReport.turn_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.OriginalFilename(); !ok {
+		return &ValidationError{Name: "original_filename", err: errors.New(`ent: missing required field "TurnReport.original_filename"`)}
+	}
+	if v, ok := _c.mutation.OriginalFilename(); ok {
+		if err := turnreport.OriginalFilenameValidator(v); err != nil {
+			return &ValidationError{Name: "original_filename", err: fmt.Errorf(`ent: validator failed for field "TurnReport.original_filename": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Raw(); !ok {
+		return &ValidationError{Name: "raw", err: errors.New(`ent: missing required field "TurnReport.raw"`)}
+	}
+	if _, ok := _c.mutation.Sha256(); !ok {
+		return &ValidationError{Name: "sha256", err: errors.New(`ent: missing required field "TurnReport.sha256"`)}
+	}
+	if v, ok := _c.mutation.Sha256(); ok {
+		if err := turnreport.Sha256Validator(v); err != nil {
+			return &ValidationError{Name: "sha256", err: fmt.Errorf(`ent: validator failed for field "TurnReport.sha256": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.UploadedAt(); !ok {
+		return &ValidationError{Name: "uploaded_at", err: errors.New(`ent: missing required field "TurnReport.uploaded_at"`)}
+	}
+	if len(_c.mutation.ClanIDs()) == 0 {
+		return &ValidationError{Name: "clan", err: errors.New(`ent: missing required edge "TurnReport.clan"`)}
+	}
+	return nil
+}
+
+func (_c *TurnReportCreate) sqlSave(ctx context.Context) (*TurnReport, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *TurnReportCreate) createSpec() (*TurnReport, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TurnReport{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(turnreport.Table, sqlgraph.NewFieldSpec(turnreport.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.TurnID(); ok {
+		_spec.SetField(turnreport.FieldTurnID, field.TypeString, value)
+		_node.TurnID = value
+	}
+	if value, ok := _c.mutation.OriginalFilename(); ok {
+		_spec.SetField(turnreport.FieldOriginalFilename, field.TypeString, value)
+		_node.OriginalFilename = value
+	}
+	if value, ok := _c.mutation.Raw(); ok {
+		_spec.SetField(turnreport.FieldRaw, field.TypeBytes, value)
+		_node.Raw = value
+	}
+	if value, ok := _c.mutation.Sha256(); ok {
+		_spec.SetField(turnreport.FieldSha256, field.TypeString, value)
+		_node.Sha256 = value
+	}
+	if value, ok := _c.mutation.UploadedAt(); ok {
+		_spec.SetField(turnreport.FieldUploadedAt, field.TypeTime, value)
+		_node.UploadedAt = value
+	}
+	if nodes := _c.mutation.ClanIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   turnreport.ClanTable,
+			Columns: []string{turnreport.ClanColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.clan_reports = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// TurnReportCreateBulk is the builder for creating many TurnReport entities in bulk.
+type TurnReportCreateBulk struct {
+	config
+	err      error
+	builders []*TurnReportCreate
+}
+
+// Save creates the TurnReport entities in the database.
+func (_c *TurnReportCreateBulk) Save(ctx context.Context) ([]*TurnReport, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*TurnReport, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TurnReportMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *TurnReportCreateBulk) SaveX(ctx context.Context) []*TurnReport {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TurnReportCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TurnReportCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
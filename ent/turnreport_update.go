@@ -0,0 +1,440 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/predicate"
+	"github.com/mdhender/ottomat/ent/turnreport"
+)
+
+// TurnReportUpdate is the builder for updating TurnReport entities.
+type TurnReportUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TurnReportMutation
+}
+
+// Where appends a list predicates to the TurnReportUpdate builder.
+func (_u *TurnReportUpdate) Where(ps ...predicate.TurnReport) *TurnReportUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetTurnID sets the "turn_id" field.
+func (_u *TurnReportUpdate) SetTurnID(v string) *TurnReportUpdate {
+	_u.mutation.SetTurnID(v)
+	return _u
+}
+
+// SetNillableTurnID sets the "turn_id" field if the given value is not nil.
+func (_u *TurnReportUpdate) SetNillableTurnID(v *string) *TurnReportUpdate {
+	if v != nil {
+		_u.SetTurnID(*v)
+	}
+	return _u
+}
+
+// SetOriginalFilename sets the "original_filename" field.
+func (_u *TurnReportUpdate) SetOriginalFilename(v string) *TurnReportUpdate {
+	_u.mutation.SetOriginalFilename(v)
+	return _u
+}
+
+// SetNillableOriginalFilename sets the "original_filename" field if the given value is not nil.
+func (_u *TurnReportUpdate) SetNillableOriginalFilename(v *string) *TurnReportUpdate {
+	if v != nil {
+		_u.SetOriginalFilename(*v)
+	}
+	return _u
+}
+
+// SetRaw sets the "raw" field.
+func (_u *TurnReportUpdate) SetRaw(v []byte) *TurnReportUpdate {
+	_u.mutation.SetRaw(v)
+	return _u
+}
+
+// SetSha256 sets the "sha256" field.
+func (_u *TurnReportUpdate) SetSha256(v string) *TurnReportUpdate {
+	_u.mutation.SetSha256(v)
+	return _u
+}
+
+// SetNillableSha256 sets the "sha256" field if the given value is not nil.
+func (_u *TurnReportUpdate) SetNillableSha256(v *string) *TurnReportUpdate {
+	if v != nil {
+		_u.SetSha256(*v)
+	}
+	return _u
+}
+
+// SetClanID sets the "clan" edge to the Clan entity by ID.
+func (_u *TurnReportUpdate) SetClanID(id int) *TurnReportUpdate {
+	_u.mutation.SetClanID(id)
+	return _u
+}
+
+// SetClan sets the "clan" edge to the Clan entity.
+func (_u *TurnReportUpdate) SetClan(v *Clan) *TurnReportUpdate {
+	return _u.SetClanID(v.ID)
+}
+
+// Mutation returns the TurnReportMutation object of the builder.
+func (_u *TurnReportUpdate) Mutation() *TurnReportMutation {
+	return _u.mutation
+}
+
+// ClearClan clears the "clan" edge to the Clan entity.
+func (_u *TurnReportUpdate) ClearClan() *TurnReportUpdate {
+	_u.mutation.ClearClan()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *TurnReportUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TurnReportUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *TurnReportUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TurnReportUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TurnReportUpdate) check() error {
+	if v, ok := _u.mutation.TurnID(); ok {
+		if err := turnreport.TurnIDValidator(v); err != nil {
+			return &ValidationError{Name: "turn_id", err: fmt.Errorf(`ent: validator failed for field "TurnReport.turn_id": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.OriginalFilename(); ok {
+		if err := turnreport.OriginalFilenameValidator(v); err != nil {
+			return &ValidationError{Name: "original_filename", err: fmt.Errorf(`ent: validator failed for field "TurnReport.original_filename": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Sha256(); ok {
+		if err := turnreport.Sha256Validator(v); err != nil {
+			return &ValidationError{Name: "sha256", err: fmt.Errorf(`ent: validator failed for field "TurnReport.sha256": %w`, err)}
+		}
+	}
+	if _u.mutation.ClanCleared() && len(_u.mutation.ClanIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "TurnReport.clan"`)
+	}
+	return nil
+}
+
+func (_u *TurnReportUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(turnreport.Table, turnreport.Columns, sqlgraph.NewFieldSpec(turnreport.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.TurnID(); ok {
+		_spec.SetField(turnreport.FieldTurnID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.OriginalFilename(); ok {
+		_spec.SetField(turnreport.FieldOriginalFilename, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Raw(); ok {
+		_spec.SetField(turnreport.FieldRaw, field.TypeBytes, value)
+	}
+	if value, ok := _u.mutation.Sha256(); ok {
+		_spec.SetField(turnreport.FieldSha256, field.TypeString, value)
+	}
+	if _u.mutation.ClanCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   turnreport.ClanTable,
+			Columns: []string{turnreport.ClanColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ClanIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   turnreport.ClanTable,
+			Columns: []string{turnreport.ClanColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{turnreport.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// TurnReportUpdateOne is the builder for updating a single TurnReport entity.
+type TurnReportUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TurnReportMutation
+}
+
+// SetTurnID sets the "turn_id" field.
+func (_u *TurnReportUpdateOne) SetTurnID(v string) *TurnReportUpdateOne {
+	_u.mutation.SetTurnID(v)
+	return _u
+}
+
+// SetNillableTurnID sets the "turn_id" field if the given value is not nil.
+func (_u *TurnReportUpdateOne) SetNillableTurnID(v *string) *TurnReportUpdateOne {
+	if v != nil {
+		_u.SetTurnID(*v)
+	}
+	return _u
+}
+
+// SetOriginalFilename sets the "original_filename" field.
+func (_u *TurnReportUpdateOne) SetOriginalFilename(v string) *TurnReportUpdateOne {
+	_u.mutation.SetOriginalFilename(v)
+	return _u
+}
+
+// SetNillableOriginalFilename sets the "original_filename" field if the given value is not nil.
+func (_u *TurnReportUpdateOne) SetNillableOriginalFilename(v *string) *TurnReportUpdateOne {
+	if v != nil {
+		_u.SetOriginalFilename(*v)
+	}
+	return _u
+}
+
+// SetRaw sets the "raw" field.
+func (_u *TurnReportUpdateOne) SetRaw(v []byte) *TurnReportUpdateOne {
+	_u.mutation.SetRaw(v)
+	return _u
+}
+
+// SetSha256 sets the "sha256" field.
+func (_u *TurnReportUpdateOne) SetSha256(v string) *TurnReportUpdateOne {
+	_u.mutation.SetSha256(v)
+	return _u
+}
+
+// SetNillableSha256 sets the "sha256" field if the given value is not nil.
+func (_u *TurnReportUpdateOne) SetNillableSha256(v *string) *TurnReportUpdateOne {
+	if v != nil {
+		_u.SetSha256(*v)
+	}
+	return _u
+}
+
+// SetClanID sets the "clan" edge to the Clan entity by ID.
+func (_u *TurnReportUpdateOne) SetClanID(id int) *TurnReportUpdateOne {
+	_u.mutation.SetClanID(id)
+	return _u
+}
+
+// SetClan sets the "clan" edge to the Clan entity.
+func (_u *TurnReportUpdateOne) SetClan(v *Clan) *TurnReportUpdateOne {
+	return _u.SetClanID(v.ID)
+}
+
+// Mutation returns the TurnReportMutation object of the builder.
+func (_u *TurnReportUpdateOne) Mutation() *TurnReportMutation {
+	return _u.mutation
+}
+
+// ClearClan clears the "clan" edge to the Clan entity.
+func (_u *TurnReportUpdateOne) ClearClan() *TurnReportUpdateOne {
+	_u.mutation.ClearClan()
+	return _u
+}
+
+// Where appends a list predicates to the TurnReportUpdate builder.
+func (_u *TurnReportUpdateOne) Where(ps ...predicate.TurnReport) *TurnReportUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *TurnReportUpdateOne) Select(field string, fields ...string) *TurnReportUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated TurnReport entity.
+func (_u *TurnReportUpdateOne) Save(ctx context.Context) (*TurnReport, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TurnReportUpdateOne) SaveX(ctx context.Context) *TurnReport {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *TurnReportUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TurnReportUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TurnReportUpdateOne) check() error {
+	if v, ok := _u.mutation.TurnID(); ok {
+		if err := turnreport.TurnIDValidator(v); err != nil {
+			return &ValidationError{Name: "turn_id", err: fmt.Errorf(`ent: validator failed for field "TurnReport.turn_id": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.OriginalFilename(); ok {
+		if err := turnreport.OriginalFilenameValidator(v); err != nil {
+			return &ValidationError{Name: "original_filename", err: fmt.Errorf(`ent: validator failed for field "TurnReport.original_filename": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Sha256(); ok {
+		if err := turnreport.Sha256Validator(v); err != nil {
+			return &ValidationError{Name: "sha256", err: fmt.Errorf(`ent: validator failed for field "TurnReport.sha256": %w`, err)}
+		}
+	}
+	if _u.mutation.ClanCleared() && len(_u.mutation.ClanIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "TurnReport.clan"`)
+	}
+	return nil
+}
+
+func (_u *TurnReportUpdateOne) sqlSave(ctx context.Context) (_node *TurnReport, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(turnreport.Table, turnreport.Columns, sqlgraph.NewFieldSpec(turnreport.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "TurnReport.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, turnreport.FieldID)
+		for _, f := range fields {
+			if !turnreport.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != turnreport.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.TurnID(); ok {
+		_spec.SetField(turnreport.FieldTurnID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.OriginalFilename(); ok {
+		_spec.SetField(turnreport.FieldOriginalFilename, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Raw(); ok {
+		_spec.SetField(turnreport.FieldRaw, field.TypeBytes, value)
+	}
+	if value, ok := _u.mutation.Sha256(); ok {
+		_spec.SetField(turnreport.FieldSha256, field.TypeString, value)
+	}
+	if _u.mutation.ClanCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   turnreport.ClanTable,
+			Columns: []string{turnreport.ClanColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ClanIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   turnreport.ClanTable,
+			Columns: []string{turnreport.ClanColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(clan.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &TurnReport{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{turnreport.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -12,8 +12,30 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// APIToken is the client for interacting with the APIToken builders.
+	APIToken *APITokenClient
+	// AuditEvent is the client for interacting with the AuditEvent builders.
+	AuditEvent *AuditEventClient
+	// Clan is the client for interacting with the Clan builders.
+	Clan *ClanClient
+	// Game is the client for interacting with the Game builders.
+	Game *GameClient
+	// LoginChallenge is the client for interacting with the LoginChallenge builders.
+	LoginChallenge *LoginChallengeClient
+	// LoginThrottle is the client for interacting with the LoginThrottle builders.
+	LoginThrottle *LoginThrottleClient
+	// PasswordReset is the client for interacting with the PasswordReset builders.
+	PasswordReset *PasswordResetClient
+	// RecoveryCode is the client for interacting with the RecoveryCode builders.
+	RecoveryCode *RecoveryCodeClient
 	// Session is the client for interacting with the Session builders.
 	Session *SessionClient
+	// Setting is the client for interacting with the Setting builders.
+	Setting *SettingClient
+	// Turn is the client for interacting with the Turn builders.
+	Turn *TurnClient
+	// TurnReport is the client for interacting with the TurnReport builders.
+	TurnReport *TurnReportClient
 	// User is the client for interacting with the User builders.
 	User *UserClient
 
@@ -147,7 +169,18 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.APIToken = NewAPITokenClient(tx.config)
+	tx.AuditEvent = NewAuditEventClient(tx.config)
+	tx.Clan = NewClanClient(tx.config)
+	tx.Game = NewGameClient(tx.config)
+	tx.LoginChallenge = NewLoginChallengeClient(tx.config)
+	tx.LoginThrottle = NewLoginThrottleClient(tx.config)
+	tx.PasswordReset = NewPasswordResetClient(tx.config)
+	tx.RecoveryCode = NewRecoveryCodeClient(tx.config)
 	tx.Session = NewSessionClient(tx.config)
+	tx.Setting = NewSettingClient(tx.config)
+	tx.Turn = NewTurnClient(tx.config)
+	tx.TurnReport = NewTurnReportClient(tx.config)
 	tx.User = NewUserClient(tx.config)
 }
 
@@ -158,7 +191,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: Session.QueryXXX(), the query will be executed
+// applies a query, for example: APIToken.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
@@ -3,16 +3,17 @@ module github.com/mdhender/ottomat
 go 1.25.2
 
 require (
+	ariga.io/atlas v0.32.1-0.20250325101103-175b25e1c1b9
 	entgo.io/ent v0.14.5
 	github.com/maloquacious/semver v0.4.0
 	github.com/mdhender/phrases/v2 v2.0.0
 	github.com/spf13/cobra v1.10.1
 	golang.org/x/crypto v0.43.0
 	modernc.org/sqlite v1.39.1
+	rsc.io/qr v0.2.0
 )
 
 require (
-	ariga.io/atlas v0.32.1-0.20250325101103-175b25e1c1b9 // indirect
 	github.com/agext/levenshtein v1.2.3 // indirect
 	github.com/apparentlymart/go-textseg/v15 v15.0.0 // indirect
 	github.com/bmatcuk/doublestar v1.3.4 // indirect
@@ -0,0 +1,154 @@
+// Package apitokens manages the personal API tokens that scripts use to
+// call the JSON API.
+//
+// A token is shown to the user once, when it is created. The database
+// keeps its SHA-256 hash and a short prefix so that users can tell their
+// tokens apart. Each token carries a list of scopes naming the parts of
+// the API it may call; the user's role still applies on top of that.
+package apitokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/apitoken"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/auth"
+)
+
+// Prefix starts every token so that leaked tokens are easy to search for.
+const Prefix = "otm_"
+
+// prefixLength is the number of characters of the token kept for display.
+const prefixLength = len(Prefix) + 6
+
+// UsedInterval limits how often last_used_at is written.
+const UsedInterval = time.Minute
+
+// Scopes limit what a token can do.
+const (
+	ScopeMe      = "me"      // read the token owner's profile
+	ScopeClans   = "clans"   // read clans
+	ScopeReports = "reports" // read turn reports
+	ScopeUsers   = "users"   // read all users (admins only)
+)
+
+// Scopes lists every scope in display order.
+var Scopes = []string{ScopeMe, ScopeClans, ScopeReports, ScopeUsers}
+
+// ErrInvalid is returned for tokens that are unknown or expired.
+var ErrInvalid = errors.New("api token is invalid or has expired")
+
+// ValidScope reports whether s is a known scope.
+func ValidScope(s string) bool {
+	return slices.Contains(Scopes, s)
+}
+
+// HasScope reports whether the token grants the scope.
+func HasScope(t *ent.APIToken, scope string) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+// Create issues a new token for the user. A ttl of zero creates a token
+// that doesn't expire. The token is returned in the clear and can't be
+// recovered later.
+func Create(ctx context.Context, client *ent.Client, u *ent.User, name string, scopes []string, ttl time.Duration) (string, *ent.APIToken, error) {
+	if len(scopes) == 0 {
+		return "", nil, fmt.Errorf("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !ValidScope(scope) {
+			return "", nil, fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+	secret, err := auth.GenerateToken()
+	if err != nil {
+		return "", nil, err
+	}
+	token := Prefix + secret
+	create := client.APIToken.Create().
+		SetName(name).
+		SetTokenHash(auth.HashToken(token)).
+		SetPrefix(token[:prefixLength]).
+		SetScopes(scopes).
+		SetUserID(u.ID)
+	if ttl > 0 {
+		create.SetExpiresAt(time.Now().Add(ttl))
+	}
+	t, err := create.Save(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create api token: %w", err)
+	}
+	return token, t, nil
+}
+
+// Authenticate returns the live token with its user, clan, and game
+// loaded, and records that it was used. Tokens of disabled users are
+// invalid.
+func Authenticate(ctx context.Context, client *ent.Client, token string) (*ent.APIToken, error) {
+	now := time.Now()
+	t, err := client.APIToken.Query().
+		Where(
+			apitoken.TokenHash(auth.HashToken(token)),
+			apitoken.Or(apitoken.ExpiresAtIsNil(), apitoken.ExpiresAtGT(now)),
+			apitoken.HasUserWith(user.DisabledAtIsNil()),
+		).
+		WithUser(func(q *ent.UserQuery) {
+			q.WithClan(func(q *ent.ClanQuery) {
+				q.WithGame()
+			})
+		}).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, ErrInvalid
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find api token: %w", err)
+	}
+	if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) > UsedInterval {
+		if err := client.APIToken.UpdateOneID(t.ID).SetLastUsedAt(now).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("failed to update api token: %w", err)
+		}
+		t.LastUsedAt = &now
+	}
+	return t, nil
+}
+
+// List returns the user's tokens, newest first. Expired tokens are
+// included so the user can see why a script stopped working.
+func List(ctx context.Context, client *ent.Client, userID int) ([]*ent.APIToken, error) {
+	list, err := client.APIToken.Query().
+		Where(apitoken.HasUserWith(user.ID(userID))).
+		Order(ent.Desc(apitoken.FieldCreatedAt), ent.Desc(apitoken.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+	return list, nil
+}
+
+// Revoke deletes one of the user's tokens. It returns false if the user
+// has no such token.
+func Revoke(ctx context.Context, client *ent.Client, userID, id int) (bool, error) {
+	n, err := client.APIToken.Delete().
+		Where(apitoken.ID(id), apitoken.HasUserWith(user.ID(userID))).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete api token: %w", err)
+	}
+	return n != 0, nil
+}
+
+// Prune deletes expired tokens and returns the number deleted.
+func Prune(ctx context.Context, client *ent.Client) (int, error) {
+	n, err := client.APIToken.Delete().
+		Where(apitoken.ExpiresAtLTE(time.Now())).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune api tokens: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,120 @@
+// Package audit keeps a permanent record of security and admin actions:
+// logins and logouts, changes to users, and revoked sessions. Events name
+// users by username, so they survive the users being deleted.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/auditevent"
+	"github.com/mdhender/ottomat/ent/predicate"
+)
+
+// Actions recorded in the audit log.
+const (
+	Login          = "login"
+	LoginFailed    = "login.failed"
+	Logout         = "logout"
+	PasswordChange = "password.change"
+	PasswordReset  = "password.reset"
+	SessionRevoke  = "session.revoke"
+	UserCreate     = "user.create"
+	UserUpdate     = "user.update"
+	UserRole       = "user.role"
+	UserDisable    = "user.disable"
+	UserEnable     = "user.enable"
+	UserDelete     = "user.delete"
+)
+
+// Actions lists every action, for filters.
+var Actions = []string{
+	Login, LoginFailed, Logout, PasswordChange, PasswordReset, SessionRevoke,
+	UserCreate, UserUpdate, UserRole, UserDisable, UserEnable, UserDelete,
+}
+
+// An Event is an action to record. Actor is the user who acted, Target the
+// user acted on, and IP the client address for web requests.
+type Event struct {
+	Action string
+	Actor  string
+	Target string
+	Detail string
+	IP     string
+}
+
+// Record writes the event. Pass a transaction's client to record the event
+// only if the change it describes is committed.
+func Record(ctx context.Context, client *ent.Client, e Event) error {
+	err := client.AuditEvent.Create().
+		SetAction(e.Action).
+		SetActor(e.Actor).
+		SetTarget(e.Target).
+		SetDetail(e.Detail).
+		SetIP(e.IP).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record %s event: %w", e.Action, err)
+	}
+	return nil
+}
+
+// CLIActor is the actor for changes made with the command line: "cli" and
+// the name of the operating system user running it.
+func CLIActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return "cli:" + u.Username
+	} else if name := os.Getenv("USER"); name != "" {
+		return "cli:" + name
+	}
+	return "cli"
+}
+
+// A Filter selects events. Empty fields match everything.
+type Filter struct {
+	Actor  string
+	Target string
+	Action string
+	Since  time.Time // events at or after
+	Until  time.Time // events before
+	After  int       // events with a larger id, for following the log
+	Limit  int
+}
+
+// List returns the events matching f, newest first.
+func List(ctx context.Context, client *ent.Client, f Filter) ([]*ent.AuditEvent, error) {
+	var where []predicate.AuditEvent
+	if f.Actor != "" {
+		where = append(where, auditevent.Actor(f.Actor))
+	}
+	if f.Target != "" {
+		where = append(where, auditevent.Target(f.Target))
+	}
+	if f.Action != "" {
+		where = append(where, auditevent.Action(f.Action))
+	}
+	if !f.Since.IsZero() {
+		where = append(where, auditevent.CreatedAtGTE(f.Since))
+	}
+	if !f.Until.IsZero() {
+		where = append(where, auditevent.CreatedAtLT(f.Until))
+	}
+	if f.After != 0 {
+		where = append(where, auditevent.IDGT(f.After))
+	}
+	q := client.AuditEvent.Query().
+		Where(where...).
+		Order(ent.Desc(auditevent.FieldCreatedAt), ent.Desc(auditevent.FieldID))
+	if f.Limit > 0 {
+		q.Limit(f.Limit)
+	}
+	list, err := q.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	return list, nil
+}
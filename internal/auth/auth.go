@@ -2,14 +2,28 @@ package auth
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 )
 
 func GenerateSessionToken() (string, error) {
+	return GenerateToken()
+}
+
+// GenerateToken returns a random, URL-safe 32-byte token.
+func GenerateToken() (string, error) {
 	bytes := make([]byte, 32)
 	if _, err := rand.Read(bytes); err != nil {
 		return "", fmt.Errorf("failed to generate session token: %w", err)
 	}
 	return base64.URLEncoding.EncodeToString(bytes), nil
 }
+
+// HashToken returns the hex SHA-256 of a token, for tokens that must not be
+// stored in the clear.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MinPasswordLength is the shortest password we accept. Generated
+	// passphrases like "happy.cat.happy.nap" are well over this.
+	MinPasswordLength = 12
+	// MaxPasswordLength is bcrypt's limit; longer input is silently truncated.
+	MaxPasswordLength = 72
+)
+
+// CheckPasswordStrength returns an error describing why the password is
+// too weak for the user, or nil if it is acceptable.
+func CheckPasswordStrength(username, password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+	} else if len(password) > MaxPasswordLength {
+		return fmt.Errorf("password must be at most %d bytes", MaxPasswordLength)
+	}
+	if username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		return fmt.Errorf("password must not contain the username")
+	}
+	distinct := map[rune]bool{}
+	for _, r := range password {
+		distinct[r] = true
+	}
+	if len(distinct) < 5 {
+		return fmt.Errorf("password must use at least 5 different characters")
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshotLayout is the timestamp used in scheduled snapshot file names.
+const snapshotLayout = "20060102T150405Z"
+
+// Backup writes a consistent copy of the database to path using VACUUM INTO.
+// It is safe to run while the server is using the database. The target
+// must not already exist.
+func Backup(ctx context.Context, db *sql.DB, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("backup target %s already exists", path)
+	}
+	if _, err := db.ExecContext(ctx, `VACUUM INTO ?`, path); err != nil {
+		return fmt.Errorf("failed backing up database: %w", err)
+	}
+	return nil
+}
+
+// Restore replaces the database at dbPath with the backup at src. The backup
+// is checked for integrity before anything is touched. The server must not
+// be running against dbPath.
+func Restore(ctx context.Context, src, dbPath string) error {
+	if err := verify(ctx, src); err != nil {
+		return err
+	}
+
+	// copy next to the target so the final rename is atomic
+	tmp := dbPath + ".restore"
+	if err := copyFile(src, tmp); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed copying backup: %w", err)
+	}
+	// move the live database and its WAL files aside rather than deleting
+	// them: stale WAL files would be replayed on top of the restored
+	// database, but they must survive if the restore fails.
+	var moved []string
+	rollback := func() {
+		os.Remove(tmp)
+		for _, name := range moved {
+			if err := os.Rename(name+".old", name); err != nil {
+				log.Printf("restore: failed putting back %s: %v\n", name, err)
+			}
+		}
+	}
+	for _, name := range []string{dbPath, dbPath + "-wal", dbPath + "-shm"} {
+		if err := os.Rename(name, name+".old"); err == nil {
+			moved = append(moved, name)
+		} else if !os.IsNotExist(err) {
+			rollback()
+			return fmt.Errorf("failed moving %s aside: %w", name, err)
+		}
+	}
+	if err := os.Rename(tmp, dbPath); err != nil {
+		rollback()
+		return fmt.Errorf("failed replacing database: %w", err)
+	}
+	for _, name := range moved {
+		if err := os.Remove(name + ".old"); err != nil {
+			log.Printf("restore: failed removing %s: %v\n", name+".old", err)
+		}
+	}
+	return nil
+}
+
+// RunBackups writes a snapshot to dir every interval until ctx is cancelled,
+// keeping only the newest keep snapshots. Failures are logged and retried on
+// the next tick.
+func RunBackups(ctx context.Context, db *sql.DB, dir string, every time.Duration, keep int) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			path, err := Snapshot(ctx, db, dir, keep)
+			if err != nil {
+				log.Printf("backup: %v\n", err)
+				continue
+			}
+			log.Printf("backup: wrote %s\n", path)
+		}
+	}
+}
+
+// Snapshot writes a timestamped backup to dir and removes the oldest
+// snapshots so that at most keep remain. A keep of zero keeps everything.
+func Snapshot(ctx context.Context, db *sql.DB, dir string, keep int) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed creating backup directory: %w", err)
+	}
+	path := filepath.Join(dir, "ottomat-"+time.Now().UTC().Format(snapshotLayout)+".db")
+	if err := Backup(ctx, db, path); err != nil {
+		return "", err
+	}
+	if keep > 0 {
+		if err := rotate(dir, keep); err != nil {
+			return path, err
+		}
+	}
+	return path, nil
+}
+
+// rotate removes all but the newest keep snapshots in dir. Snapshot names
+// sort by time, so the oldest come first.
+func rotate(dir string, keep int) error {
+	matches, err := filepath.Glob(filepath.Join(dir, "ottomat-*.db"))
+	if err != nil {
+		return err
+	}
+	var snapshots []string
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(match), "ottomat-"), ".db")
+		if _, err := time.Parse(snapshotLayout, stamp); err == nil {
+			snapshots = append(snapshots, match)
+		}
+	}
+	sort.Strings(snapshots)
+	for len(snapshots) > keep {
+		if err := os.Remove(snapshots[0]); err != nil {
+			return fmt.Errorf("failed removing old snapshot: %w", err)
+		}
+		log.Printf("backup: removed %s\n", snapshots[0])
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+// verify opens src read-only and runs an integrity check on it.
+func verify(ctx context.Context, src string) error {
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("backup does not exist at %s", src)
+	}
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?mode=ro", src))
+	if err != nil {
+		return fmt.Errorf("failed opening backup: %w", err)
+	}
+	defer db.Close()
+	var result string
+	if err := db.QueryRowContext(ctx, `PRAGMA integrity_check`).Scan(&result); err != nil {
+		return fmt.Errorf("%s is not a valid database: %w", src, err)
+	} else if result != "ok" {
+		return fmt.Errorf("%s failed integrity check: %s", src, result)
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -1,10 +1,8 @@
 package database
 
 import (
-	"context"
 	"database/sql"
 	"fmt"
-	"log"
 	"os"
 
 	"entgo.io/ent/dialect"
@@ -14,6 +12,18 @@ import (
 )
 
 func Open(dbPath string) (*ent.Client, error) {
+	db, err := OpenDB(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	drv := entsql.OpenDB(dialect.SQLite, db)
+	client := ent.NewClient(ent.Driver(drv))
+	return client, nil
+}
+
+// OpenDB opens the raw database handle. It is used by commands that work
+// below the Ent client, like migrations.
+func OpenDB(dbPath string) (*sql.DB, error) {
 	// hack to prevent Sqlite from creating files when dbPath does not exist
 	if _, err := os.Stat(dbPath); err != nil {
 		return nil, fmt.Errorf("database does not exist at %s", dbPath)
@@ -27,15 +37,5 @@ func Open(dbPath string) (*ent.Client, error) {
 	// Configure connection pool for SQLite
 	db.SetMaxOpenConns(1)
 
-	drv := entsql.OpenDB(dialect.SQLite, db)
-	client := ent.NewClient(ent.Driver(drv))
-	return client, nil
-}
-
-func Migrate(ctx context.Context, client *ent.Client) error {
-	if err := client.Schema.Create(ctx); err != nil {
-		return fmt.Errorf("failed creating schema resources: %w", err)
-	}
-	log.Println("database schema created successfully")
-	return nil
+	return db, nil
 }
@@ -0,0 +1,518 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	atlas "ariga.io/atlas/sql/migrate"
+)
+
+// Migration files are generated from the Ent schema with
+//
+//	go run -mod=mod ent/migrate/main.go <name>
+//
+// and are named <version>_<name>.up.sql and <version>_<name>.down.sql.
+//
+// The files are checked against atlas.sum so that a migration edited by hand
+// is rejected; regenerate the checksums with
+//
+//	go run -mod=mod ent/migrate/main.go --hash
+//
+//go:embed migrations/*.sql migrations/atlas.sum
+var migrationsFS embed.FS
+
+var (
+	// ErrBehind is returned by Check when the database has pending migrations.
+	ErrBehind = errors.New("database schema is behind")
+	// ErrChecksum is returned when the migration files don't match atlas.sum.
+	ErrChecksum = errors.New("migration files do not match atlas.sum")
+)
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a migration has been applied.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrations returns the embedded migrations sorted by version.
+func Migrations() ([]Migration, error) {
+	fsys, err := fs.Sub(migrationsFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed reading migrations: %w", err)
+	}
+	return readMigrations(fsys)
+}
+
+func readMigrations(fsys fs.FS) ([]Migration, error) {
+	if err := verifySum(fsys); err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed reading migrations: %w", err)
+	}
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		filename := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(filename, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(filename, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+		base := strings.TrimSuffix(filename, "."+direction+".sql")
+		prefix, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s: missing name", filename)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version", filename)
+		}
+		data, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", filename, err)
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	var list []Migration
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d: missing up file", m.Version)
+		}
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+	return list, nil
+}
+
+// verifySum checks the migration files against atlas.sum, the same way the
+// Atlas CLI does. The hashes are cumulative, so the first entry that differs
+// names the file that was changed, added or removed.
+func verifySum(fsys fs.FS) error {
+	data, err := fs.ReadFile(fsys, atlas.HashFileName)
+	if err != nil {
+		return fmt.Errorf("failed reading migrations: %w", err)
+	}
+	var want atlas.HashFile
+	if err := want.UnmarshalText(data); err != nil {
+		return fmt.Errorf("%s: %w", atlas.HashFileName, err)
+	}
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return fmt.Errorf("failed reading migrations: %w", err)
+	}
+	sort.Strings(names)
+	var files []atlas.File
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", name, err)
+		}
+		files = append(files, atlas.NewLocalFile(name, data))
+	}
+	got, err := atlas.NewHashFile(files)
+	if err != nil {
+		return fmt.Errorf("failed hashing migrations: %w", err)
+	}
+	if got.Sum() == want.Sum() {
+		return nil
+	}
+	for i := 0; i < max(len(got), len(want)); i++ {
+		switch {
+		case i >= len(got):
+			return fmt.Errorf("%w: %s is missing", ErrChecksum, want[i].N)
+		case i >= len(want):
+			return fmt.Errorf("%w: %s is not listed", ErrChecksum, got[i].N)
+		case got[i] != want[i]:
+			return fmt.Errorf("%w: %s was changed", ErrChecksum, got[i].N)
+		}
+	}
+	return ErrChecksum
+}
+
+// Status returns every known migration along with the time it was applied.
+// Versions recorded in the database but not embedded in this binary are
+// returned with an empty body so callers can report them.
+func Status(ctx context.Context, db *sql.DB) ([]MigrationStatus, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var list []MigrationStatus
+	for _, m := range migrations {
+		ms := MigrationStatus{Migration: m}
+		if at, ok := applied[m.Version]; ok {
+			ms.AppliedAt = &at
+			delete(applied, m.Version)
+		}
+		list = append(list, ms)
+	}
+	for version, at := range applied {
+		list = append(list, MigrationStatus{Migration: Migration{Version: version, Name: "unknown"}, AppliedAt: &at})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+	return list, nil
+}
+
+// Check returns ErrBehind if the database has migrations that have not been
+// applied.
+func Check(ctx context.Context, db *sql.DB) error {
+	list, err := Status(ctx, db)
+	if err != nil {
+		return err
+	}
+	pending := 0
+	for _, ms := range list {
+		if ms.AppliedAt == nil {
+			pending++
+		}
+	}
+	if pending != 0 {
+		return fmt.Errorf("%w: %d pending migration(s)", ErrBehind, pending)
+	}
+	return nil
+}
+
+// Up applies pending migrations in order. If to is not zero, migrations with
+// a version greater than to are left pending. It returns the migrations that
+// were applied.
+func Up(ctx context.Context, db *sql.DB, to int64) ([]Migration, error) {
+	list, err := Status(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	if len(list) != 0 && !anyApplied(list) {
+		// databases created by the old auto-migration already have tables
+		// but no migration history, and the initial migration would fail.
+		tables, err := tableNames(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		if len(tables) != 0 {
+			if to != 0 && list[0].Version > to {
+				return nil, nil
+			}
+			if err := adopt(ctx, db, list[0].Migration, tables); err != nil {
+				return nil, err
+			}
+			now := time.Now()
+			list[0].AppliedAt = &now
+			done = append(done, list[0].Migration)
+		}
+	}
+
+	for _, ms := range list {
+		if ms.AppliedAt != nil {
+			continue
+		} else if to != 0 && ms.Version > to {
+			break
+		}
+		err := apply(ctx, db, ms.Version, ms.Up, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`, ms.Version, ms.Name, time.Now().UTC())
+			return err
+		})
+		if err != nil {
+			return done, err
+		}
+		done = append(done, ms.Migration)
+	}
+	return done, nil
+}
+
+// Down reverts applied migrations, newest first, until the latest applied
+// version is to. Passing zero reverts every migration. It returns the
+// migrations that were reverted.
+func Down(ctx context.Context, db *sql.DB, to int64) ([]Migration, error) {
+	list, err := Status(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if to != 0 {
+		found := false
+		for _, ms := range list {
+			if ms.Version == to {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown migration version %d", to)
+		}
+	}
+
+	var done []Migration
+	for i := len(list) - 1; i >= 0; i-- {
+		ms := list[i]
+		if ms.Version <= to {
+			break
+		} else if ms.AppliedAt == nil {
+			continue
+		} else if ms.Down == "" {
+			return done, fmt.Errorf("migration %d: no down file in this binary", ms.Version)
+		}
+		err := apply(ctx, db, ms.Version, ms.Down, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, ms.Version)
+			return err
+		})
+		if err != nil {
+			return done, err
+		}
+		done = append(done, ms.Migration)
+	}
+	return done, nil
+}
+
+var (
+	reCreateTable = regexp.MustCompile("^CREATE TABLE `(\\w+)`")
+	reCreateIndex = regexp.MustCompile("^CREATE (?:UNIQUE )?INDEX ")
+)
+
+// adopt brings a database created by the auto-migration that ran before
+// versioned migrations up to the initial migration and records it as
+// applied. Depending on the release it was created by, such a database has
+// some of the tables of the initial migration, with fewer columns and
+// constraints. Each table is rebuilt from the initial migration and its
+// rows are copied over; missing tables are created.
+//
+// Before clans were a table, users.clan_id held the clan number. Those
+// users get a clan with that number.
+func adopt(ctx context.Context, db *sql.DB, initial Migration, tables []string) error {
+	var creates []string
+	var indexes []string
+	known := map[string]string{}
+	for _, stmt := range strings.Split(initial.Up, "\n") {
+		stmt = strings.TrimSpace(stmt)
+		if m := reCreateTable.FindStringSubmatch(stmt); m != nil {
+			creates = append(creates, m[1])
+			known[m[1]] = stmt
+		} else if reCreateIndex.MatchString(stmt) {
+			indexes = append(indexes, stmt)
+		}
+	}
+	for _, name := range tables {
+		if _, ok := known[name]; !ok {
+			return fmt.Errorf("database has no migration history and an unknown table %q; it must be migrated by hand", name)
+		}
+	}
+
+	return inTx(ctx, db, initial.Version, func(tx *sql.Tx) error {
+		hasClans := slices.Contains(tables, "clans")
+		for _, name := range creates {
+			if !slices.Contains(tables, name) {
+				if _, err := tx.ExecContext(ctx, known[name]); err != nil {
+					return fmt.Errorf("create %s: %w", name, err)
+				}
+				continue
+			}
+			stmt := strings.Replace(known[name], "`"+name+"`", "`new_"+name+"`", 1)
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("rebuild %s: %w", name, err)
+			}
+			columns, err := commonColumns(ctx, tx, name, "new_"+name)
+			if err != nil {
+				return fmt.Errorf("rebuild %s: %w", name, err)
+			}
+			copyRows := fmt.Sprintf("INSERT INTO `new_%s` (%s) SELECT %s FROM `%s`", name, columns, columns, name)
+			if _, err := tx.ExecContext(ctx, copyRows); err != nil {
+				return fmt.Errorf("rebuild %s: %w", name, err)
+			}
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TABLE `%s`", name)); err != nil {
+				return fmt.Errorf("rebuild %s: %w", name, err)
+			}
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE `new_%s` RENAME TO `%s`", name, name)); err != nil {
+				return fmt.Errorf("rebuild %s: %w", name, err)
+			}
+		}
+		for _, stmt := range indexes {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+		if !hasClans && slices.Contains(tables, "users") {
+			now := time.Now().UTC()
+			_, err := tx.ExecContext(ctx, `INSERT INTO clans (number, created_at) SELECT DISTINCT clan_id, ? FROM users WHERE clan_id IS NOT NULL`, now)
+			if err != nil {
+				return fmt.Errorf("create clans: %w", err)
+			}
+			_, err = tx.ExecContext(ctx, `UPDATE users SET clan_id = (SELECT id FROM clans WHERE number = users.clan_id) WHERE clan_id IS NOT NULL`)
+			if err != nil {
+				return fmt.Errorf("link clans: %w", err)
+			}
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`, initial.Version, initial.Name, time.Now().UTC())
+		return err
+	})
+}
+
+// tableNames returns the application tables in the database.
+func tableNames(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'schema_migrations' ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed inspecting schema: %w", err)
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed inspecting schema: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// commonColumns returns the quoted names of the columns that both tables
+// have, for copying rows from one to the other.
+func commonColumns(ctx context.Context, tx *sql.Tx, from, to string) (string, error) {
+	columns := func(table string) ([]string, error) {
+		rows, err := tx.QueryContext(ctx, `SELECT name FROM pragma_table_info(?)`, table)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		var names []string
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return nil, err
+			}
+			names = append(names, name)
+		}
+		return names, rows.Err()
+	}
+	have, err := columns(from)
+	if err != nil {
+		return "", err
+	}
+	want, err := columns(to)
+	if err != nil {
+		return "", err
+	}
+	var list []string
+	for _, name := range want {
+		if slices.Contains(have, name) {
+			list = append(list, "`"+name+"`")
+		}
+	}
+	return strings.Join(list, ", "), nil
+}
+
+func anyApplied(list []MigrationStatus) bool {
+	for _, ms := range list {
+		if ms.AppliedAt != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func appliedVersions(ctx context.Context, db *sql.DB) (map[int64]time.Time, error) {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version integer NOT NULL PRIMARY KEY, name text NOT NULL, applied_at datetime NOT NULL)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating schema_migrations: %w", err)
+	}
+	rows, err := db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading schema_migrations: %w", err)
+	}
+	defer rows.Close()
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, fmt.Errorf("failed reading schema_migrations: %w", err)
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+// apply runs a migration script and its bookkeeping in one transaction.
+func apply(ctx context.Context, db *sql.DB, version int64, script string, record func(tx *sql.Tx) error) error {
+	return inTx(ctx, db, version, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, script); err != nil {
+			return err
+		}
+		return record(tx)
+	})
+}
+
+// inTx runs fn in a transaction for a migration. SQLite ignores the
+// foreign_keys pragma inside a transaction, and table rebuilds need it off,
+// so it is disabled around the transaction and the constraints are checked
+// before committing.
+func inTx(ctx context.Context, db *sql.DB, version int64, fn func(tx *sql.Tx) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = off`); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), `PRAGMA foreign_keys = on`)
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return fmt.Errorf("migration %d: %w", version, err)
+	}
+	rows, err := tx.QueryContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		return fmt.Errorf("migration %d: %w", version, err)
+	}
+	violations := rows.Next()
+	rows.Close()
+	if violations {
+		return fmt.Errorf("migration %d: foreign key violations", version)
+	}
+	return tx.Commit()
+}
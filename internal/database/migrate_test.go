@@ -0,0 +1,278 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// legacySchema is the schema the auto-migration created before versioned
+// migrations, when users.clan_id was a clan number.
+const legacySchema = "CREATE TABLE `sessions` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `token` text NOT NULL, `expires_at` datetime NOT NULL, `created_at` datetime NOT NULL, `user_sessions` integer NOT NULL, CONSTRAINT `sessions_users_sessions` FOREIGN KEY (`user_sessions`) REFERENCES `users` (`id`) ON DELETE NO ACTION);\n" +
+	"CREATE UNIQUE INDEX `sessions_token_key` ON `sessions` (`token`);\n" +
+	"CREATE INDEX `session_token` ON `sessions` (`token`);\n" +
+	"CREATE INDEX `session_expires_at` ON `sessions` (`expires_at`);\n" +
+	"CREATE TABLE `users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `username` text NOT NULL, `password_hash` text NOT NULL, `role` text NOT NULL DEFAULT ('guest'), `clan_id` integer NULL, `created_at` datetime NOT NULL, `updated_at` datetime NOT NULL);\n" +
+	"CREATE UNIQUE INDEX `users_username_key` ON `users` (`username`);\n"
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func appliedCount(t *testing.T, db *sql.DB) (applied, pending int) {
+	t.Helper()
+	list, err := Status(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ms := range list {
+		if ms.AppliedAt != nil {
+			applied++
+		} else {
+			pending++
+		}
+	}
+	return applied, pending
+}
+
+// schema returns the DDL of every table and index, for comparing databases.
+func schema(t *testing.T, db *sql.DB) string {
+	t.Helper()
+	rows, err := db.Query(`SELECT sql FROM sqlite_master WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%' AND name != 'schema_migrations' ORDER BY name`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var sb strings.Builder
+	for rows.Next() {
+		var stmt string
+		if err := rows.Scan(&stmt); err != nil {
+			t.Fatal(err)
+		}
+		sb.WriteString(stmt + "\n")
+	}
+	return sb.String()
+}
+
+func TestMigrations(t *testing.T) {
+	list, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) == 0 {
+		t.Fatal("no migrations")
+	}
+	for i, m := range list {
+		if m.Down == "" {
+			t.Errorf("migration %d: missing down file", m.Version)
+		}
+		if i != 0 && m.Version <= list[i-1].Version {
+			t.Errorf("migration %d: out of order", m.Version)
+		}
+	}
+}
+
+func TestMigrationsChecksum(t *testing.T) {
+	sub, err := fs.Sub(migrationsFS, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys := fstest.MapFS{}
+	err = fs.WalkDir(sub, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(sub, path)
+		fsys[path] = &fstest.MapFile{Data: data}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readMigrations(fsys); err != nil {
+		t.Fatalf("unchanged files: %v", err)
+	}
+
+	// the hashes are cumulative, so edit the first file
+	upName := ""
+	for path := range fsys {
+		if strings.HasSuffix(path, ".up.sql") && (upName == "" || path < upName) {
+			upName = path
+		}
+	}
+	edited := fstest.MapFS{}
+	for path, f := range fsys {
+		edited[path] = f
+	}
+	edited[upName] = &fstest.MapFile{Data: append([]byte("-- edited by hand\n"), fsys[upName].Data...)}
+	_, err = readMigrations(edited)
+	if !errors.Is(err, ErrChecksum) || !strings.Contains(err.Error(), upName) {
+		t.Errorf("edited file: got %v, want %v naming %s", err, ErrChecksum, upName)
+	}
+
+	added := fstest.MapFS{}
+	for path, f := range fsys {
+		added[path] = f
+	}
+	added["99999999999999_extra.up.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;\n")}
+	if _, err := readMigrations(added); !errors.Is(err, ErrChecksum) {
+		t.Errorf("added file: got %v, want %v", err, ErrChecksum)
+	}
+}
+
+func TestUpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	list, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if applied, pending := appliedCount(t, db); applied != 0 || pending != len(list) {
+		t.Fatalf("new database: got %d applied, %d pending", applied, pending)
+	}
+	if err := Check(ctx, db); !errors.Is(err, ErrBehind) {
+		t.Fatalf("check: got %v, want %v", err, ErrBehind)
+	}
+
+	// stop after the first migration
+	done, err := Up(ctx, db, list[0].Version)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(done) != 1 {
+		t.Fatalf("up to %d: applied %d, want 1", list[0].Version, len(done))
+	}
+	if applied, pending := appliedCount(t, db); applied != 1 || pending != len(list)-1 {
+		t.Fatalf("up to %d: got %d applied, %d pending", list[0].Version, applied, pending)
+	}
+
+	done, err = Up(ctx, db, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(done) != len(list)-1 {
+		t.Fatalf("up: applied %d, want %d", len(done), len(list)-1)
+	}
+	if err := Check(ctx, db); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	latest := schema(t, db)
+
+	// nothing left to do
+	if done, err := Up(ctx, db, 0); err != nil || len(done) != 0 {
+		t.Fatalf("up again: applied %d, err %v", len(done), err)
+	}
+
+	// every down file must undo its up file
+	for i := len(list) - 1; i > 0; i-- {
+		done, err := Down(ctx, db, list[i-1].Version)
+		if err != nil {
+			t.Fatalf("down to %d: %v", list[i-1].Version, err)
+		} else if len(done) != 1 || done[0].Version != list[i].Version {
+			t.Fatalf("down to %d: reverted %v", list[i-1].Version, done)
+		}
+	}
+	if applied, _ := appliedCount(t, db); applied != 1 {
+		t.Fatalf("down: got %d applied, want 1", applied)
+	}
+	if _, err := Down(ctx, db, 0); err != nil {
+		t.Fatalf("down to 0: %v", err)
+	}
+	if got := schema(t, db); got != "" {
+		t.Errorf("down to 0: schema left behind:\n%s", got)
+	}
+
+	if _, err := Up(ctx, db, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := schema(t, db); got != latest {
+		t.Errorf("up after down: schema differs:\n%s\nwant\n%s", got, latest)
+	}
+
+	if _, err := Down(ctx, db, 12345); err == nil {
+		t.Error("down to unknown version: got nil error")
+	}
+}
+
+func TestUpLegacy(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	if _, err := db.Exec(legacySchema); err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		`INSERT INTO users (username, password_hash, role, clan_id, created_at, updated_at) VALUES ('admin', 'x', 'admin', NULL, datetime(), datetime())`,
+		`INSERT INTO users (username, password_hash, role, clan_id, created_at, updated_at) VALUES ('charlie', 'x', 'chief', 138, datetime(), datetime())`,
+		`INSERT INTO users (username, password_hash, role, clan_id, created_at, updated_at) VALUES ('dana', 'x', 'chief', 138, datetime(), datetime())`,
+		`INSERT INTO sessions (token, expires_at, created_at, user_sessions) VALUES ('t', datetime('now', '+1 day'), datetime(), 2)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	done, err := Up(ctx, db, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(done) != len(list) {
+		t.Fatalf("up: applied %d, want %d", len(done), len(list))
+	}
+	if err := Check(ctx, db); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	// the result must match a database migrated from scratch
+	fresh := openTestDB(t)
+	if _, err := Up(ctx, fresh, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := schema(t, db), schema(t, fresh); got != want {
+		t.Errorf("schema differs from a new database:\n%s\nwant\n%s", got, want)
+	}
+
+	var clans, linked, sessions int
+	if err := db.QueryRow(`SELECT count(*) FROM clans WHERE number = 138`).Scan(&clans); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueryRow(`SELECT count(*) FROM users JOIN clans ON clans.id = users.clan_id WHERE clans.number = 138`).Scan(&linked); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueryRow(`SELECT count(*) FROM sessions WHERE user_sessions = 2`).Scan(&sessions); err != nil {
+		t.Fatal(err)
+	}
+	if clans != 1 || linked != 2 || sessions != 1 {
+		t.Errorf("got %d clans, %d users in clan 0138, %d sessions; want 1, 2, 1", clans, linked, sessions)
+	}
+}
+
+func TestUpUnknownTable(t *testing.T) {
+	db := openTestDB(t)
+	if _, err := db.Exec("CREATE TABLE `widgets` (`id` integer NOT NULL PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Up(context.Background(), db, 0); err == nil || !strings.Contains(err.Error(), "widgets") {
+		t.Errorf("got %v, want an error naming widgets", err)
+	}
+	if applied, _ := appliedCount(t, db); applied != 0 {
+		t.Errorf("got %d applied, want 0", applied)
+	}
+}
@@ -0,0 +1,30 @@
+-- reverse: create index "users_username_key" to table: "users"
+DROP INDEX `users_username_key`;
+-- reverse: create "users" table
+DROP TABLE `users`;
+-- reverse: create index "turnreport_turn_id" to table: "turn_reports"
+DROP INDEX `turnreport_turn_id`;
+-- reverse: create index "turnreport_sha256_clan_reports" to table: "turn_reports"
+DROP INDEX `turnreport_sha256_clan_reports`;
+-- reverse: create "turn_reports" table
+DROP TABLE `turn_reports`;
+-- reverse: create index "turn_turn_id_game_turns" to table: "turns"
+DROP INDEX `turn_turn_id_game_turns`;
+-- reverse: create "turns" table
+DROP TABLE `turns`;
+-- reverse: create index "session_expires_at" to table: "sessions"
+DROP INDEX `session_expires_at`;
+-- reverse: create index "session_token" to table: "sessions"
+DROP INDEX `session_token`;
+-- reverse: create index "sessions_token_key" to table: "sessions"
+DROP INDEX `sessions_token_key`;
+-- reverse: create "sessions" table
+DROP TABLE `sessions`;
+-- reverse: create index "games_code_key" to table: "games"
+DROP INDEX `games_code_key`;
+-- reverse: create "games" table
+DROP TABLE `games`;
+-- reverse: create index "clans_number_key" to table: "clans"
+DROP INDEX `clans_number_key`;
+-- reverse: create "clans" table
+DROP TABLE `clans`;
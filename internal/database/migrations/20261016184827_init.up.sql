@@ -0,0 +1,30 @@
+-- create "clans" table
+CREATE TABLE `clans` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `number` integer NOT NULL, `name` text NOT NULL DEFAULT (''), `created_at` datetime NOT NULL, `active` bool NOT NULL DEFAULT (true), `game_clans` integer NULL, CONSTRAINT `clans_games_clans` FOREIGN KEY (`game_clans`) REFERENCES `games` (`id`) ON DELETE SET NULL);
+-- create index "clans_number_key" to table: "clans"
+CREATE UNIQUE INDEX `clans_number_key` ON `clans` (`number`);
+-- create "games" table
+CREATE TABLE `games` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `code` text NOT NULL, `name` text NOT NULL DEFAULT (''), `created_at` datetime NOT NULL);
+-- create index "games_code_key" to table: "games"
+CREATE UNIQUE INDEX `games_code_key` ON `games` (`code`);
+-- create "sessions" table
+CREATE TABLE `sessions` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `token` text NOT NULL, `expires_at` datetime NOT NULL, `created_at` datetime NOT NULL, `user_sessions` integer NOT NULL, CONSTRAINT `sessions_users_sessions` FOREIGN KEY (`user_sessions`) REFERENCES `users` (`id`) ON DELETE NO ACTION);
+-- create index "sessions_token_key" to table: "sessions"
+CREATE UNIQUE INDEX `sessions_token_key` ON `sessions` (`token`);
+-- create index "session_token" to table: "sessions"
+CREATE INDEX `session_token` ON `sessions` (`token`);
+-- create index "session_expires_at" to table: "sessions"
+CREATE INDEX `session_expires_at` ON `sessions` (`expires_at`);
+-- create "turns" table
+CREATE TABLE `turns` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `turn_id` text NOT NULL, `status` text NOT NULL DEFAULT ('open'), `due_at` datetime NULL, `created_at` datetime NOT NULL, `game_turns` integer NOT NULL, CONSTRAINT `turns_games_turns` FOREIGN KEY (`game_turns`) REFERENCES `games` (`id`) ON DELETE NO ACTION);
+-- create index "turn_turn_id_game_turns" to table: "turns"
+CREATE UNIQUE INDEX `turn_turn_id_game_turns` ON `turns` (`turn_id`, `game_turns`);
+-- create "turn_reports" table
+CREATE TABLE `turn_reports` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `turn_id` text NOT NULL, `original_filename` text NOT NULL, `raw` blob NOT NULL, `sha256` text NOT NULL, `uploaded_at` datetime NOT NULL, `clan_reports` integer NOT NULL, CONSTRAINT `turn_reports_clans_reports` FOREIGN KEY (`clan_reports`) REFERENCES `clans` (`id`) ON DELETE NO ACTION);
+-- create index "turnreport_sha256_clan_reports" to table: "turn_reports"
+CREATE UNIQUE INDEX `turnreport_sha256_clan_reports` ON `turn_reports` (`sha256`, `clan_reports`);
+-- create index "turnreport_turn_id" to table: "turn_reports"
+CREATE INDEX `turnreport_turn_id` ON `turn_reports` (`turn_id`);
+-- create "users" table
+CREATE TABLE `users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `username` text NOT NULL, `password_hash` text NOT NULL, `role` text NOT NULL DEFAULT ('guest'), `created_at` datetime NOT NULL, `updated_at` datetime NOT NULL, `clan_id` integer NULL, CONSTRAINT `users_clans_users` FOREIGN KEY (`clan_id`) REFERENCES `clans` (`id`) ON DELETE SET NULL);
+-- create index "users_username_key" to table: "users"
+CREATE UNIQUE INDEX `users_username_key` ON `users` (`username`);
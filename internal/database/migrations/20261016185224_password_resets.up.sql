@@ -0,0 +1,6 @@
+-- create "password_resets" table
+CREATE TABLE `password_resets` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `token_hash` text NOT NULL, `expires_at` datetime NOT NULL, `used_at` datetime NULL, `created_at` datetime NOT NULL, `user_password_resets` integer NOT NULL, CONSTRAINT `password_resets_users_password_resets` FOREIGN KEY (`user_password_resets`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- create index "password_resets_token_hash_key" to table: "password_resets"
+CREATE UNIQUE INDEX `password_resets_token_hash_key` ON `password_resets` (`token_hash`);
+-- create index "passwordreset_expires_at" to table: "password_resets"
+CREATE INDEX `passwordreset_expires_at` ON `password_resets` (`expires_at`);
@@ -0,0 +1,4 @@
+-- reverse: create index "loginthrottle_kind_key" to table: "login_throttles"
+DROP INDEX `loginthrottle_kind_key`;
+-- reverse: create "login_throttles" table
+DROP TABLE `login_throttles`;
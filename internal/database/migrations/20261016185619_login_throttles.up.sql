@@ -0,0 +1,4 @@
+-- create "login_throttles" table
+CREATE TABLE `login_throttles` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `kind` text NOT NULL, `key` text NOT NULL, `failures` integer NOT NULL DEFAULT (0), `last_failure_at` datetime NOT NULL, `locked_until` datetime NULL);
+-- create index "loginthrottle_kind_key" to table: "login_throttles"
+CREATE UNIQUE INDEX `loginthrottle_kind_key` ON `login_throttles` (`kind`, `key`);
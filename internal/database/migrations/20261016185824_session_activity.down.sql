@@ -0,0 +1,18 @@
+-- disable the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = off;
+-- create "old_sessions" table without the activity columns
+CREATE TABLE `old_sessions` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `token` text NOT NULL, `csrf_token` text NOT NULL DEFAULT (''), `expires_at` datetime NOT NULL, `created_at` datetime NOT NULL, `user_sessions` integer NOT NULL, CONSTRAINT `sessions_users_sessions` FOREIGN KEY (`user_sessions`) REFERENCES `users` (`id`) ON DELETE NO ACTION);
+-- copy rows from "sessions" to "old_sessions"
+INSERT INTO `old_sessions` (`id`, `token`, `csrf_token`, `expires_at`, `created_at`, `user_sessions`) SELECT `id`, `token`, `csrf_token`, `expires_at`, `created_at`, `user_sessions` FROM `sessions`;
+-- drop "sessions" table after copying rows
+DROP TABLE `sessions`;
+-- rename "old_sessions" to "sessions"
+ALTER TABLE `old_sessions` RENAME TO `sessions`;
+-- create index "sessions_token_key" to table: "sessions"
+CREATE UNIQUE INDEX `sessions_token_key` ON `sessions` (`token`);
+-- create index "session_token" to table: "sessions"
+CREATE INDEX `session_token` ON `sessions` (`token`);
+-- create index "session_expires_at" to table: "sessions"
+CREATE INDEX `session_expires_at` ON `sessions` (`expires_at`);
+-- enable back the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = on;
@@ -0,0 +1,24 @@
+-- reverse: create index "settings_key_key" to table: "settings"
+DROP INDEX `settings_key_key`;
+-- reverse: create "settings" table
+DROP TABLE `settings`;
+-- reverse: create "recovery_codes" table
+DROP TABLE `recovery_codes`;
+-- reverse: create index "login_challenges_token_hash_key" to table: "login_challenges"
+DROP INDEX `login_challenges_token_hash_key`;
+-- reverse: create "login_challenges" table
+DROP TABLE `login_challenges`;
+-- disable the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = off;
+-- create "old_users" table without the totp columns
+CREATE TABLE `old_users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `username` text NOT NULL, `password_hash` text NOT NULL, `role` text NOT NULL DEFAULT ('guest'), `created_at` datetime NOT NULL, `updated_at` datetime NOT NULL, `clan_id` integer NULL, CONSTRAINT `users_clans_users` FOREIGN KEY (`clan_id`) REFERENCES `clans` (`id`) ON DELETE SET NULL);
+-- copy rows from "users" to "old_users"
+INSERT INTO `old_users` (`id`, `username`, `password_hash`, `role`, `created_at`, `updated_at`, `clan_id`) SELECT `id`, `username`, `password_hash`, `role`, `created_at`, `updated_at`, `clan_id` FROM `users`;
+-- drop "users" table after copying rows
+DROP TABLE `users`;
+-- rename "old_users" to "users"
+ALTER TABLE `old_users` RENAME TO `users`;
+-- create index "users_username_key" to table: "users"
+CREATE UNIQUE INDEX `users_username_key` ON `users` (`username`);
+-- enable back the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = on;
@@ -0,0 +1,24 @@
+-- disable the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = off;
+-- create "new_users" table
+CREATE TABLE `new_users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `username` text NOT NULL, `password_hash` text NOT NULL, `role` text NOT NULL DEFAULT ('guest'), `created_at` datetime NOT NULL, `updated_at` datetime NOT NULL, `totp_secret` text NULL, `totp_enabled_at` datetime NULL, `totp_last_step` integer NOT NULL DEFAULT (0), `clan_id` integer NULL, CONSTRAINT `users_clans_users` FOREIGN KEY (`clan_id`) REFERENCES `clans` (`id`) ON DELETE SET NULL);
+-- copy rows from old table "users" to new temporary table "new_users"
+INSERT INTO `new_users` (`id`, `username`, `password_hash`, `role`, `created_at`, `updated_at`, `clan_id`) SELECT `id`, `username`, `password_hash`, `role`, `created_at`, `updated_at`, `clan_id` FROM `users`;
+-- drop "users" table after copying rows
+DROP TABLE `users`;
+-- rename temporary table "new_users" to "users"
+ALTER TABLE `new_users` RENAME TO `users`;
+-- create index "users_username_key" to table: "users"
+CREATE UNIQUE INDEX `users_username_key` ON `users` (`username`);
+-- create "login_challenges" table
+CREATE TABLE `login_challenges` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `token_hash` text NOT NULL, `remember` bool NOT NULL DEFAULT (false), `expires_at` datetime NOT NULL, `created_at` datetime NOT NULL, `user_login_challenges` integer NOT NULL, CONSTRAINT `login_challenges_users_login_challenges` FOREIGN KEY (`user_login_challenges`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- create index "login_challenges_token_hash_key" to table: "login_challenges"
+CREATE UNIQUE INDEX `login_challenges_token_hash_key` ON `login_challenges` (`token_hash`);
+-- create "recovery_codes" table
+CREATE TABLE `recovery_codes` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `code_hash` text NOT NULL, `used_at` datetime NULL, `created_at` datetime NOT NULL, `user_recovery_codes` integer NOT NULL, CONSTRAINT `recovery_codes_users_recovery_codes` FOREIGN KEY (`user_recovery_codes`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- create "settings" table
+CREATE TABLE `settings` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `key` text NOT NULL, `value` text NOT NULL, `updated_at` datetime NOT NULL);
+-- create index "settings_key_key" to table: "settings"
+CREATE UNIQUE INDEX `settings_key_key` ON `settings` (`key`);
+-- enable back the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = on;
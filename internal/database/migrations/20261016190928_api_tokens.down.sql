@@ -0,0 +1,4 @@
+-- reverse: create index "api_tokens_token_hash_key" to table: "api_tokens"
+DROP INDEX `api_tokens_token_hash_key`;
+-- reverse: create "api_tokens" table
+DROP TABLE `api_tokens`;
@@ -0,0 +1,8 @@
+-- reverse: create index "auditevent_target_created_at" to table: "audit_events"
+DROP INDEX `auditevent_target_created_at`;
+-- reverse: create index "auditevent_actor_created_at" to table: "audit_events"
+DROP INDEX `auditevent_actor_created_at`;
+-- reverse: create index "auditevent_created_at" to table: "audit_events"
+DROP INDEX `auditevent_created_at`;
+-- reverse: create "audit_events" table
+DROP TABLE `audit_events`;
@@ -0,0 +1,8 @@
+-- create "audit_events" table
+CREATE TABLE `audit_events` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `created_at` datetime NOT NULL, `action` text NOT NULL, `actor` text NOT NULL DEFAULT (''), `target` text NOT NULL DEFAULT (''), `detail` text NOT NULL DEFAULT (''), `ip` text NOT NULL DEFAULT (''));
+-- create index "auditevent_created_at" to table: "audit_events"
+CREATE INDEX `auditevent_created_at` ON `audit_events` (`created_at`);
+-- create index "auditevent_actor_created_at" to table: "audit_events"
+CREATE INDEX `auditevent_actor_created_at` ON `audit_events` (`actor`, `created_at`);
+-- create index "auditevent_target_created_at" to table: "audit_events"
+CREATE INDEX `auditevent_target_created_at` ON `audit_events` (`target`, `created_at`);
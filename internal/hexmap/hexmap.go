@@ -0,0 +1,214 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package hexmap builds a map of the hexes a clan knows about from its
+// parsed turn reports.
+//
+// TribeNet hexes are flat-topped and laid out in columns. Odd columns
+// (counting from 1) sit higher than even columns, so the neighbors of a hex
+// depend on which kind of column it is in.
+package hexmap
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/mdhender/ottomat/internal/report"
+)
+
+// Map is the set of known hexes.
+type Map struct {
+	Hexes map[report.Hex]*Hex
+	// Warnings are problems found while applying reports to the map.
+	// They don't stop the map from being built.
+	Warnings []string
+
+	turn  string                // turn of the last report applied
+	units map[string]report.Hex // where each unit is placed in that turn
+}
+
+// Hex is everything known about a single hex.
+type Hex struct {
+	Location   report.Hex
+	Terrain    report.Terrain // empty if never seen
+	Settlement string
+	Units      []string // units in the hex at the end of the latest turn
+	Edges      map[report.Direction][]string
+	Turn       string // turn the hex was last updated
+}
+
+// New returns an empty map.
+func New() *Map {
+	return &Map{Hexes: map[report.Hex]*Hex{}, units: map[string]report.Hex{}}
+}
+
+// Neighbor returns the hex adjacent to h in direction d.
+func Neighbor(h report.Hex, d report.Direction) report.Hex {
+	col, row := h.Absolute()
+	odd := col%2 == 1 // zero-based, so these are the even columns in the report
+	switch d {
+	case report.North:
+		row--
+	case report.South:
+		row++
+	case report.NorthEast:
+		col++
+		if !odd {
+			row--
+		}
+	case report.SouthEast:
+		col++
+		if odd {
+			row++
+		}
+	case report.SouthWest:
+		col--
+		if odd {
+			row++
+		}
+	case report.NorthWest:
+		col--
+		if !odd {
+			row--
+		}
+	}
+	if col < 0 || row < 0 || col >= 26*report.GridColumns || row >= 26*report.GridRows {
+		return report.Hex{} // off the edge of the world
+	}
+	return report.HexFromAbsolute(col, row)
+}
+
+// hex returns the hex at location, creating it if needed.
+func (m *Map) hex(location report.Hex) *Hex {
+	h, ok := m.Hexes[location]
+	if !ok {
+		h = &Hex{Location: location, Edges: map[report.Direction][]string{}}
+		m.Hexes[location] = h
+	}
+	return h
+}
+
+func (m *Map) warnf(format string, args ...any) {
+	m.Warnings = append(m.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Apply adds the observations in a report to the map. Reports should be
+// applied in turn order so that later observations replace earlier ones.
+func (m *Map) Apply(rpt *report.Report) {
+	turn := ""
+	if rpt.Turn != nil {
+		turn = rpt.Turn.ID
+	}
+
+	// units only show where they were at the end of the latest turn. a clan
+	// can upload several reports for a turn, so only clear them when the
+	// turn changes.
+	if turn != m.turn {
+		for _, h := range m.Hexes {
+			h.Units = nil
+		}
+		m.turn, m.units = turn, map[string]report.Hex{}
+	}
+
+	for _, u := range rpt.Units {
+		if u.CurrentHex.IsZero() || u.CurrentHex.Obscured {
+			m.warnf("%s: unit %s: current hex %s can't be placed on the map", turn, u.ID, u.CurrentHex)
+			continue
+		}
+
+		// movement starts in the previous hex and should end in the current hex
+		if u.Moves != nil && !u.PreviousHex.IsZero() && !u.PreviousHex.Obscured {
+			at := m.walk(turn, u.PreviousHex, u.Moves)
+			if at != u.CurrentHex {
+				m.warnf("%s: unit %s: movement ends in %s, not %s", turn, u.ID, at, u.CurrentHex)
+			}
+		}
+
+		// scouts start from where the unit ended its movement
+		for _, mv := range u.Scouts {
+			m.walk(turn, u.CurrentHex, mv)
+		}
+
+		// a later report for the same turn replaces where the unit is
+		if at, ok := m.units[u.ID]; ok {
+			h := m.hex(at)
+			h.Units = slices.DeleteFunc(h.Units, func(id string) bool { return id == u.ID })
+		}
+		m.units[u.ID] = u.CurrentHex
+		here := m.hex(u.CurrentHex)
+		here.Turn = turn
+		here.Units = append(here.Units, u.ID)
+		if u.Status != nil {
+			m.observe(turn, u.CurrentHex, u.Status)
+		}
+	}
+
+	for _, h := range m.Hexes {
+		sort.Strings(h.Units)
+	}
+}
+
+// walk follows the steps of a move and returns the hex the mover ended in.
+func (m *Map) walk(turn string, from report.Hex, mv *report.Move) report.Hex {
+	at := from
+	if mv.Start != nil {
+		m.observe(turn, at, mv.Start)
+	}
+	for _, step := range mv.Steps {
+		if step.Result == report.Succeeded {
+			next := Neighbor(at, step.Direction)
+			if next.IsZero() {
+				m.warnf("%s: line %d: step %s from %s leaves the map", turn, mv.Line, step.Direction, at)
+				return at
+			}
+			at = next
+		}
+		m.observe(turn, at, &step.Observation)
+	}
+	return at
+}
+
+// observe records what was seen from a hex.
+func (m *Map) observe(turn string, location report.Hex, obs *report.Observation) {
+	h := m.hex(location)
+	h.Turn = turn
+	if obs.Terrain != "" {
+		h.Terrain = obs.Terrain
+	}
+	if obs.Settlement != "" {
+		h.Settlement = obs.Settlement
+	}
+	for _, n := range obs.Neighbors {
+		location := Neighbor(location, n.Direction)
+		if location.IsZero() {
+			continue
+		}
+		nh := m.hex(location)
+		nh.Terrain = n.Terrain
+		if nh.Turn == "" {
+			nh.Turn = turn
+		}
+	}
+	for _, e := range obs.Edges {
+		if !slices.Contains(h.Edges[e.Direction], e.Feature) {
+			h.Edges[e.Direction] = append(h.Edges[e.Direction], e.Feature)
+		}
+	}
+}
+
+// Sorted returns the hexes ordered by column and then by row.
+func (m *Map) Sorted() []*Hex {
+	hexes := make([]*Hex, 0, len(m.Hexes))
+	for _, h := range m.Hexes {
+		hexes = append(hexes, h)
+	}
+	sort.Slice(hexes, func(i, j int) bool {
+		ci, ri := hexes[i].Location.Absolute()
+		cj, rj := hexes[j].Location.Absolute()
+		if ci != cj {
+			return ci < cj
+		}
+		return ri < rj
+	})
+	return hexes
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package hexmap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/turnreport"
+	"github.com/mdhender/ottomat/internal/report"
+)
+
+// Load builds the map for a clan from every report the clan uploaded for
+// turns up to and including turn. Reports that can't be read are skipped
+// and noted in the map warnings.
+func Load(ctx context.Context, c *ent.Clan, turn string) (*Map, error) {
+	reports, err := c.QueryReports().
+		Where(turnreport.TurnIDLTE(turn)).
+		Order(ent.Asc(turnreport.FieldTurnID), ent.Asc(turnreport.FieldUploadedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("clan %04d: turn %s: %w", c.Number, turn, err)
+	}
+
+	m := New()
+	for _, tr := range reports {
+		text, err := report.Text(tr.OriginalFilename, tr.Raw)
+		if err != nil {
+			m.warnf("%s: %s: %v", tr.TurnID, tr.OriginalFilename, err)
+			continue
+		}
+		rpt := report.Parse(text)
+		for _, e := range rpt.Errors {
+			m.warnf("%s: %s: %v", tr.TurnID, tr.OriginalFilename, e)
+		}
+		m.Apply(rpt)
+	}
+	return m, nil
+}
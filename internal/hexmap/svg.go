@@ -0,0 +1,197 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package hexmap
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/mdhender/ottomat/internal/report"
+)
+
+// terrainColors are the fill colors for each terrain. Unknown terrain
+// is drawn in grey.
+var terrainColors = map[report.Terrain]string{
+	report.Alps:                 "#c8c8d0",
+	report.AridHills:            "#c9a66b",
+	report.AridTundra:           "#d8c89a",
+	report.BrushFlat:            "#a3b86c",
+	report.BrushHills:           "#8fa35a",
+	report.ConiferHills:         "#3f6e47",
+	report.Deciduous:            "#4f9a4a",
+	report.Desert:               "#eed9a0",
+	report.DeciduousHills:       "#3f8040",
+	report.GrassyHills:          "#86b85c",
+	report.GrassyHillsPlateau:   "#9cc46e",
+	report.HighSnowyMountains:   "#f4f6f8",
+	report.Jungle:               "#1f6b3a",
+	report.JungleHills:          "#1a5a31",
+	report.Lake:                 "#5b9bd5",
+	report.LowAridMountains:     "#a8865a",
+	report.LowConiferMountains:  "#56705a",
+	report.LowJungleMountains:   "#2f5a3d",
+	report.LowSnowyMountains:    "#dfe4ea",
+	report.LowVolcanicMountains: "#6b4b4b",
+	report.Ocean:                "#2e5f9e",
+	report.PolarIce:             "#e8f4fa",
+	report.Prairie:              "#c2d982",
+	report.RockyHills:           "#9a8f80",
+	report.SnowyHills:           "#e6ecf0",
+	report.Swamp:                "#5e7a5a",
+	report.Tundra:               "#b8c4b0",
+}
+
+// edgeColors are the stroke colors for features on the sides of hexes.
+var edgeColors = map[string]string{
+	"River":      "#1e64c8",
+	"Ford":       "#7fb2f0",
+	"Pass":       "#8b5a2b",
+	"Canal":      "#00a0a0",
+	"Stone Road": "#606060",
+}
+
+// edgeSides maps a direction to the corners (see corner) bounding that side
+// of a flat-topped hex.
+var edgeSides = map[report.Direction][2]int{
+	report.NorthEast: {5, 0},
+	report.SouthEast: {0, 1},
+	report.South:     {1, 2},
+	report.SouthWest: {2, 3},
+	report.NorthWest: {3, 4},
+	report.North:     {4, 5},
+}
+
+// RenderOptions control how the map is drawn.
+type RenderOptions struct {
+	Title string  // optional title drawn at the top left
+	Size  float64 // distance from the center of a hex to a corner; default 40
+}
+
+// RenderSVG writes the map as an SVG document. Only the area around the
+// known hexes is drawn.
+func RenderSVG(w io.Writer, m *Map, opts RenderOptions) error {
+	size := opts.Size
+	if size <= 0 {
+		size = 40
+	}
+	height := math.Sqrt(3) * size // flat-top hex, flat side to flat side
+	margin, top := size/2, size/2+24
+
+	hexes := m.Sorted()
+
+	// find the bounds of the known hexes
+	minCol, minRow, maxCol, maxRow := 0, 0, 0, 0
+	for i, h := range hexes {
+		col, row := h.Location.Absolute()
+		if i == 0 {
+			minCol, minRow, maxCol, maxRow = col, row, col, row
+			continue
+		}
+		minCol, maxCol = min(minCol, col), max(maxCol, col)
+		minRow, maxRow = min(minRow, row), max(maxRow, row)
+	}
+
+	// center returns the pixel center of a hex
+	center := func(location report.Hex) (x, y float64) {
+		col, row := location.Absolute()
+		x = margin + size + float64(col-minCol)*1.5*size
+		y = top + height/2 + float64(row-minRow)*height
+		if col%2 == 1 {
+			y += height / 2
+		}
+		return x, y
+	}
+	// corner returns corner i of the hex centered at x, y; corner 0 is
+	// the right-most corner and the rest follow clockwise.
+	corner := func(x, y float64, i int) (float64, float64) {
+		angle := math.Pi / 3 * float64(i)
+		return x + size*math.Cos(angle), y + size*math.Sin(angle)
+	}
+
+	width := 2*margin + (float64(maxCol-minCol)*1.5+2)*size
+	depth := top + margin + (float64(maxRow-minRow)+1.5)*height
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="sans-serif">`+"\n", width, depth, width, depth)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="#1f2937"/>`+"\n")
+	if opts.Title != "" {
+		fmt.Fprintf(bw, `<text x="%.0f" y="20" font-size="16" fill="#ffffff">%s</text>`+"\n", margin, html.EscapeString(opts.Title))
+	}
+	if len(hexes) == 0 {
+		fmt.Fprintf(bw, `<text x="%.0f" y="%.0f" font-size="14" fill="#9ca3af">No hexes</text>`+"\n", margin, top+16)
+	}
+
+	// terrain first so that edges and labels are drawn on top of every hex
+	for _, h := range hexes {
+		x, y := center(h.Location)
+		var points []string
+		for i := 0; i < 6; i++ {
+			cx, cy := corner(x, y, i)
+			points = append(points, fmt.Sprintf("%.1f,%.1f", cx, cy))
+		}
+		fill, ok := terrainColors[h.Terrain]
+		if !ok {
+			fill = "#4b5563"
+		}
+		fmt.Fprintf(bw, `<polygon points="%s" fill="%s" stroke="#111827" stroke-width="1"><title>%s</title></polygon>`+"\n",
+			strings.Join(points, " "), fill, html.EscapeString(hexTitle(h)))
+	}
+
+	for _, h := range hexes {
+		x, y := center(h.Location)
+		for _, d := range report.Directions {
+			for _, feature := range h.Edges[d] {
+				color, ok := edgeColors[feature]
+				if !ok {
+					continue
+				}
+				x1, y1 := corner(x, y, edgeSides[d][0])
+				x2, y2 := corner(x, y, edgeSides[d][1])
+				fmt.Fprintf(bw, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="4" stroke-linecap="round"/>`+"\n", x1, y1, x2, y2, color)
+			}
+		}
+	}
+
+	fontSize := size * 0.24
+	for _, h := range hexes {
+		x, y := center(h.Location)
+		fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" font-size="%.1f" text-anchor="middle" fill="#111827">%s</text>`+"\n",
+			x, y-height/2+fontSize*1.4, fontSize, h.Location)
+		if h.Settlement != "" {
+			fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" font-size="%.1f" text-anchor="middle" font-weight="bold" fill="#111827">%s</text>`+"\n",
+				x, y+height/2-fontSize*0.6, fontSize, html.EscapeString(h.Settlement))
+		}
+		if len(h.Units) != 0 {
+			fmt.Fprintf(bw, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="#dc2626" stroke="#ffffff" stroke-width="1"><title>%s</title></circle>`+"\n",
+				x, y, size*0.18, strings.Join(h.Units, ", "))
+			fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" font-size="%.1f" text-anchor="middle" fill="#111827">%s</text>`+"\n",
+				x, y+size*0.18+fontSize, fontSize, h.Units[0])
+		}
+	}
+
+	fmt.Fprintln(bw, `</svg>`)
+	return bw.Flush()
+}
+
+// hexTitle is the tooltip for a hex.
+func hexTitle(h *Hex) string {
+	var sb strings.Builder
+	sb.WriteString(h.Location.String())
+	if h.Terrain != "" {
+		sb.WriteString(": " + h.Terrain.Name())
+	}
+	if h.Settlement != "" {
+		sb.WriteString(", " + h.Settlement)
+	}
+	if len(h.Units) != 0 {
+		sb.WriteString(", units " + strings.Join(h.Units, " "))
+	}
+	if h.Turn != "" {
+		sb.WriteString(" (turn " + h.Turn + ")")
+	}
+	return sb.String()
+}
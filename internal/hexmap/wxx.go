@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package hexmap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/mdhender/ottomat/internal/report"
+)
+
+const (
+	// Worldographer draws columns of flat-topped hexes with these dimensions.
+	wxxHexWidth  = 46.18
+	wxxHexHeight = 40.0
+)
+
+// wxxTerrain maps our terrain to the names of tiles in Worldographer's
+// classic terrain set. Index 0 is always "Blank".
+var wxxTerrain = []struct {
+	terrain report.Terrain
+	name    string
+}{
+	{"", "Blank"},
+	{report.Alps, "Mountains Snowcapped"},
+	{report.AridHills, "Hills Desert"},
+	{report.AridTundra, "Flat Desert Rocky"},
+	{report.BrushFlat, "Flat Shrubland"},
+	{report.BrushHills, "Hills Shrubland"},
+	{report.ConiferHills, "Hills Forest Evergreen"},
+	{report.Deciduous, "Flat Forest Deciduous"},
+	{report.Desert, "Flat Desert Sandy"},
+	{report.DeciduousHills, "Hills Forest Deciduous"},
+	{report.GrassyHills, "Hills Grassland"},
+	{report.GrassyHillsPlateau, "Hills Grassy"},
+	{report.HighSnowyMountains, "Mountains Snowcapped"},
+	{report.Jungle, "Flat Forest Jungle"},
+	{report.JungleHills, "Hills Forest Jungle"},
+	{report.Lake, "Water Shoals"},
+	{report.LowAridMountains, "Mountain Desert"},
+	{report.LowConiferMountains, "Mountain Forest Evergreen"},
+	{report.LowJungleMountains, "Mountain Forest Jungle"},
+	{report.LowSnowyMountains, "Mountain Snowcapped"},
+	{report.LowVolcanicMountains, "Mountain Volcano Dormant"},
+	{report.Ocean, "Water Sea"},
+	{report.PolarIce, "Flat Ice"},
+	{report.Prairie, "Flat Grazing Land"},
+	{report.RockyHills, "Hills Rocky"},
+	{report.SnowyHills, "Hills Snowcapped"},
+	{report.Swamp, "Flat Swamp"},
+	{report.Tundra, "Flat Tundra"},
+}
+
+// RenderWXX writes the map as a Worldographer (.wxx) file, which is
+// gzipped, UTF-16 encoded XML.
+//
+// The exported area covers every TribeNet grid that has a known hex, so
+// exports from different turns line up with each other.
+func RenderWXX(w io.Writer, m *Map) error {
+	buf := &bytes.Buffer{}
+	writeWXX(buf, m)
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(encodeUTF16(buf.String())); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeWXX(w *bytes.Buffer, m *Map) {
+	hexes := m.Sorted()
+
+	// find the grids that hold the known hexes
+	minCol, minRow, maxCol, maxRow := 0, 0, report.GridColumns-1, report.GridRows-1
+	for i, h := range hexes {
+		col, row := h.Location.Absolute()
+		if i == 0 {
+			minCol, minRow, maxCol, maxRow = col, row, col, row
+			continue
+		}
+		minCol, maxCol = min(minCol, col), max(maxCol, col)
+		minRow, maxRow = min(minRow, row), max(maxRow, row)
+	}
+	minCol, minRow = minCol-minCol%report.GridColumns, minRow-minRow%report.GridRows
+	maxCol = maxCol - maxCol%report.GridColumns + report.GridColumns - 1
+	maxRow = maxRow - maxRow%report.GridRows + report.GridRows - 1
+	wide, high := maxCol-minCol+1, maxRow-minRow+1
+
+	terrainIndex := map[report.Terrain]int{}
+	var terrainMap []string
+	for i, t := range wxxTerrain {
+		terrainIndex[t.terrain] = i
+		terrainMap = append(terrainMap, fmt.Sprintf("%s\t%d", t.name, i))
+	}
+
+	// center returns the Worldographer coordinates of the center of a hex
+	center := func(h report.Hex) (float64, float64) {
+		col, row := h.Absolute()
+		col, row = col-minCol, row-minRow
+		x := float64(col)*wxxHexWidth*0.75 + wxxHexWidth/2
+		y := float64(row)*wxxHexHeight + wxxHexHeight/2
+		if col%2 == 1 {
+			y += wxxHexHeight / 2
+		}
+		return x, y
+	}
+
+	fmt.Fprintf(w, "<?xml version='1.0' encoding='utf-16'?>\n")
+	fmt.Fprintf(w, `<map type="WORLD" version="1.74" lastViewLevel="WORLD" continentFactor="-1" kingdomFactor="-1" provinceFactor="-1" hexWidth="%.2f" hexHeight="%.2f" hexOrientation="COLUMNS" mapProjection="FLAT" showNotes="true" showGMOnly="false" showGMOnlyGlow="false" showFeatureLabels="true" showGrid="true" showGridNumbers="false" showShadows="true" triangleSize="12">`+"\n", wxxHexWidth, wxxHexHeight)
+	fmt.Fprintf(w, `<gridandnumbering color0="0x00000040" color1="0x00000040" color2="0x00000040" color3="0x00000040" color4="0x00000040" width0="1.0" width1="2.0" width2="3.0" width3="4.0" width4="1.0" gridOffsetContinentKingdomX="0.0" gridOffsetContinentKingdomY="0.0" gridOffsetWorldContinentX="0.0" gridOffsetWorldContinentY="0.0" gridOffsetWorldKingdomX="0.0" gridOffsetWorldKingdomY="0.0" gridSquare="0" gridSquareHeight="-1.0" gridSquareWidth="-1.0" gridOffsetX="0.0" gridOffsetY="0.0" numberFont="Arial" numberColor="0x000000ff" numberSize="20" numberStyle="PLAIN" numberFirstCol="0" numberFirstRow="0" numberOrder="COL_ROW" numberPosition="BOTTOM" numberPrePad="DOUBLE_ZERO" numberSeparator="." />`+"\n")
+	fmt.Fprintf(w, "<terrainmap>%s</terrainmap>\n", strings.Join(terrainMap, "\t"))
+	for _, layer := range []string{"Labels", "Grid", "Features", "Above Terrain", "Terrain Land", "Above Water", "Terrain Water", "Below All"} {
+		fmt.Fprintf(w, `<maplayer name="%s" isVisible="true"/>`+"\n", layer)
+	}
+
+	// tiles are written one column per tilerow
+	known := map[[2]int]*Hex{}
+	for _, h := range hexes {
+		col, row := h.Location.Absolute()
+		known[[2]int{col, row}] = h
+	}
+	fmt.Fprintf(w, `<tiles viewLevel="WORLD" tilesWide="%d" tilesHigh="%d">`+"\n", wide, high)
+	for col := minCol; col <= maxCol; col++ {
+		fmt.Fprintf(w, "<tilerow>\n")
+		for row := minRow; row <= maxRow; row++ {
+			index := 0
+			if h, ok := known[[2]int{col, row}]; ok {
+				index = terrainIndex[h.Terrain]
+			}
+			fmt.Fprintf(w, "%d\t0\t0\t0\t0\t0\t0\t0\t0\t0\tZ\n", index)
+		}
+		fmt.Fprintf(w, "</tilerow>\n")
+	}
+	fmt.Fprintf(w, "</tiles>\n")
+
+	fmt.Fprintf(w, "<mapkey positionx=\"0.0\" positiony=\"0.0\" viewlevel=\"WORLD\" height=\"-1\" backgroundcolor=\"0.9803921580314636,0.9215686321258545,0.843137264251709,1.0\" backgroundopacity=\"50\" titleText=\"Map Key\" titleFontFace=\"Arial\" titleFontColor=\"0.0,0.0,0.0,1.0\" titleFontBold=\"true\" titleFontItalic=\"false\" titleScale=\"80\" scaleText=\"1 Hex = ? units\" scaleFontFace=\"Arial\" scaleFontColor=\"0.0,0.0,0.0,1.0\" scaleFontBold=\"true\" scaleFontItalic=\"false\" scaleScale=\"65\" entryFontFace=\"Arial\" entryFontColor=\"0.0,0.0,0.0,1.0\" entryFontBold=\"true\" entryFontItalic=\"false\" entryScale=\"55\"  >\n</mapkey>\n")
+
+	fmt.Fprintf(w, "<features>\n")
+	for _, h := range hexes {
+		if h.Settlement == "" {
+			continue
+		}
+		x, y := center(h.Location)
+		fmt.Fprintf(w, `<feature type="Settlement Village" rotate="0.0" uuid="%s" mapLayer="Above Terrain" isFlipHorizontal="false" isFlipVertical="false" scale="-1.0" scaleHt="-1.0" tags="" color="null" ringcolor="null" isGMOnly="false" isPlaceFreely="false" labelPosition="6:00" labelDistance="0" isWorld="true" isContinent="true" isKingdom="true" isProvince="true" isFillHexBottom="false" isHideTerrainIcon="false">`, wxxUUID(h.Location))
+		fmt.Fprintf(w, `<location viewLevel="WORLD" x="%.2f" y="%.2f" />`, x, y)
+		fmt.Fprintf(w, `<label mapLayer="Labels" style="null" fontFace="null" color="0.0,0.0,0.0,1.0" outlineColor="null" outlineSize="0.0" rotate="0.0" isBold="false" size="0.0" isItalic="false" isWorld="true" isContinent="true" isKingdom="true" isProvince="true" isGMOnly="false" tags="">`)
+		fmt.Fprintf(w, `<location viewLevel="WORLD" x="%.2f" y="%.2f" scale="12.5" />%s</label>`, x, y+wxxHexHeight/4, html.EscapeString(h.Settlement))
+		fmt.Fprintf(w, "</feature>\n")
+	}
+	fmt.Fprintf(w, "</features>\n")
+
+	fmt.Fprintf(w, "<labels>\n")
+	for _, h := range hexes {
+		x, y := center(h.Location)
+		fmt.Fprintf(w, `<label mapLayer="Labels" style="null" fontFace="null" color="0.0,0.0,0.0,1.0" outlineColor="null" outlineSize="0.0" rotate="0.0" isBold="false" size="0.0" isItalic="false" isWorld="true" isContinent="true" isKingdom="true" isProvince="true" isGMOnly="false" tags="">`)
+		fmt.Fprintf(w, `<location viewLevel="WORLD" x="%.2f" y="%.2f" scale="6.25" />%s</label>`+"\n", x, y-wxxHexHeight/3, h.Location)
+	}
+	fmt.Fprintf(w, "</labels>\n")
+	fmt.Fprintf(w, "<shapes>\n</shapes>\n")
+
+	// notes carry everything that doesn't have a place on the tiles
+	fmt.Fprintf(w, "<notes>\n")
+	for _, h := range hexes {
+		text := wxxNote(h)
+		if text == "" {
+			continue
+		}
+		x, y := center(h.Location)
+		col, row := h.Location.Absolute()
+		fmt.Fprintf(w, `<note key="WORLD,%d,%d" viewLevel="WORLD" x="%.2f" y="%.2f" filename="" parent="" color="1.0,1.0,0.0,1.0" title="%s">`, col-minCol, row-minRow, x, y, h.Location)
+		fmt.Fprintf(w, "<notetext>%s</notetext></note>\n", html.EscapeString(text))
+	}
+	fmt.Fprintf(w, "</notes>\n")
+	fmt.Fprintf(w, "<informations>\n</informations>\n")
+	fmt.Fprintf(w, "<configuration>\n</configuration>\n")
+	fmt.Fprintf(w, "</map>\n")
+}
+
+// wxxNote is the note text for a hex: units, edges, and the turn it was last seen.
+func wxxNote(h *Hex) string {
+	var lines []string
+	if len(h.Units) != 0 {
+		lines = append(lines, "Units: "+strings.Join(h.Units, ", "))
+	}
+	for _, d := range report.Directions {
+		if features := h.Edges[d]; len(features) != 0 {
+			lines = append(lines, fmt.Sprintf("%s: %s", d, strings.Join(features, ", ")))
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	if h.Turn != "" {
+		lines = append(lines, "Last seen: "+h.Turn)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wxxUUID returns a stable, UUID-shaped id for a feature, so that exports
+// of the same map are identical.
+func wxxUUID(h report.Hex) string {
+	col, row := h.Absolute()
+	return fmt.Sprintf("00000000-0000-4000-8000-%012x", col<<16|row)
+}
+
+// encodeUTF16 converts text to big-endian UTF-16 with a byte order mark.
+func encodeUTF16(s string) []byte {
+	units := utf16.Encode([]rune(s))
+	buf := make([]byte, 2+2*len(units))
+	buf[0], buf[1] = 0xfe, 0xff
+	for i, unit := range units {
+		binary.BigEndian.PutUint16(buf[2+2*i:], unit)
+	}
+	return buf
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package report
+
+// Direction is one of the six hex directions. TribeNet hexes are flat-topped,
+// so there is no East or West.
+type Direction string
+
+const (
+	North     Direction = "N"
+	NorthEast Direction = "NE"
+	SouthEast Direction = "SE"
+	South     Direction = "S"
+	SouthWest Direction = "SW"
+	NorthWest Direction = "NW"
+)
+
+// Directions lists the directions in clockwise order starting from North.
+var Directions = []Direction{North, NorthEast, SouthEast, South, SouthWest, NorthWest}
+
+// LookupDirection returns the direction for a string like "NE".
+func LookupDirection(s string) (Direction, bool) {
+	switch d := Direction(s); d {
+	case North, NorthEast, SouthEast, South, SouthWest, NorthWest:
+		return d, true
+	}
+	return "", false
+}
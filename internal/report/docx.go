@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Text returns the text of a report file. Word documents are converted to
+// plain text; any other file is assumed to already be text.
+func Text(filename string, data []byte) ([]byte, error) {
+	if strings.ToLower(filepath.Ext(filename)) == ".docx" {
+		return DocxText(data)
+	}
+	return data, nil
+}
+
+// MaxDocumentSize is the largest word/document.xml DocxText will expand, and
+// the most text it will return. Reports are a few hundred kilobytes at most.
+const MaxDocumentSize = 8 << 20
+
+// ErrTooLarge is returned when a Word document expands past MaxDocumentSize.
+var ErrTooLarge = errors.New("docx: document is too large")
+
+// DocxText extracts the text from a Word document, one line per paragraph.
+func DocxText(data []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("docx: %w", err)
+	}
+	var doc *zip.File
+	for _, f := range zr.File {
+		if f.Name == "word/document.xml" {
+			doc = f
+			break
+		}
+	}
+	if doc == nil {
+		return nil, errors.New("docx: missing word/document.xml")
+	} else if doc.UncompressedSize64 > MaxDocumentSize {
+		return nil, ErrTooLarge
+	}
+	rc, err := doc.Open()
+	if err != nil {
+		return nil, fmt.Errorf("docx: %w", err)
+	}
+	defer rc.Close()
+
+	// we only care about text runs (w:t), tabs, line breaks, and the end of paragraphs.
+	var buf bytes.Buffer
+	inText := false
+	// the header size can't be trusted, so limit what is read too; the
+	// extra byte tells a document at the limit from one past it.
+	lr := &io.LimitedReader{R: rc, N: MaxDocumentSize + 1}
+	dec := xml.NewDecoder(lr)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			if lr.N <= 0 {
+				return nil, ErrTooLarge
+			}
+			return nil, fmt.Errorf("docx: %w", err)
+		}
+		if buf.Len() > MaxDocumentSize {
+			return nil, ErrTooLarge
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				buf.WriteByte('\t')
+			case "br", "cr":
+				buf.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				buf.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				buf.Write(t)
+			}
+		}
+	}
+	if lr.N <= 0 || buf.Len() > MaxDocumentSize {
+		return nil, ErrTooLarge
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package report_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mdhender/ottomat/internal/report"
+)
+
+// docx returns a Word document holding body in word/document.xml.
+func docx(t *testing.T, body string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("word/document.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		body + `</w:body></w:document>`
+	if _, err := w.Write([]byte(doc)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDocxText(t *testing.T) {
+	data := docx(t, `<w:p><w:r><w:t>Tribe 0138,</w:t><w:tab/><w:t>Current Hex = OO 0202</w:t></w:r></w:p>`+
+		`<w:p><w:r><w:t>Current Turn 901-04</w:t><w:br/><w:t>Next</w:t></w:r></w:p>`)
+	got, err := report.Text("0138.0901-04.docx", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Tribe 0138,\tCurrent Hex = OO 0202\nCurrent Turn 901-04\nNext\n"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, err := report.DocxText([]byte("not a zip file")); err == nil {
+		t.Error("not a zip file: got nil error")
+	}
+}
+
+// A document that compresses well must not be expanded past the limit.
+func TestDocxTextTooLarge(t *testing.T) {
+	text := strings.Repeat("x", report.MaxDocumentSize)
+	data := docx(t, `<w:p><w:r><w:t>`+text+`</w:t></w:r></w:p>`)
+	if len(data) > report.MaxDocumentSize/100 {
+		t.Fatalf("document is %d bytes, want it to compress well", len(data))
+	}
+	if _, err := report.DocxText(data); !errors.Is(err, report.ErrTooLarge) {
+		t.Errorf("got %v, want %v", err, report.ErrTooLarge)
+	}
+}
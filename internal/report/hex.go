@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+const (
+	// GridColumns is the number of hex columns in a TribeNet grid.
+	GridColumns = 30
+	// GridRows is the number of hex rows in a TribeNet grid.
+	GridRows = 21
+)
+
+var (
+	hexPattern = regexp.MustCompile(`^(##|[A-Z]{2}) (\d{2})(\d{2})$`)
+)
+
+// Hex is a location in TribeNet "AA 0101" notation. The letters select the
+// grid (row, then column) and the digits select the column and row within
+// the grid. Reports sometimes hide the grid behind "##"; those hexes are
+// Obscured and can't be placed on a map without more information.
+type Hex struct {
+	GridRow  byte // 'A' ... 'Z', or 0 when obscured
+	GridCol  byte // 'A' ... 'Z', or 0 when obscured
+	Column   int  // 1 ... 30
+	Row      int  // 1 ... 21
+	Obscured bool
+}
+
+// ParseHex parses a hex like "AA 0101" or "## 0101".
+func ParseHex(s string) (Hex, error) {
+	m := hexPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Hex{}, fmt.Errorf("invalid hex %q", s)
+	}
+	col, _ := strconv.Atoi(m[2])
+	row, _ := strconv.Atoi(m[3])
+	if col < 1 || col > GridColumns || row < 1 || row > GridRows {
+		return Hex{}, fmt.Errorf("invalid hex %q: out of range", s)
+	}
+	h := Hex{Column: col, Row: row}
+	if m[1] == "##" {
+		h.Obscured = true
+	} else {
+		h.GridRow, h.GridCol = m[1][0], m[1][1]
+	}
+	return h, nil
+}
+
+// HexFromAbsolute returns the hex for a zero-based column and row on the
+// full map. It is the inverse of Absolute.
+func HexFromAbsolute(col, row int) Hex {
+	return Hex{
+		GridRow: byte('A' + row/GridRows),
+		GridCol: byte('A' + col/GridColumns),
+		Column:  col%GridColumns + 1,
+		Row:     row%GridRows + 1,
+	}
+}
+
+// Absolute returns the zero-based column and row of the hex on the full map.
+// The result is meaningless for obscured hexes.
+func (h Hex) Absolute() (col, row int) {
+	return int(h.GridCol-'A')*GridColumns + h.Column - 1, int(h.GridRow-'A')*GridRows + h.Row - 1
+}
+
+// IsZero returns true if the hex was never set (e.g., "N/A" in a report).
+func (h Hex) IsZero() bool {
+	return h == Hex{}
+}
+
+// String implements the fmt.Stringer interface.
+func (h Hex) String() string {
+	if h.IsZero() {
+		return "N/A"
+	} else if h.Obscured {
+		return fmt.Sprintf("## %02d%02d", h.Column, h.Row)
+	}
+	return fmt.Sprintf("%c%c %02d%02d", h.GridRow, h.GridCol, h.Column, h.Row)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (h Hex) MarshalJSON() ([]byte, error) {
+	if h.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(h.String())
+}
@@ -0,0 +1,422 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	reUnitHeader = regexp.MustCompile(`^(Tribe|Courier|Element|Fleet|Garrison) (\d{4}(?:[cefg][1-9])?), ([^,]*), Current Hex = (N/A|## \d{4}|[A-Z]{2} \d{4}), \(Previous Hex = (N/A|## \d{4}|[A-Z]{2} \d{4})\)`)
+	reTurnID     = regexp.MustCompile(`^\d{4}-(0[1-9]|1[0-2])$`)
+	reTurn       = regexp.MustCompile(`^Current Turn (\d{3,4})-(\d{2}) \(#(\d+)\)(?:, (\w+), (\w+))?`)
+	reMovement   = regexp.MustCompile(`^(?:Tribe|Courier|Element|Fleet|Garrison) Movement: Move(.*)$`)
+	reScout      = regexp.MustCompile(`^Scout ([1-8]):Scout(.*)$`)
+	reFollows    = regexp.MustCompile(`^(?:Tribe|Courier|Element|Fleet|Garrison) Follows (\d{4}(?:[cefg][1-9])?)$`)
+	reGoesTo     = regexp.MustCompile(`^(?:Tribe|Courier|Element|Fleet|Garrison) Goes to (## \d{4}|[A-Z]{2} \d{4})$`)
+	reStatus     = regexp.MustCompile(`^(\d{4}(?:[cefg][1-9])?) Status: (.*)$`)
+
+	reStepMoved     = regexp.MustCompile(`^(NE|SE|SW|NW|N|S)-([A-Za-z]+)(?:,(.*))?$`)
+	reStepWater     = regexp.MustCompile(`^Can't Move on ([A-Za-z ]+) to (NE|SE|SW|NW|N|S) of HEX(?:,(.*))?$`)
+	reStepExhausted = regexp.MustCompile(`^Not enough M\.P's to move to (NE|SE|SW|NW|N|S) into ([A-Za-z ]+?)(?:,(.*))?$`)
+	reStepNoFord    = regexp.MustCompile(`^No Ford on River to (NE|SE|SW|NW|N|S) of HEX(?:,(.*))?$`)
+	reFound         = regexp.MustCompile(`^(?:Patrolled and found|Found) (.*)$`)
+	reUnitID        = regexp.MustCompile(`^\d{4}(?:[cefg][1-9])?$`)
+	reSettlement    = regexp.MustCompile(`^[A-Z][A-Za-z' -]*$`)
+
+	// edgeFeatures are the features that can appear on the side of a hex.
+	edgeFeatures = []string{"River", "Ford", "Pass", "Canal", "Stone Road"}
+)
+
+// IsTurnID returns true if s is a normalized turn id like "0901-04".
+func IsTurnID(s string) bool {
+	return reTurnID.MatchString(s)
+}
+
+// Parse parses the text of a turn report. It never fails; problems are
+// returned in the Errors of the report.
+func Parse(text []byte) *Report {
+	p := &parser{rpt: &Report{}}
+	scanner := bufio.NewScanner(bytes.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		p.line++
+		p.parseLine(strings.TrimSpace(strings.TrimRight(scanner.Text(), "\r")))
+	}
+	if err := scanner.Err(); err != nil {
+		p.errorf("%v", err)
+	}
+	if p.rpt.Turn == nil {
+		p.rpt.Errors = append(p.rpt.Errors, &Error{Line: 0, Message: "missing \"Current Turn\" line"})
+	}
+	if len(p.rpt.Units) == 0 {
+		p.rpt.Errors = append(p.rpt.Errors, &Error{Line: 0, Message: "no units found"})
+	}
+	return p.rpt
+}
+
+type parser struct {
+	rpt  *Report
+	line int
+	unit *Unit // the unit whose section we are in
+}
+
+func (p *parser) errorf(format string, args ...any) {
+	p.rpt.Errors = append(p.rpt.Errors, &Error{Line: p.line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) parseLine(line string) {
+	if line == "" {
+		return
+	}
+
+	// unit headers start a new section
+	if m := reUnitHeader.FindStringSubmatch(line); m != nil {
+		p.parseUnitHeader(m)
+		return
+	} else if isUnitHeaderLike(line) {
+		p.errorf("invalid unit header %q", line)
+		p.unit = nil
+		return
+	}
+
+	if strings.HasPrefix(line, "Current Turn ") {
+		p.parseTurn(line)
+		return
+	}
+
+	if m := reMovement.FindStringSubmatch(line); m != nil {
+		if u := p.requireUnit("movement"); u != nil {
+			if u.Moves != nil {
+				p.errorf("unit %s: duplicate movement line", u.ID)
+				return
+			}
+			u.Moves = p.parseSteps(m[1])
+		}
+		return
+	}
+
+	if m := reScout.FindStringSubmatch(line); m != nil {
+		if u := p.requireUnit("scout"); u != nil {
+			mv := p.parseSteps(m[2])
+			mv.Scout, _ = strconv.Atoi(m[1])
+			u.Scouts = append(u.Scouts, mv)
+		}
+		return
+	}
+
+	if m := reFollows.FindStringSubmatch(line); m != nil {
+		if u := p.requireUnit("follows"); u != nil {
+			u.Follows = m[1]
+		}
+		return
+	}
+
+	if m := reGoesTo.FindStringSubmatch(line); m != nil {
+		if u := p.requireUnit("goes to"); u != nil {
+			h, err := ParseHex(m[1])
+			if err != nil {
+				p.errorf("unit %s: goes to: %v", u.ID, err)
+				return
+			}
+			u.GoesTo = h
+		}
+		return
+	}
+
+	if m := reStatus.FindStringSubmatch(line); m != nil {
+		if u := p.requireUnit("status"); u != nil {
+			if m[1] != u.ID {
+				p.errorf("status for unit %s found in section for unit %s", m[1], u.ID)
+				return
+			}
+			u.Status = p.parseStatus(m[2])
+		}
+		return
+	}
+
+	// everything else in the report is ignored
+}
+
+// isUnitHeaderLike returns true if the line looks like it was meant to be a
+// unit header. We use it to report typos in headers rather than silently
+// dropping the whole section.
+func isUnitHeaderLike(line string) bool {
+	for _, prefix := range []string{"Tribe ", "Courier ", "Element ", "Fleet ", "Garrison "} {
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			return len(rest) >= 4 && reUnitID.MatchString(strings.SplitN(rest, ",", 2)[0]) && strings.Contains(rest, "Current Hex")
+		}
+	}
+	return false
+}
+
+func (p *parser) parseUnitHeader(m []string) {
+	u := &Unit{Line: p.line, ID: m[2], Kind: unitKind(m[2])}
+	p.unit = nil
+	for _, other := range p.rpt.Units {
+		if other.ID == u.ID {
+			p.errorf("duplicate section for unit %s (first seen on line %d)", u.ID, other.Line)
+			return
+		}
+	}
+	var err error
+	if m[4] != "N/A" {
+		if u.CurrentHex, err = ParseHex(m[4]); err != nil {
+			p.errorf("unit %s: current hex: %v", u.ID, err)
+		}
+	} else {
+		p.errorf("unit %s: current hex is N/A", u.ID)
+	}
+	if m[5] != "N/A" {
+		if u.PreviousHex, err = ParseHex(m[5]); err != nil {
+			p.errorf("unit %s: previous hex: %v", u.ID, err)
+		}
+	}
+	p.rpt.Units = append(p.rpt.Units, u)
+	p.unit = u
+}
+
+// unitKind returns the kind of unit from the unit id. Clan units are the
+// tribes numbered 0001 to 0999; the other kinds have a letter suffix.
+func unitKind(id string) Kind {
+	if len(id) == 6 {
+		switch id[4] {
+		case 'c':
+			return KindCourier
+		case 'e':
+			return KindElement
+		case 'f':
+			return KindFleet
+		case 'g':
+			return KindGarrison
+		}
+	} else if id[0] == '0' {
+		return KindClan
+	}
+	return KindTribe
+}
+
+func (p *parser) parseTurn(line string) {
+	m := reTurn.FindStringSubmatch(line)
+	if m == nil {
+		p.errorf("invalid turn line %q", line)
+		return
+	} else if p.rpt.Turn != nil {
+		// every unit section repeats the turn; they must agree
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if id := fmt.Sprintf("%04d-%02d", year, month); id != p.rpt.Turn.ID {
+			p.errorf("turn %s does not match turn %s on line %d", id, p.rpt.Turn.ID, p.rpt.Turn.Line)
+		}
+		return
+	}
+	t := &Turn{Line: p.line, Season: m[4], Wx: m[5]}
+	t.Year, _ = strconv.Atoi(m[1])
+	t.Month, _ = strconv.Atoi(m[2])
+	t.Number, _ = strconv.Atoi(m[3])
+	if t.Month < 1 || t.Month > 12 {
+		p.errorf("invalid turn month %q", m[2])
+		return
+	}
+	t.ID = fmt.Sprintf("%04d-%02d", t.Year, t.Month)
+	p.rpt.Turn = t
+}
+
+func (p *parser) requireUnit(what string) *Unit {
+	if p.unit == nil {
+		p.errorf("%s line found outside of a unit section", what)
+	}
+	return p.unit
+}
+
+// parseSteps parses the steps of a movement or scout line. Steps are
+// separated by backslashes, e.g. "N-PR, River S\NE-GH\Can't Move on Ocean to N of HEX".
+func (p *parser) parseSteps(text string) *Move {
+	mv := &Move{Line: p.line, Steps: []*Step{}}
+	for _, raw := range strings.Split(text, `\`) {
+		s := strings.TrimSpace(strings.Trim(strings.TrimSpace(raw), ","))
+		if s == "" {
+			continue
+		}
+		if m := reFound.FindStringSubmatch(s); m != nil {
+			// units found while patrolling are in the hex the scout ended in,
+			// or the hex it started from if it hasn't taken a step yet
+			var obs *Observation
+			if n := len(mv.Steps); n != 0 {
+				obs = &mv.Steps[n-1].Observation
+			} else {
+				if mv.Start == nil {
+					mv.Start = &Observation{}
+				}
+				obs = mv.Start
+			}
+			p.parseFeatures(obs, m[1])
+			continue
+		}
+		step, err := parseStep(s)
+		if err != nil {
+			p.errorf("%v", err)
+			continue
+		}
+		if step.rest != "" {
+			p.parseFeatures(&step.Observation, step.rest)
+		}
+		mv.Steps = append(mv.Steps, step.Step)
+	}
+	return mv
+}
+
+type parsedStep struct {
+	*Step
+	rest string // unparsed features after the terrain
+}
+
+func parseStep(s string) (parsedStep, error) {
+	if m := reStepMoved.FindStringSubmatch(s); m != nil {
+		d, _ := LookupDirection(m[1])
+		t, ok := LookupTerrain(m[2])
+		if !ok {
+			return parsedStep{}, fmt.Errorf("step %q: unknown terrain %q", s, m[2])
+		}
+		return parsedStep{Step: &Step{Direction: d, Result: Succeeded, Observation: Observation{Terrain: t}}, rest: m[3]}, nil
+	} else if m := reStepWater.FindStringSubmatch(s); m != nil {
+		d, _ := LookupDirection(m[2])
+		t, ok := LookupTerrain(m[1])
+		if !ok {
+			return parsedStep{}, fmt.Errorf("step %q: unknown terrain %q", s, m[1])
+		}
+		// the unit did not move, but it did see the terrain it bumped into
+		obs := Observation{Neighbors: []*Neighbor{{Direction: d, Terrain: t}}}
+		return parsedStep{Step: &Step{Direction: d, Result: Blocked, Observation: obs}, rest: m[3]}, nil
+	} else if m := reStepExhausted.FindStringSubmatch(s); m != nil {
+		d, _ := LookupDirection(m[1])
+		t, ok := LookupTerrain(m[2])
+		if !ok {
+			return parsedStep{}, fmt.Errorf("step %q: unknown terrain %q", s, m[2])
+		}
+		obs := Observation{Neighbors: []*Neighbor{{Direction: d, Terrain: t}}}
+		return parsedStep{Step: &Step{Direction: d, Result: Exhausted, Observation: obs}, rest: m[3]}, nil
+	} else if m := reStepNoFord.FindStringSubmatch(s); m != nil {
+		d, _ := LookupDirection(m[1])
+		obs := Observation{Edges: []*Edge{{Direction: d, Feature: "River"}}}
+		return parsedStep{Step: &Step{Direction: d, Result: Blocked, Observation: obs}, rest: m[2]}, nil
+	}
+	return parsedStep{}, fmt.Errorf("step %q: not recognized", s)
+}
+
+// parseStatus parses the text after "Status:", which starts with the full
+// terrain name of the hex the unit is in.
+func (p *parser) parseStatus(text string) *Observation {
+	terrain, rest, _ := strings.Cut(text, ",")
+	t, ok := LookupTerrain(terrain)
+	if !ok {
+		p.errorf("status: unknown terrain %q", terrain)
+	}
+	obs := &Observation{Terrain: t}
+	p.parseFeatures(obs, rest)
+	return obs
+}
+
+// parseFeatures parses the comma separated list of things seen from a hex.
+// Lists of directions may themselves be split by commas ("O N, NE"), so a
+// token that is only directions continues the previous feature.
+func (p *parser) parseFeatures(obs *Observation, text string) {
+	var continueDirections func(d Direction)
+	for _, tok := range strings.Split(text, ",") {
+		tok = strings.TrimSpace(tok)
+		if m := reFound.FindStringSubmatch(tok); m != nil {
+			// "Patrolled and found 0138" starts a list of units
+			tok = m[1]
+		}
+		if tok == "" {
+			continue
+		}
+		fields := strings.Fields(tok)
+
+		if dirs, ok := parseDirections(fields); ok {
+			if continueDirections == nil {
+				p.errorf("directions %q do not follow a feature", tok)
+				continue
+			}
+			for _, d := range dirs {
+				continueDirections(d)
+			}
+			continue
+		}
+		continueDirections = nil
+
+		if reUnitID.MatchString(tok) {
+			obs.Units = append(obs.Units, tok)
+			continue
+		}
+
+		if feature, rest, ok := cutEdgeFeature(tok); ok {
+			dirs, ok := parseDirections(strings.Fields(rest))
+			if !ok {
+				p.errorf("%s: invalid directions %q", feature, rest)
+				continue
+			}
+			continueDirections = func(d Direction) {
+				obs.Edges = append(obs.Edges, &Edge{Direction: d, Feature: feature})
+			}
+			for _, d := range dirs {
+				continueDirections(d)
+			}
+			continue
+		}
+
+		if len(fields) > 1 {
+			if t, ok := LookupTerrain(fields[0]); ok {
+				if dirs, ok := parseDirections(fields[1:]); ok {
+					continueDirections = func(d Direction) {
+						obs.Neighbors = append(obs.Neighbors, &Neighbor{Direction: d, Terrain: t})
+					}
+					for _, d := range dirs {
+						continueDirections(d)
+					}
+					continue
+				}
+			}
+		}
+
+		if reSettlement.MatchString(tok) {
+			if obs.Settlement != "" && obs.Settlement != tok {
+				p.errorf("second settlement %q in hex with settlement %q", tok, obs.Settlement)
+				continue
+			}
+			obs.Settlement = tok
+			continue
+		}
+
+		p.errorf("unrecognized feature %q", tok)
+	}
+}
+
+func cutEdgeFeature(tok string) (feature, rest string, ok bool) {
+	for _, feature := range edgeFeatures {
+		if rest, ok := strings.CutPrefix(tok, feature+" "); ok {
+			return feature, rest, true
+		}
+	}
+	return "", "", false
+}
+
+func parseDirections(fields []string) ([]Direction, bool) {
+	if len(fields) == 0 {
+		return nil, false
+	}
+	var dirs []Direction
+	for _, f := range fields {
+		d, ok := LookupDirection(f)
+		if !ok {
+			return nil, false
+		}
+		dirs = append(dirs, d)
+	}
+	return dirs, true
+}
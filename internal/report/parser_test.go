@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package report_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mdhender/ottomat/internal/report"
+)
+
+func parseFixture(t *testing.T, name string) *report.Report {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report.Parse(data)
+}
+
+func TestParseReport(t *testing.T) {
+	rpt := parseFixture(t, "0138.0901-04.txt")
+	for _, e := range rpt.Errors {
+		t.Errorf("unexpected error: %v", e)
+	}
+
+	if rpt.Turn == nil {
+		t.Fatal("turn: got nil")
+	} else if rpt.Turn.ID != "0901-04" || rpt.Turn.Number != 4 || rpt.Turn.Season != "Spring" || rpt.Turn.Wx != "FINE" {
+		t.Errorf("turn: got %+v", *rpt.Turn)
+	}
+
+	var ids []string
+	var kinds []report.Kind
+	for _, u := range rpt.Units {
+		ids = append(ids, u.ID)
+		kinds = append(kinds, u.Kind)
+	}
+	if want := []string{"0138", "0138e1", "0138c1"}; !slices.Equal(ids, want) {
+		t.Fatalf("units: got %v, want %v", ids, want)
+	}
+	if want := []report.Kind{report.KindClan, report.KindElement, report.KindCourier}; !slices.Equal(kinds, want) {
+		t.Errorf("kinds: got %v, want %v", kinds, want)
+	}
+
+	clan, element, courier := rpt.Units[0], rpt.Units[1], rpt.Units[2]
+	if got := clan.CurrentHex.String(); got != "OO 0202" {
+		t.Errorf("clan: current hex: got %q, want %q", got, "OO 0202")
+	}
+	if got := clan.PreviousHex.String(); got != "OO 0101" {
+		t.Errorf("clan: previous hex: got %q, want %q", got, "OO 0101")
+	}
+
+	if clan.Moves == nil {
+		t.Fatal("clan: moves: got nil")
+	}
+	steps := clan.Moves.Steps
+	if len(steps) != 3 {
+		t.Fatalf("clan: steps: got %d, want 3", len(steps))
+	}
+	if s := steps[0]; s.Direction != report.SouthEast || s.Result != report.Succeeded || s.Terrain != report.Prairie {
+		t.Errorf("clan: step 1: got %s %s %s", s.Direction, s.Result, s.Terrain)
+	}
+	if e := steps[0].Edges; len(e) != 1 || e[0].Direction != report.South || e[0].Feature != "River" {
+		t.Errorf("clan: step 1: edges: got %v", e)
+	}
+	if n := steps[1].Neighbors; len(n) != 2 || n[0].Direction != report.NorthEast || n[1].Direction != report.North || n[1].Terrain != report.Ocean {
+		t.Errorf("clan: step 2: neighbors: got %v", n)
+	}
+	if s := steps[2]; s.Direction != report.North || s.Result != report.Blocked {
+		t.Errorf("clan: step 3: got %s %s", s.Direction, s.Result)
+	}
+
+	if len(clan.Scouts) != 2 {
+		t.Fatalf("clan: scouts: got %d, want 2", len(clan.Scouts))
+	}
+	scout := clan.Scouts[0]
+	if scout.Scout != 1 || len(scout.Steps) != 2 {
+		t.Fatalf("scout 1: got scout %d with %d steps", scout.Scout, len(scout.Steps))
+	}
+	if s := scout.Steps[1]; s.Result != report.Exhausted || !slices.Equal(s.Units, []string{"0250"}) {
+		t.Errorf("scout 1: step 2: got %s with units %v", s.Result, s.Units)
+	}
+	if clan.Scouts[1].Steps[0].Edges[0].Feature != "Ford" {
+		t.Errorf("scout 2: step 1: got edges %v", clan.Scouts[1].Steps[0].Edges)
+	}
+
+	if clan.Status == nil {
+		t.Fatal("clan: status: got nil")
+	} else if clan.Status.Terrain != report.Prairie || clan.Status.Settlement != "Riverton" || !slices.Equal(clan.Status.Units, []string{"0138", "0138e1"}) {
+		t.Errorf("clan: status: got %+v", *clan.Status)
+	}
+
+	if element.Follows != "0138" {
+		t.Errorf("element: follows: got %q, want %q", element.Follows, "0138")
+	}
+	if !courier.CurrentHex.Obscured {
+		t.Errorf("courier: current hex: got %s, want obscured", courier.CurrentHex)
+	}
+	if got := courier.GoesTo.String(); got != "OO 0505" {
+		t.Errorf("courier: goes to: got %q, want %q", got, "OO 0505")
+	}
+}
+
+// Units found before the first step of a scout are in the hex the scout
+// started from and must not be dropped.
+func TestParseFoundBeforeFirstStep(t *testing.T) {
+	rpt := parseFixture(t, "0138.0901-04.txt")
+	scout := rpt.Units[0].Scouts[0]
+	if scout.Start == nil {
+		t.Fatal("scout 1: start: got nil")
+	} else if !slices.Equal(scout.Start.Units, []string{"0138e1"}) {
+		t.Errorf("scout 1: start: units: got %v, want [0138e1]", scout.Start.Units)
+	}
+	if rpt.Units[0].Scouts[1].Start != nil {
+		t.Errorf("scout 2: start: got %+v, want nil", *rpt.Units[0].Scouts[1].Start)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	rpt := parseFixture(t, "errors.txt")
+	want := []report.Error{
+		{Line: 1, Message: "scout line found outside of a unit section"},
+		{Line: 4, Message: `step "SE-XX": unknown terrain "XX"`},
+		{Line: 5, Message: "unit 0138: duplicate movement line"},
+		{Line: 7, Message: "unit 0139: current hex is N/A"},
+		{Line: 8, Message: "turn 0901-05 does not match turn 0901-04 on line 3"},
+		{Line: 9, Message: "status for unit 0138 found in section for unit 0139"},
+	}
+	if len(rpt.Errors) != len(want) {
+		for _, e := range rpt.Errors {
+			t.Log(e)
+		}
+		t.Fatalf("errors: got %d, want %d", len(rpt.Errors), len(want))
+	}
+	for i, e := range rpt.Errors {
+		if *e != want[i] {
+			t.Errorf("error %d: got %v, want %v", i+1, e, &want[i])
+		}
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	rpt := report.Parse(nil)
+	want := []string{`line 0: missing "Current Turn" line`, "line 0: no units found"}
+	var got []string
+	for _, e := range rpt.Errors {
+		got = append(got, e.Error())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("errors: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package report parses TribeNet turn reports into structured data.
+//
+// The parser only looks at the lines that describe where units are and what
+// they saw while moving. Everything else in a report (inventories, combat,
+// transfers, and so on) is ignored. Problems are collected as Errors with
+// line numbers instead of stopping the parse, so that a chief can fix all of
+// them before uploading the report again.
+package report
+
+import (
+	"fmt"
+)
+
+// Kind is the kind of unit that a section of the report describes.
+type Kind string
+
+const (
+	KindClan     Kind = "clan"
+	KindTribe    Kind = "tribe"
+	KindCourier  Kind = "courier"
+	KindElement  Kind = "element"
+	KindFleet    Kind = "fleet"
+	KindGarrison Kind = "garrison"
+)
+
+// Result is the outcome of a single step of movement.
+type Result string
+
+const (
+	Succeeded Result = "succeeded" // the unit moved into the hex
+	Blocked   Result = "blocked"   // the unit could not enter the hex (water, no ford)
+	Exhausted Result = "exhausted" // the unit ran out of movement points
+)
+
+// Report is the parsed content of a single turn report.
+type Report struct {
+	Turn   *Turn    `json:"turn,omitempty"`
+	Units  []*Unit  `json:"units"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Turn is the turn the report was generated for.
+type Turn struct {
+	Line   int    `json:"line"`
+	ID     string `json:"id"` // "0901-04"
+	Year   int    `json:"year"`
+	Month  int    `json:"month"`
+	Number int    `json:"number"`
+	Season string `json:"season,omitempty"`
+	Wx     string `json:"weather,omitempty"`
+}
+
+// Unit is a clan, tribe, courier, element, fleet, or garrison section.
+type Unit struct {
+	Line        int          `json:"line"`
+	ID          string       `json:"id"`
+	Kind        Kind         `json:"kind"`
+	CurrentHex  Hex          `json:"current_hex"`
+	PreviousHex Hex          `json:"previous_hex"`
+	Moves       *Move        `json:"moves,omitempty"`
+	Scouts      []*Move      `json:"scouts,omitempty"`
+	Follows     string       `json:"follows,omitempty"`
+	GoesTo      Hex          `json:"goes_to"`
+	Status      *Observation `json:"status,omitempty"`
+}
+
+// Move is a list of steps taken by a unit or one of its scouts. Start is
+// what was seen in the starting hex before the first step, if anything.
+type Move struct {
+	Line  int          `json:"line"`
+	Scout int          `json:"scout,omitempty"`
+	Start *Observation `json:"start,omitempty"`
+	Steps []*Step      `json:"steps"`
+}
+
+// Step is a single attempt to move one hex in a direction, along with
+// everything the unit saw from the hex it ended the step in.
+type Step struct {
+	Direction   Direction `json:"direction"`
+	Result      Result    `json:"result"`
+	Observation           // what was seen after the step
+}
+
+// Observation is what a unit reports about the hex it is in and
+// the hexes around it.
+type Observation struct {
+	Terrain    Terrain     `json:"terrain,omitempty"`
+	Settlement string      `json:"settlement,omitempty"`
+	Neighbors  []*Neighbor `json:"neighbors,omitempty"`
+	Edges      []*Edge     `json:"edges,omitempty"`
+	Units      []string    `json:"units,omitempty"`
+}
+
+// Neighbor is the terrain seen in an adjacent hex.
+type Neighbor struct {
+	Direction Direction `json:"direction"`
+	Terrain   Terrain   `json:"terrain"`
+}
+
+// Edge is a feature (river, ford, pass, ...) on a side of the hex.
+type Edge struct {
+	Direction Direction `json:"direction"`
+	Feature   string    `json:"feature"`
+}
+
+// Error is a problem found while parsing a report.
+type Error struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
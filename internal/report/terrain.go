@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package report
+
+import "strings"
+
+// Terrain is the TribeNet terrain code for a hex, such as "PR" for prairie.
+type Terrain string
+
+const (
+	Alps                 Terrain = "ALPS"
+	AridHills            Terrain = "AH"
+	AridTundra           Terrain = "AR"
+	BrushFlat            Terrain = "BF"
+	BrushHills           Terrain = "BH"
+	ConiferHills         Terrain = "CH"
+	Deciduous            Terrain = "D"
+	Desert               Terrain = "DE"
+	DeciduousHills       Terrain = "DH"
+	GrassyHills          Terrain = "GH"
+	GrassyHillsPlateau   Terrain = "GHP"
+	HighSnowyMountains   Terrain = "HSM"
+	Jungle               Terrain = "JG"
+	JungleHills          Terrain = "JH"
+	Lake                 Terrain = "L"
+	LowAridMountains     Terrain = "LAM"
+	LowConiferMountains  Terrain = "LCM"
+	LowJungleMountains   Terrain = "LJM"
+	LowSnowyMountains    Terrain = "LSM"
+	LowVolcanicMountains Terrain = "LVM"
+	Ocean                Terrain = "O"
+	PolarIce             Terrain = "PI"
+	Prairie              Terrain = "PR"
+	RockyHills           Terrain = "RH"
+	SnowyHills           Terrain = "SH"
+	Swamp                Terrain = "SW"
+	Tundra               Terrain = "TU"
+)
+
+// terrainNames maps terrain codes to the names used in the reports.
+var terrainNames = map[Terrain]string{
+	Alps:                 "Alps",
+	AridHills:            "Arid Hills",
+	AridTundra:           "Arid Tundra",
+	BrushFlat:            "Brush Flat",
+	BrushHills:           "Brush Hills",
+	ConiferHills:         "Conifer Hills",
+	Deciduous:            "Deciduous",
+	Desert:               "Desert",
+	DeciduousHills:       "Deciduous Hills",
+	GrassyHills:          "Grassy Hills",
+	GrassyHillsPlateau:   "Grassy Hills Plateau",
+	HighSnowyMountains:   "High Snowy Mountains",
+	Jungle:               "Jungle",
+	JungleHills:          "Jungle Hills",
+	Lake:                 "Lake",
+	LowAridMountains:     "Low Arid Mountains",
+	LowConiferMountains:  "Low Conifer Mountains",
+	LowJungleMountains:   "Low Jungle Mountains",
+	LowSnowyMountains:    "Low Snowy Mountains",
+	LowVolcanicMountains: "Low Volcanic Mountains",
+	Ocean:                "Ocean",
+	PolarIce:             "Polar Ice",
+	Prairie:              "Prairie",
+	RockyHills:           "Rocky Hills",
+	SnowyHills:           "Snowy Hills",
+	Swamp:                "Swamp",
+	Tundra:               "Tundra",
+}
+
+// Name returns the display name for the terrain, or the code if the
+// terrain is not known.
+func (t Terrain) Name() string {
+	if name, ok := terrainNames[t]; ok {
+		return name
+	}
+	return string(t)
+}
+
+// IsWater returns true if units can't walk into the terrain.
+func (t Terrain) IsWater() bool {
+	return t == Lake || t == Ocean
+}
+
+// LookupTerrain returns the terrain for a code ("PR") or a name ("PRAIRIE").
+// The lookup is not case-sensitive.
+func LookupTerrain(s string) (Terrain, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if _, ok := terrainNames[Terrain(s)]; ok {
+		return Terrain(s), true
+	}
+	for code, name := range terrainNames {
+		if strings.ToUpper(name) == s {
+			return code, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,146 @@
+// Package resets manages the one-time password reset links that admins
+// hand to players.
+package resets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/passwordreset"
+	"github.com/mdhender/ottomat/ent/predicate"
+	"github.com/mdhender/ottomat/ent/session"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/auth"
+)
+
+// DefaultTTL is how long a reset link is valid.
+const DefaultTTL = 24 * time.Hour
+
+// ErrInvalid is returned for tokens that are unknown, used, or expired.
+var ErrInvalid = errors.New("reset link is invalid or has expired")
+
+// Path returns the site-relative URL for a reset token.
+func Path(token string) string {
+	return "/reset/" + token
+}
+
+// Create issues a new reset token for the user and returns it along with
+// its expiration. Any earlier unused tokens for the user are removed, so
+// only the newest link works.
+func Create(ctx context.Context, client *ent.Client, u *ent.User, ttl time.Duration) (string, time.Time, error) {
+	token, err := auth.GenerateToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	_, err = tx.PasswordReset.Delete().
+		Where(passwordreset.HasUserWith(user.ID(u.ID)), passwordreset.UsedAtIsNil()).
+		Exec(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return "", time.Time{}, fmt.Errorf("failed removing old reset links: %w", err)
+	}
+	_, err = tx.PasswordReset.Create().
+		SetTokenHash(auth.HashToken(token)).
+		SetExpiresAt(expiresAt).
+		SetUserID(u.ID).
+		Save(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return "", time.Time{}, fmt.Errorf("failed creating reset link: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// Lookup returns the user a valid token belongs to.
+func Lookup(ctx context.Context, client *ent.Client, token string) (*ent.User, error) {
+	pr, err := client.PasswordReset.Query().
+		Where(valid(token)...).
+		WithUser().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, ErrInvalid
+	} else if err != nil {
+		return nil, err
+	}
+	return pr.Edges.User, nil
+}
+
+// Consume marks the token used, sets the user's password hash, and signs
+// the user out of every session. It returns ErrInvalid if the token was
+// already used, even by a concurrent request.
+func Consume(ctx context.Context, client *ent.Client, token, passwordHash string) (*ent.User, error) {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := tx.PasswordReset.Query().
+		Where(valid(token)...).
+		WithUser().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		_ = tx.Rollback()
+		return nil, ErrInvalid
+	} else if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	n, err := tx.PasswordReset.Update().
+		Where(passwordreset.ID(pr.ID), passwordreset.UsedAtIsNil()).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	} else if n == 0 {
+		_ = tx.Rollback()
+		return nil, ErrInvalid
+	}
+	u := pr.Edges.User
+	if err := tx.User.UpdateOneID(u.ID).SetPasswordHash(passwordHash).Exec(ctx); err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed updating password: %w", err)
+	}
+	if _, err := tx.Session.Delete().Where(session.HasUserWith(user.ID(u.ID))).Exec(ctx); err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed removing sessions: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Prune deletes reset tokens that have been used or have expired and
+// returns the number deleted.
+func Prune(ctx context.Context, client *ent.Client) (int, error) {
+	n, err := client.PasswordReset.Delete().
+		Where(passwordreset.Or(passwordreset.UsedAtNotNil(), passwordreset.ExpiresAtLTE(time.Now()))).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune reset links: %w", err)
+	}
+	return n, nil
+}
+
+// valid matches an unused, unexpired reset with the token's hash. Links
+// for disabled users are never valid.
+func valid(token string) []predicate.PasswordReset {
+	return []predicate.PasswordReset{
+		passwordreset.TokenHash(auth.HashToken(token)),
+		passwordreset.UsedAtIsNil(),
+		passwordreset.ExpiresAtGT(time.Now()),
+		passwordreset.HasUserWith(user.DisabledAtIsNil()),
+	}
+}
@@ -0,0 +1,195 @@
+package resets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/passwordreset"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/database"
+)
+
+// openTestClient returns a client for a new, fully migrated database.
+func openTestClient(t *testing.T) *ent.Client {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	db, err := database.OpenDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.Up(context.Background(), db, 0); err != nil {
+		t.Fatal(err)
+	}
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func createUser(t *testing.T, client *ent.Client, username string) *ent.User {
+	t.Helper()
+	u, err := client.User.Create().
+		SetUsername(username).
+		SetPasswordHash("old").
+		SetRole(user.RoleGuest).
+		Save(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	u := createUser(t, client, "alice")
+
+	first, expiresAt, err := Create(ctx, client, u, DefaultTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Until(expiresAt); d <= DefaultTTL-time.Minute || d > DefaultTTL {
+		t.Errorf("expires in %s, want %s", d, DefaultTTL)
+	}
+	if got, err := Lookup(ctx, client, first); err != nil || got.ID != u.ID {
+		t.Fatalf("lookup: got %v, %v; want user %d", got, err, u.ID)
+	}
+
+	// only the newest link works
+	second, _, err := Create(ctx, client, u, DefaultTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Lookup(ctx, client, first); !errors.Is(err, ErrInvalid) {
+		t.Errorf("first link: got %v, want %v", err, ErrInvalid)
+	}
+	if _, err := Lookup(ctx, client, second); err != nil {
+		t.Errorf("second link: %v", err)
+	}
+	if n := client.PasswordReset.Query().Where(passwordreset.TokenHash(second)).CountX(ctx); n != 0 {
+		t.Errorf("token stored in the clear: got %d rows", n)
+	}
+
+	expired, _, err := Create(ctx, client, createUser(t, client, "bob"), -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Lookup(ctx, client, expired); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expired link: got %v, want %v", err, ErrInvalid)
+	}
+	if _, err := Lookup(ctx, client, "unknown"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("unknown link: got %v, want %v", err, ErrInvalid)
+	}
+}
+
+func TestConsume(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	u := createUser(t, client, "alice")
+	token, _, err := Create(ctx, client, u, DefaultTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tok := range []string{"one", "two"} {
+		if _, err := client.Session.Create().SetToken(tok).SetExpiresAt(time.Now().Add(time.Hour)).SetUser(u).Save(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := Consume(ctx, client, token, "new")
+	if err != nil {
+		t.Fatal(err)
+	} else if got.ID != u.ID {
+		t.Errorf("user: got %d, want %d", got.ID, u.ID)
+	}
+	if hash := client.User.GetX(ctx, u.ID).PasswordHash; hash != "new" {
+		t.Errorf("password hash: got %q, want %q", hash, "new")
+	}
+	if n := client.Session.Query().CountX(ctx); n != 0 {
+		t.Errorf("sessions: got %d, want 0", n)
+	}
+
+	// the link works once
+	if _, err := Consume(ctx, client, token, "newer"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("second use: got %v, want %v", err, ErrInvalid)
+	}
+	if _, err := Lookup(ctx, client, token); !errors.Is(err, ErrInvalid) {
+		t.Errorf("lookup after use: got %v, want %v", err, ErrInvalid)
+	}
+	if hash := client.User.GetX(ctx, u.ID).PasswordHash; hash != "new" {
+		t.Errorf("password hash after second use: got %q, want %q", hash, "new")
+	}
+
+	if n, err := Prune(ctx, client); err != nil || n != 1 {
+		t.Errorf("prune: got %d, %v; want 1", n, err)
+	}
+}
+
+// Requests racing to use the same link must not both succeed.
+func TestConsumeRace(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	u := createUser(t, client, "alice")
+	token, _, err := Create(ctx, client, u, DefaultTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 8
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = Consume(ctx, client, token, "new")
+		}()
+	}
+	wg.Wait()
+
+	used := 0
+	for i, err := range errs {
+		if err == nil {
+			used++
+		} else if !errors.Is(err, ErrInvalid) {
+			t.Errorf("request %d: got %v, want %v", i, err, ErrInvalid)
+		}
+	}
+	if used != 1 {
+		t.Errorf("got %d successful uses, want 1", used)
+	}
+}
+
+// Disabling a user makes their outstanding links useless.
+func TestDisabledUser(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	u := createUser(t, client, "alice")
+	token, _, err := Create(ctx, client, u, DefaultTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.User.UpdateOneID(u.ID).SetDisabledAt(time.Now()).Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Lookup(ctx, client, token); !errors.Is(err, ErrInvalid) {
+		t.Errorf("lookup: got %v, want %v", err, ErrInvalid)
+	}
+	if _, err := Consume(ctx, client, token, "new"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("consume: got %v, want %v", err, ErrInvalid)
+	}
+	if hash := client.User.GetX(ctx, u.ID).PasswordHash; hash != "old" {
+		t.Errorf("password hash: got %q, want %q", hash, "old")
+	}
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/mdhender/ottomat"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/session"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/audit"
+	"github.com/mdhender/ottomat/internal/auth"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/throttle"
+	"github.com/mdhender/ottomat/internal/views"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type accountPasswordPayload struct {
+	Username     string
+	Dashboard    string
+	PasswordType string
+	MinLength    int
+	Error        string
+	Success      string
+	CSRFToken    string
+	Version      string
+}
+
+func AccountPasswordPage(view views.Loader, visiblePasswords bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		payload := newAccountPasswordPayload(u, visiblePasswords)
+		payload.CSRFToken = middleware.CSRFToken(r.Context())
+		renderAccountPassword(w, r, view, payload, http.StatusOK)
+	}
+}
+
+// PostAccountPassword changes the current user's password. On success,
+// every other session belonging to the user is deleted so that a stolen
+// session can't outlive the password change.
+func PostAccountPassword(client *ent.Client, view views.Loader, visiblePasswords bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		payload := newAccountPasswordPayload(u, visiblePasswords)
+		payload.CSRFToken = middleware.CSRFToken(r.Context())
+
+		current := r.FormValue("current_password")
+		password := r.FormValue("new_password")
+		confirm := r.FormValue("confirm_password")
+
+		// the current password is throttled like a login, so a stolen
+		// session can't be used to guess it
+		ctx := r.Context()
+		ip := middleware.ClientIP(r)
+		if err := throttle.Check(ctx, client, u.Username, ip); errors.Is(err, throttle.ErrThrottled) {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			payload.Error = "Too many failed attempts. Please wait and try again."
+			renderAccountPassword(w, r, view, payload, http.StatusTooManyRequests)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(current)); err != nil {
+			log.Printf("%s %s: bcrypt %v\n", r.Method, r.URL.Path, err)
+			if err := throttle.Fail(ctx, client, u.Username, ip); err != nil {
+				log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+			}
+			payload.Error = "Current password is incorrect."
+			renderAccountPassword(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		}
+		if err := throttle.Succeed(ctx, client, u.Username); err != nil {
+			log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+		}
+		if password != confirm {
+			payload.Error = "New passwords do not match."
+			renderAccountPassword(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		} else if password == current {
+			payload.Error = "New password must be different from the current password."
+			renderAccountPassword(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		} else if err := auth.CheckPasswordStrength(u.Username, password); err != nil {
+			payload.Error = "New " + err.Error() + "."
+			renderAccountPassword(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			log.Printf("%s %s: hash %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		sess, ok := middleware.GetSession(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			log.Printf("%s %s: tx %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if err := tx.User.UpdateOneID(u.ID).SetPasswordHash(string(hash)).Exec(ctx); err != nil {
+			_ = tx.Rollback()
+			log.Printf("%s %s: update %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		n, err := tx.Session.Delete().
+			Where(session.HasUserWith(user.ID(u.ID)), session.IDNEQ(sess.ID)).
+			Exec(ctx)
+		if err != nil {
+			_ = tx.Rollback()
+			log.Printf("%s %s: sessions %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			log.Printf("%s %s: commit %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: %q: password changed, %d other session(s) removed\n", r.Method, r.URL.Path, u.Username, n)
+		recordAudit(r, client, audit.PasswordChange, u.Username, fmt.Sprintf("%d other session(s) signed out", n))
+
+		payload.Success = "Your password has been changed. Other sessions have been signed out."
+		renderAccountPassword(w, r, view, payload, http.StatusOK)
+	}
+}
+
+func newAccountPasswordPayload(u *ent.User, visiblePasswords bool) accountPasswordPayload {
+	payload := accountPasswordPayload{
+		Username:     u.Username,
+		Dashboard:    dashboardPath(u),
+		PasswordType: "password",
+		MinLength:    auth.MinPasswordLength,
+		Version:      ottomat.Version().String(),
+	}
+	if visiblePasswords {
+		payload.PasswordType = "text"
+	}
+	return payload
+}
+
+func renderAccountPassword(w http.ResponseWriter, r *http.Request, view views.Loader, payload accountPasswordPayload, status int) {
+	name := "pages/account/password"
+	buf, err := view.Execute(name, payload)
+	if err != nil {
+		log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+		http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -1,15 +1,25 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mdhender/ottomat"
 	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/ent/loginthrottle"
 	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/audit"
+	"github.com/mdhender/ottomat/internal/auth"
 	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/throttle"
+	"github.com/mdhender/ottomat/internal/users"
 	"github.com/mdhender/ottomat/internal/views"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -17,45 +27,75 @@ import (
 func AdminDashboard(client *ent.Client, view views.Loader) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
-		u, ok := middleware.GetUser(r.Context())
-		if !ok || u.Role != user.RoleAdmin {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		uq, err := parseUserQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-
 		ctx := r.Context()
-		users, err := client.User.Query().All(ctx)
+		table, err := newUserTable(ctx, client, uq)
 		if err != nil {
 			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			return
 		}
-
-		type userRow struct {
-			ID       string
-			Username string
-			Role     string
-			ClanID   string
-			UserID   string
+		clans, err := client.Clan.Query().WithGame().Order(ent.Asc(clan.FieldNumber)).All(ctx)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
 		}
+
 		payload := struct {
-			UserRows []userRow
-			Version  string
+			Users       userTable
+			ClanRows    []clanRow
+			GameRows    []gameRow
+			LockoutRows []lockoutRow
+			Settings    settingsRow
+			MinLength   int
+			CSRFToken   string
+			Version     string
 		}{
-			Version: ottomat.Version().String(),
-		}
-		for _, usr := range users {
-			row := userRow{
-				ID:       fmt.Sprintf("%d", usr.ID),
-				Username: usr.Username,
-				Role:     usr.Role.String(),
-				ClanID:   "N/A",
-				UserID:   fmt.Sprintf("%d", usr.ID),
-			}
-			if usr.ClanID != nil {
-				row.ClanID = fmt.Sprintf("%04d", *usr.ClanID)
+			Users:     table,
+			MinLength: auth.MinPasswordLength,
+			CSRFToken: middleware.CSRFToken(r.Context()),
+			Version:   ottomat.Version().String(),
+		}
+		for _, c := range clans {
+			payload.ClanRows = append(payload.ClanRows, newClanRow(c))
+		}
+		games, err := client.Game.Query().Order(ent.Asc(game.FieldCode)).All(ctx)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		for _, g := range games {
+			row, err := newGameRow(ctx, g)
+			if err != nil {
+				log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
 			}
-			payload.UserRows = append(payload.UserRows, row)
+			payload.GameRows = append(payload.GameRows, row)
+		}
+		lockouts, err := client.LoginThrottle.Query().
+			Where(loginthrottle.LastFailureAtGT(time.Now().Add(-throttle.Window))).
+			Order(ent.Desc(loginthrottle.FieldLastFailureAt)).
+			All(ctx)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		for _, lt := range lockouts {
+			payload.LockoutRows = append(payload.LockoutRows, newLockoutRow(lt))
+		}
+		payload.Settings, err = newSettingsRow(ctx, client)
+		if err != nil {
+			log.Printf("%s %s: settings %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
 		}
 		name := "pages/admin/dashboard"
 		buf, err := view.Execute(name, payload)
@@ -71,14 +111,8 @@ func AdminDashboard(client *ent.Client, view views.Loader) http.HandlerFunc {
 	}
 }
 
-func CreateUser(client *ent.Client) http.HandlerFunc {
+func CreateUser(client *ent.Client, view views.Loader) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		u, ok := middleware.GetUser(r.Context())
-		if !ok || u.Role != user.RoleAdmin {
-			http.Error(w, "Forbidden", http.StatusForbidden)
-			return
-		}
-
 		username := r.FormValue("username")
 		password := r.FormValue("password")
 		roleStr := r.FormValue("role")
@@ -86,6 +120,11 @@ func CreateUser(client *ent.Client) http.HandlerFunc {
 
 		ctx := r.Context()
 
+		if err := auth.CheckPasswordStrength(username, password); err != nil {
+			log.Printf("%s %s: %q: %v\n", r.Method, r.URL.Path, username, err)
+			http.Error(w, "New "+err.Error()+".", http.StatusUnprocessableEntity)
+			return
+		}
 		passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 		if err != nil {
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -98,11 +137,21 @@ func CreateUser(client *ent.Client) http.HandlerFunc {
 			SetPasswordHash(string(passwordHash)).
 			SetRole(user.Role(roleStr))
 
+		var userClan *ent.Clan
 		if clanIDStr != "" {
-			clanID, err := strconv.Atoi(clanIDStr)
-			if err == nil {
-				create.SetClanID(clanID)
+			number, err := strconv.Atoi(clanIDStr)
+			if err != nil {
+				http.Error(w, "Invalid clan number", http.StatusBadRequest)
+				return
+			}
+			c, err := client.Clan.Query().Where(clan.Number(number), clan.Active(true)).Only(ctx)
+			if err != nil {
+				log.Printf("%s %s: clan %d: %v\n", r.Method, r.URL.Path, number, err)
+				http.Error(w, "Unknown or inactive clan", http.StatusBadRequest)
+				return
 			}
+			create.SetClan(c)
+			userClan = c
 		}
 
 		newUser, err := create.Save(ctx)
@@ -111,51 +160,56 @@ func CreateUser(client *ent.Client) http.HandlerFunc {
 			return
 		}
 
-		clanID := "N/A"
-		if newUser.ClanID != nil {
-			clanID = fmt.Sprintf("%d", *newUser.ClanID)
-		}
-
-		html := fmt.Sprintf(`
-        <tr class="border-b border-gray-700">
-            <td class="py-3 px-4">%d</td>
-            <td class="py-3 px-4">%s</td>
-            <td class="py-3 px-4">%s</td>
-            <td class="py-3 px-4">%s</td>
-            <td class="py-3 px-4">
-                <button hx-delete="/admin/users/%d" hx-confirm="Are you sure?" hx-target="closest tr" hx-swap="outerHTML"
-                    class="bg-red-600 hover:bg-red-700 text-white font-medium py-1 px-3 rounded transition text-sm">
-                    Delete
-                </button>
-            </td>
-        </tr>`, newUser.ID, newUser.Username, newUser.Role, clanID, newUser.ID)
-
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, html)
+		newUser.Edges.Clan = userClan
+		recordAudit(r, client, audit.UserCreate, newUser.Username, userDetail(newUser.Role, userClan))
+		renderUserRow(w, r, view, newUserRow(newUser), http.StatusOK)
 	}
 }
 
-func DeleteUser(client *ent.Client) http.HandlerFunc {
+// DeleteUser permanently deletes a user and everything that belongs to
+// them. Because it can't be undone, the admin must confirm by sending the
+// username in the confirm value or, from hx-prompt, the HX-Prompt header;
+// otherwise the row is rendered again with an error. Admins can't delete
+// themselves or the last admin. Users who should only lose access are
+// disabled instead.
+func DeleteUser(client *ent.Client, view views.Loader) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		u, ok := middleware.GetUser(r.Context())
-		if !ok || u.Role != user.RoleAdmin {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
+		target := pathUser(client, w, r)
+		if target == nil {
+			return
+		}
+		confirm := r.FormValue("confirm")
+		if confirm == "" {
+			confirm = r.Header.Get("HX-Prompt")
+		}
 
-		idStr := r.PathValue("id")
-		id, err := strconv.Atoi(idStr)
-		if err != nil {
-			http.Error(w, "Invalid ID", http.StatusBadRequest)
+		row := newUserRow(target)
+		if target.ID == u.ID {
+			row.Error = "You can't delete your own account."
+			renderUserRow(w, r, view, row, http.StatusUnprocessableEntity)
+			return
+		} else if strings.TrimSpace(confirm) != target.Username {
+			row.Error = "Not deleted: type the username to confirm."
+			renderUserRow(w, r, view, row, http.StatusUnprocessableEntity)
 			return
 		}
 
-		ctx := r.Context()
-		err = client.User.DeleteOneID(id).Exec(ctx)
-		if err != nil {
+		if err := users.Purge(r.Context(), client, target); errors.Is(err, users.ErrLastAdmin) {
+			row.Error = "The last admin can't be deleted."
+			renderUserRow(w, r, view, row, http.StatusUnprocessableEntity)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
 			http.Error(w, "Failed to delete user", http.StatusInternalServerError)
 			return
 		}
+		log.Printf("%s %s: deleted user %q\n", r.Method, r.URL.Path, target.Username)
+		recordAudit(r, client, audit.UserDelete, target.Username, "")
 
 		w.WriteHeader(http.StatusOK)
 	}
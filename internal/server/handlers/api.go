@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/turnreport"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/apitokens"
+	"github.com/mdhender/ottomat/internal/report"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/twofactor"
+)
+
+// APIUser is the JSON form of a user.
+type APIUser struct {
+	ID         int        `json:"id"`
+	Username   string     `json:"username"`
+	Role       string     `json:"role"`
+	Clan       *int       `json:"clan"`
+	TwoFactor  bool       `json:"two_factor"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+}
+
+func newAPIUser(u *ent.User) APIUser {
+	au := APIUser{
+		ID:        u.ID,
+		Username:  u.Username,
+		Role:      u.Role.String(),
+		TwoFactor: twofactor.Enabled(u),
+		CreatedAt: u.CreatedAt.UTC(),
+	}
+	if c := u.Edges.Clan; c != nil {
+		au.Clan = &c.Number
+	}
+	if u.DisabledAt != nil {
+		t := u.DisabledAt.UTC()
+		au.DisabledAt = &t
+	}
+	return au
+}
+
+// APIClan is the JSON form of a clan.
+type APIClan struct {
+	Number int    `json:"number"`
+	Name   string `json:"name"`
+	Game   string `json:"game,omitempty"`
+	Active bool   `json:"active"`
+}
+
+func newAPIClan(c *ent.Clan) APIClan {
+	ac := APIClan{
+		Number: c.Number,
+		Name:   c.Name,
+		Active: c.Active,
+	}
+	if g := c.Edges.Game; g != nil {
+		ac.Game = g.Code
+	}
+	return ac
+}
+
+// APITurnReport is the JSON form of a turn report, without its text.
+type APITurnReport struct {
+	ID         int       `json:"id"`
+	Clan       int       `json:"clan"`
+	TurnID     string    `json:"turn_id"`
+	Filename   string    `json:"filename"`
+	SHA256     string    `json:"sha256"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+func newAPITurnReport(rpt *ent.TurnReport) APITurnReport {
+	ar := APITurnReport{
+		ID:         rpt.ID,
+		TurnID:     rpt.TurnID,
+		Filename:   rpt.OriginalFilename,
+		SHA256:     rpt.Sha256,
+		UploadedAt: rpt.UploadedAt.UTC(),
+	}
+	if c := rpt.Edges.Clan; c != nil {
+		ar.Clan = c.Number
+	}
+	return ar
+}
+
+// APIProfile is the JSON form of the caller's own user.
+type APIProfile struct {
+	APIUser
+	Scopes []string `json:"scopes"`
+}
+
+// APIError is the body of every API error response.
+type APIError struct {
+	Error string `json:"error"`
+}
+
+// APIMe returns the token owner's profile and the token's scopes.
+func APIMe() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := apiAuthorize(w, r, apitokens.ScopeMe)
+		if !ok {
+			return
+		}
+		t, _ := middleware.GetAPIToken(r.Context())
+		writeJSON(w, r, http.StatusOK, APIProfile{
+			APIUser: newAPIUser(u),
+			Scopes:  t.Scopes,
+		})
+	}
+}
+
+// APIUsers lists every user. The route is for admins only.
+func APIUsers(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		if _, ok := apiAuthorize(w, r, apitokens.ScopeUsers); !ok {
+			return
+		}
+
+		users, err := client.User.Query().WithClan().Order(ent.Asc(user.FieldUsername)).All(r.Context())
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			writeAPIError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		list := []APIUser{}
+		for _, usr := range users {
+			list = append(list, newAPIUser(usr))
+		}
+		writeJSON(w, r, http.StatusOK, list)
+	}
+}
+
+// APIClans lists clans. Referees and admins see every clan; everyone
+// else sees only their own.
+func APIClans(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := apiAuthorize(w, r, apitokens.ScopeClans)
+		if !ok {
+			return
+		}
+
+		list := []APIClan{}
+		if !actsForAllClans(u) {
+			if c := u.Edges.Clan; c != nil {
+				list = append(list, newAPIClan(c))
+			}
+			writeJSON(w, r, http.StatusOK, list)
+			return
+		}
+		clans, err := client.Clan.Query().WithGame().Order(ent.Asc(clan.FieldNumber)).All(r.Context())
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			writeAPIError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		for _, c := range clans {
+			list = append(list, newAPIClan(c))
+		}
+		writeJSON(w, r, http.StatusOK, list)
+	}
+}
+
+// APIReports lists turn reports, newest turn first, without their
+// contents. Referees and admins see every clan's reports and may filter
+// with ?clan=N; everyone else sees their own clan's. Any caller may filter
+// with ?turn=.
+func APIReports(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := apiAuthorize(w, r, apitokens.ScopeReports)
+		if !ok {
+			return
+		}
+
+		query := client.TurnReport.Query().
+			Select(turnreport.FieldTurnID, turnreport.FieldOriginalFilename, turnreport.FieldSha256, turnreport.FieldUploadedAt).
+			WithClan().
+			Order(ent.Desc(turnreport.FieldTurnID), ent.Desc(turnreport.FieldUploadedAt))
+		if actsForAllClans(u) {
+			if value := r.URL.Query().Get("clan"); value != "" {
+				number, err := strconv.Atoi(value)
+				if err != nil {
+					writeAPIError(w, http.StatusBadRequest, "invalid clan number")
+					return
+				}
+				query.Where(turnreport.HasClanWith(clan.Number(number)))
+			}
+		} else if c := u.Edges.Clan; c != nil {
+			query.Where(turnreport.HasClanWith(clan.ID(c.ID)))
+		} else {
+			writeJSON(w, r, http.StatusOK, []APITurnReport{})
+			return
+		}
+		if turnID := strings.TrimSpace(r.URL.Query().Get("turn")); turnID != "" {
+			if !report.IsTurnID(turnID) {
+				writeAPIError(w, http.StatusBadRequest, "invalid turn (expected YYYY-MM, e.g. 0901-04)")
+				return
+			}
+			query.Where(turnreport.TurnID(turnID))
+		}
+
+		reports, err := query.All(r.Context())
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			writeAPIError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		list := []APITurnReport{}
+		for _, rpt := range reports {
+			list = append(list, newAPITurnReport(rpt))
+		}
+		writeJSON(w, r, http.StatusOK, list)
+	}
+}
+
+// APIReport returns the text of a turn report. Word documents are
+// converted to text, the same way the map reads them.
+func APIReport(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := apiAuthorize(w, r, apitokens.ScopeReports)
+		if !ok {
+			return
+		}
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		query := client.TurnReport.Query().Where(turnreport.ID(id))
+		if !actsForAllClans(u) {
+			c := u.Edges.Clan
+			if c == nil {
+				writeAPIError(w, http.StatusNotFound, "not found")
+				return
+			}
+			query.Where(turnreport.HasClanWith(clan.ID(c.ID)))
+		}
+		rpt, err := query.Only(r.Context())
+		if ent.IsNotFound(err) {
+			writeAPIError(w, http.StatusNotFound, "not found")
+			return
+		} else if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			writeAPIError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		text, err := report.Text(rpt.OriginalFilename, rpt.Raw)
+		if err != nil {
+			log.Printf("%s %s: %s: %v\n", r.Method, r.URL.Path, rpt.OriginalFilename, err)
+			writeAPIError(w, http.StatusUnprocessableEntity, "report can't be read as text")
+			return
+		}
+		filename := rpt.OriginalFilename
+		if ext := filepath.Ext(filename); strings.EqualFold(ext, ".docx") {
+			filename = strings.TrimSuffix(filename, ext) + ".txt"
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(text)
+	}
+}
+
+// APINotFound answers requests for unknown API paths.
+func APINotFound() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		writeAPIError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// apiAuthorize returns the token owner if the token grants the scope.
+// Otherwise it writes an error and returns false.
+func apiAuthorize(w http.ResponseWriter, r *http.Request, scope string) (*ent.User, bool) {
+	u, ok := middleware.GetUser(r.Context())
+	if !ok {
+		writeAPIError(w, http.StatusUnauthorized, "unauthorized")
+		return nil, false
+	}
+	t, ok := middleware.GetAPIToken(r.Context())
+	if !ok {
+		writeAPIError(w, http.StatusUnauthorized, "unauthorized")
+		return nil, false
+	} else if !apitokens.HasScope(t, scope) {
+		writeAPIError(w, http.StatusForbidden, fmt.Sprintf("token does not have the %q scope", scope))
+		return nil, false
+	}
+	return u, true
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	buf, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Printf("%s %s: json %v\n", r.Method, r.URL.Path, err)
+		writeAPIError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(buf, '\n'))
+}
+
+func writeAPIError(w http.ResponseWriter, status int, msg string) {
+	buf, _ := json.Marshal(APIError{Error: msg})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(buf, '\n'))
+}
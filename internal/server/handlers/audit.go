@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mdhender/ottomat"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/internal/audit"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/views"
+)
+
+// auditLimit is the number of events shown on the audit page.
+const auditLimit = 500
+
+// recordAudit writes an audit event for a signed-in request. The actor is
+// always the user the request was authenticated as. A failure to record is
+// logged but does not fail the request.
+func recordAudit(r *http.Request, client *ent.Client, action, target, detail string) {
+	var actor string
+	if u, ok := middleware.GetUser(r.Context()); ok && u != nil {
+		actor = u.Username
+	}
+	writeAudit(r, client, audit.Event{Action: action, Actor: actor, Target: target, Detail: detail})
+}
+
+// recordSelf writes an audit event for the public routes that act on the
+// caller's own account before (or while ending) a session: logins, logouts
+// and reset links. There is no signed-in user, so the actor is the user
+// whose credentials were presented.
+func recordSelf(r *http.Request, client *ent.Client, action, username, detail string) {
+	writeAudit(r, client, audit.Event{Action: action, Actor: username, Target: username, Detail: detail})
+}
+
+func writeAudit(r *http.Request, client *ent.Client, e audit.Event) {
+	e.IP = middleware.ClientIP(r)
+	if err := audit.Record(r.Context(), client, e); err != nil {
+		log.Printf("%s %s: audit %v\n", r.Method, r.URL.Path, err)
+	}
+}
+
+type auditRow struct {
+	At     string
+	Action string
+	Actor  string
+	Target string
+	Detail string
+	IP     string
+}
+
+// AdminAudit shows the latest audit events, filtered by actor, target,
+// action, and a range of dates (YYYY-MM-DD, in UTC; the end date is
+// included).
+func AdminAudit(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		v := r.URL.Query()
+		payload := struct {
+			Actor     string
+			Target    string
+			Action    string
+			From      string
+			To        string
+			Actions   []string
+			Rows      []auditRow
+			Limit     int
+			CSRFToken string
+			Version   string
+		}{
+			Actor:     strings.TrimSpace(v.Get("actor")),
+			Target:    strings.TrimSpace(v.Get("target")),
+			Action:    v.Get("action"),
+			From:      v.Get("from"),
+			To:        v.Get("to"),
+			Actions:   audit.Actions,
+			Limit:     auditLimit,
+			CSRFToken: middleware.CSRFToken(r.Context()),
+			Version:   ottomat.Version().String(),
+		}
+
+		f := audit.Filter{Actor: payload.Actor, Target: payload.Target, Action: payload.Action, Limit: auditLimit}
+		if payload.From != "" {
+			t, err := time.Parse(time.DateOnly, payload.From)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid from date %q", payload.From), http.StatusBadRequest)
+				return
+			}
+			f.Since = t
+		}
+		if payload.To != "" {
+			t, err := time.Parse(time.DateOnly, payload.To)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid to date %q", payload.To), http.StatusBadRequest)
+				return
+			}
+			f.Until = t.AddDate(0, 0, 1)
+		}
+
+		events, err := audit.List(r.Context(), client, f)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		for _, e := range events {
+			payload.Rows = append(payload.Rows, auditRow{
+				At:     e.CreatedAt.UTC().Format("2006-01-02 15:04:05 UTC"),
+				Action: e.Action,
+				Actor:  e.Actor,
+				Target: e.Target,
+				Detail: e.Detail,
+				IP:     e.IP,
+			})
+		}
+
+		name := "pages/admin/audit"
+		buf, err := view.Execute(name, payload)
+		if err != nil {
+			log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+			http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
@@ -1,29 +1,31 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
-	"time"
 
 	"github.com/mdhender/ottomat"
 	"github.com/mdhender/ottomat/ent"
-	"github.com/mdhender/ottomat/ent/session"
 	"github.com/mdhender/ottomat/ent/user"
-	"github.com/mdhender/ottomat/internal/auth"
+	"github.com/mdhender/ottomat/internal/audit"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/sessions"
+	"github.com/mdhender/ottomat/internal/throttle"
+	"github.com/mdhender/ottomat/internal/twofactor"
 	"github.com/mdhender/ottomat/internal/views"
 	"golang.org/x/crypto/bcrypt"
 )
 
-const (
-	sessionCookieName = "ottomat_session"
-)
-
 type LoginPageData struct {
 	Title         string
 	Version       string
 	PasswordType  string
 	AvoidAutofill bool
+	Error         string
+	Remember      string
+	CSRFToken     string
 }
 
 func LoginPage(view views.Loader, avoidAutofill, visiblePasswords bool) http.HandlerFunc {
@@ -38,6 +40,16 @@ func LoginPage(view views.Loader, avoidAutofill, visiblePasswords bool) http.Han
 			Version:       ottomat.Version().String(),
 			PasswordType:  passwordType,
 			AvoidAutofill: avoidAutofill,
+			Remember:      sessions.RememberTerms(),
+			CSRFToken:     middleware.CSRFToken(r.Context()),
+		}
+		switch r.URL.Query().Get("error") {
+		case "invalid":
+			data.Error = "Invalid username or password."
+		case "throttled":
+			data.Error = "Too many failed login attempts. Please wait and try again."
+		case "disabled":
+			data.Error = "This account has been disabled."
 		}
 
 		var name string
@@ -63,95 +75,136 @@ func LoginPage(view views.Loader, avoidAutofill, visiblePasswords bool) http.Han
 	}
 }
 
+// dummyHash is a bcrypt hash, at the default cost, that no password matches.
+// Unknown usernames are checked against it so that they take as long to
+// reject as wrong passwords.
+const dummyHash = "$2a$10$FFxhiz6JoicbhS6x8krg4.XTpolTfCh83ES4mWhBhDX9lg5hQWBYC"
+
 func PostLogin(client *ent.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
 		username := r.FormValue("username")
 		password := r.FormValue("password")
-		log.Printf("%s %s: username %q: password %q\n", r.Method, r.URL.Path, username, password)
+		log.Printf("%s %s: username %q\n", r.Method, r.URL.Path, username)
 
 		ctx := r.Context()
+		ip := middleware.ClientIP(r)
+		if err := throttle.Check(ctx, client, username, ip); errors.Is(err, throttle.ErrThrottled) {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			recordSelf(r, client, audit.LoginFailed, username, "throttled")
+			http.Redirect(w, r, "/login?error=throttled", http.StatusSeeOther)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
 		u, err := client.User.
 			Query().
 			Where(user.Username(username)).
 			Only(ctx)
 		if err != nil {
 			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
-			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			_ = bcrypt.CompareHashAndPassword([]byte(dummyHash), []byte(password))
+			loginFailed(w, r, client, username, ip)
 			return
 		}
 
 		if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
 			log.Printf("%s %s: bcrypt %v\n", r.Method, r.URL.Path, err)
-			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			loginFailed(w, r, client, username, ip)
 			return
 		}
-
-		token, err := auth.GenerateSessionToken()
-		if err != nil {
-			log.Printf("%s %s: genToken %v\n", r.Method, r.URL.Path, err)
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
+		if err := throttle.Succeed(ctx, client, username); err != nil {
+			log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
 		}
-
-		_, err = client.Session.
-			Create().
-			SetToken(token).
-			SetExpiresAt(time.Now().Add(24 * time.Hour)).
-			SetUser(u).
-			Save(ctx)
-		if err != nil {
-			log.Printf("%s %s: setToken %v\n", r.Method, r.URL.Path, err)
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		if u.DisabledAt != nil {
+			log.Printf("%s %s: %q: user is disabled\n", r.Method, r.URL.Path, username)
+			recordSelf(r, client, audit.LoginFailed, username, "user is disabled")
+			http.Redirect(w, r, "/login?error=disabled", http.StatusSeeOther)
 			return
 		}
 
-		http.SetCookie(w, &http.Cookie{
-			Name:     sessionCookieName,
-			Value:    token,
-			Path:     "/",
-			Expires:  time.Now().Add(24 * time.Hour),
-			HttpOnly: true,
-			Secure:   false,
-			SameSite: http.SameSiteLaxMode,
-		})
-
-		urlDashboard := "/dashboard" // assume user
-		if u.Role == user.RoleAdmin {
-			urlDashboard = "/admin"
-		}
-		if r.Header.Get("HX-Request") == "true" {
-			// HTMX-specific header for full page redirect
-			w.Header().Add("HX-Redirect", urlDashboard) // redirect to dashboard
-			w.WriteHeader(http.StatusNoContent)         // no content to swap
+		remember := r.FormValue("remember") != ""
+		if twofactor.Enabled(u) {
+			token, expiresAt, err := twofactor.StartChallenge(ctx, client, u, remember)
+			if err != nil {
+				log.Printf("%s %s: challenge %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			twofactor.SetChallengeCookie(w, token, expiresAt)
+			loginRedirect(w, r, "/login/2fa")
 			return
 		}
-		// traditional soft redirect
-		http.Redirect(w, r, urlDashboard, http.StatusSeeOther)
+		startSession(w, r, client, u, remember)
+	}
+}
+
+// startSession logs the user in and sends them to their dashboard.
+// Disabled users are sent back to the login page instead.
+func startSession(w http.ResponseWriter, r *http.Request, client *ent.Client, u *ent.User, remember bool) {
+	if u.DisabledAt != nil {
+		log.Printf("%s %s: %q: user is disabled\n", r.Method, r.URL.Path, u.Username)
+		recordSelf(r, client, audit.LoginFailed, u.Username, "user is disabled")
+		loginRedirect(w, r, "/login?error=disabled")
+		return
+	}
+	sess, err := sessions.Create(r.Context(), client, u, remember, middleware.ClientIP(r), r.UserAgent())
+	if err != nil {
+		log.Printf("%s %s: session %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
 	}
+	sessions.SetCookie(w, sess)
+	log.Printf("%s %s: %q: signed in\n", r.Method, r.URL.Path, u.Username)
+	recordSelf(r, client, audit.Login, u.Username, r.UserAgent())
+
+	loginRedirect(w, r, dashboardPath(u))
+}
+
+// loginRedirect sends the browser to the next page of the login flow.
+func loginRedirect(w http.ResponseWriter, r *http.Request, url string) {
+	if r.Header.Get("HX-Request") == "true" {
+		// HTMX-specific header for full page redirect
+		w.Header().Add("HX-Redirect", url)  // redirect to next page
+		w.WriteHeader(http.StatusNoContent) // no content to swap
+		return
+	}
+	// traditional soft redirect
+	http.Redirect(w, r, url, http.StatusSeeOther)
+}
+
+// loginFailed records the failure and sends the user back to the login page.
+func loginFailed(w http.ResponseWriter, r *http.Request, client *ent.Client, username, ip string) {
+	log.Printf("%s %s: %q: invalid username or password\n", r.Method, r.URL.Path, username)
+	if err := throttle.Fail(r.Context(), client, username, ip); err != nil {
+		log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+	}
+	recordSelf(r, client, audit.LoginFailed, username, "invalid username or password")
+	http.Redirect(w, r, "/login?error=invalid", http.StatusSeeOther)
 }
 
 func PostLogout(client *ent.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
-		cookie, err := r.Cookie(sessionCookieName)
+		cookie, err := r.Cookie(sessions.CookieName)
 		if err == nil {
 			ctx := r.Context()
-			client.Session.
-				Delete().
-				Where(session.Token(cookie.Value)).
-				ExecX(ctx)
+			var username string
+			if sess, err := sessions.Lookup(ctx, client, cookie.Value); err == nil {
+				if u, err := sess.QueryUser().Only(ctx); err == nil {
+					username = u.Username
+				}
+			}
+			if err := sessions.Revoke(ctx, client, cookie.Value); err != nil {
+				log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			} else if username != "" {
+				recordSelf(r, client, audit.Logout, username, "")
+			}
 		}
-
-		http.SetCookie(w, &http.Cookie{
-			Name:     sessionCookieName,
-			Value:    "",
-			Path:     "/",
-			Expires:  time.Now().Add(-1 * time.Hour),
-			HttpOnly: true,
-			Secure:   false,
-			SameSite: http.SameSiteLaxMode,
-		})
+		sessions.ClearCookie(w)
 
 		if r.Header.Get("HX-Request") == "true" {
 			// HTMX-specific header for full page redirect
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/internal/views"
+)
+
+type clanRow struct {
+	ID     string
+	Number string
+	Name   string
+	Game   string
+	Active bool
+}
+
+// newClanRow expects the game edge to be loaded.
+func newClanRow(c *ent.Clan) clanRow {
+	row := clanRow{
+		ID:     fmt.Sprintf("%d", c.ID),
+		Number: fmt.Sprintf("%04d", c.Number),
+		Name:   c.Name,
+		Active: c.Active,
+	}
+	if c.Edges.Game != nil {
+		row.Game = c.Edges.Game.Code
+	}
+	return row
+}
+
+func CreateClan(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		number, err := strconv.Atoi(r.FormValue("number"))
+		if err != nil || number < 1 || number > 9999 {
+			http.Error(w, "Invalid clan number", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		exists, err := client.Clan.Query().Where(clan.Number(number)).Exist(ctx)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		} else if exists {
+			http.Error(w, fmt.Sprintf("Clan %04d already exists", number), http.StatusConflict)
+			return
+		}
+
+		create := client.Clan.
+			Create().
+			SetNumber(number).
+			SetName(strings.TrimSpace(r.FormValue("name")))
+		var g *ent.Game
+		if code := strings.TrimSpace(r.FormValue("game")); code != "" {
+			g, err = client.Game.Query().Where(game.Code(code)).Only(ctx)
+			if err != nil {
+				log.Printf("%s %s: game %q: %v\n", r.Method, r.URL.Path, code, err)
+				http.Error(w, "Unknown game", http.StatusBadRequest)
+				return
+			}
+			create.SetGame(g)
+		}
+
+		c, err := create.Save(ctx)
+		if err != nil {
+			log.Printf("%s %s: create %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Failed to create clan", http.StatusBadRequest)
+			return
+		}
+		c.Edges.Game = g
+
+		renderClanRow(w, r, view, c)
+	}
+}
+
+// UpdateClan changes the active flag on a clan. Clans are never deleted
+// because users (and, eventually, their history) reference them.
+func UpdateClan(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid ID", http.StatusBadRequest)
+			return
+		}
+		active, err := strconv.ParseBool(r.FormValue("active"))
+		if err != nil {
+			http.Error(w, "Invalid active flag", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		err = client.Clan.UpdateOneID(id).SetActive(active).Exec(ctx)
+		if ent.IsNotFound(err) {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: update %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Failed to update clan", http.StatusInternalServerError)
+			return
+		}
+		c, err := client.Clan.Query().Where(clan.ID(id)).WithGame().Only(ctx)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		renderClanRow(w, r, view, c)
+	}
+}
+
+func renderClanRow(w http.ResponseWriter, r *http.Request, view views.Loader, c *ent.Clan) {
+	name := "frags/admin/clans_table_row"
+	row := newClanRow(c)
+	buf, err := view.Execute(name, row)
+	if err != nil {
+		log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+		http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
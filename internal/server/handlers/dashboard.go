@@ -2,70 +2,105 @@ package handlers
 
 import (
 	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/mdhender/ottomat"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/turnreport"
 	"github.com/mdhender/ottomat/ent/user"
 	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/turns"
+	"github.com/mdhender/ottomat/internal/views"
 )
 
-func Dashboard(w http.ResponseWriter, r *http.Request) {
-	u, ok := middleware.GetUser(r.Context())
-	if !ok {
-		http.Redirect(w, r, "/login", http.StatusSeeOther)
-		return
+// dashboardPath returns the dashboard for the user's role.
+func dashboardPath(u *ent.User) string {
+	switch u.Role {
+	case user.RoleAdmin:
+		return "/admin"
+	case user.RoleReferee:
+		return "/referee"
 	}
+	return "/dashboard"
+}
 
-	if u.Role == user.RoleAdmin {
-		http.Redirect(w, r, "/admin", http.StatusSeeOther)
-		return
-	}
+func Dashboard(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
 
-	clanID := "N/A"
-	if u.ClanID != nil {
-		clanID = fmt.Sprintf("%d", *u.ClanID)
-	}
+		if path := dashboardPath(u); path != "/dashboard" {
+			http.Redirect(w, r, path, http.StatusSeeOther)
+			return
+		}
+
+		payload := struct {
+			Username   string
+			ClanID     string
+			Game       string
+			Turn       string
+			DueAt      string
+			CanUpload  bool
+			ReportRows []reportRow
+			CSRFToken  string
+			Version    string
+		}{
+			Username:  u.Username,
+			ClanID:    "N/A",
+			CSRFToken: middleware.CSRFToken(r.Context()),
+			Version:   ottomat.Version().String(),
+		}
 
-	html := fmt.Sprintf(`<!DOCTYPE html>
-<html lang="en">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Dashboard - OttoMat</title>
-    <script src="https://unpkg.com/htmx.org@2.0.4"></script>
-    <script src="https://unpkg.com/alpinejs@3.14.8/dist/cdn.min.js" defer></script>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-900 text-white min-h-screen flex flex-col">
-    <div class="flex-grow container mx-auto p-8">
-        <div class="bg-gray-800 p-8 rounded-lg shadow-lg">
-            <h1 class="text-3xl font-bold mb-6">Chief Dashboard</h1>
-            <div class="mb-6">
-                <p class="text-lg">Welcome, <span class="font-semibold">%s</span></p>
-                <p class="text-lg">Clan Number: <span class="font-semibold">%s</span></p>
-            </div>
-            <form hx-post="/logout" hx-swap="none">
-                <button type="submit" 
-                    class="bg-red-600 hover:bg-red-700 text-white font-medium py-2 px-4 rounded transition">
-                    Logout
-                </button>
-            </form>
-        </div>
-    </div>
-    <footer class="bg-gray-800 text-gray-400 text-sm py-4 mt-8 border-t border-gray-700">
-        <div class="container mx-auto px-8 flex justify-between items-center">
-            <div>
-                Version %s
-            </div>
-            <div class="space-x-4">
-                <a href="https://github.com/mdhender/ottomat" target="_blank" class="hover:text-white transition">GitHub</a>
-                <a href="https://discord.gg/8v2pWUs2Pg" target="_blank" class="hover:text-white transition">Discord</a>
-            </div>
-        </div>
-    </footer>
-</body>
-</html>`, u.Username, clanID, ottomat.Version().String())
+		if c := u.Edges.Clan; c != nil {
+			payload.ClanID = fmt.Sprintf("%04d", c.Number)
+			payload.CanUpload = u.Role == user.RoleChief
 
-	w.Header().Set("Content-Type", "text/html")
-	fmt.Fprint(w, html)
+			ctx := r.Context()
+			if g := c.Edges.Game; g != nil {
+				payload.Game = g.Code
+				t, err := turns.Current(ctx, g)
+				if err != nil {
+					log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+					return
+				} else if t != nil {
+					payload.Turn = t.TurnID
+					if t.DueAt != nil {
+						payload.DueAt = t.DueAt.UTC().Format("2006-01-02 15:04 UTC")
+					}
+				}
+			}
+
+			reports, err := c.QueryReports().
+				Select(turnreport.FieldTurnID, turnreport.FieldOriginalFilename, turnreport.FieldSha256, turnreport.FieldUploadedAt).
+				Order(ent.Desc(turnreport.FieldTurnID), ent.Desc(turnreport.FieldUploadedAt)).
+				All(ctx)
+			if err != nil {
+				log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			for _, rpt := range reports {
+				rpt.Edges.Clan = c
+				payload.ReportRows = append(payload.ReportRows, newReportRow(rpt))
+			}
+		}
+
+		name := "pages/dashboard"
+		buf, err := view.Execute(name, payload)
+		if err != nil {
+			log.Printf("%s %s: %s: data %+v\n", r.Method, r.URL.Path, name, payload)
+			log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+			http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	}
 }
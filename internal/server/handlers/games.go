@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/internal/turns"
+	"github.com/mdhender/ottomat/internal/views"
+)
+
+type gameRow struct {
+	ID     string
+	Code   string
+	Name   string
+	Turn   string
+	Status string
+	DueAt  string
+}
+
+func newGameRow(ctx context.Context, g *ent.Game) (gameRow, error) {
+	row := gameRow{
+		ID:     fmt.Sprintf("%d", g.ID),
+		Code:   g.Code,
+		Name:   g.Name,
+		Turn:   "N/A",
+		Status: "N/A",
+		DueAt:  "N/A",
+	}
+	t, err := turns.Current(ctx, g)
+	if err != nil {
+		return row, err
+	} else if t != nil {
+		row.Turn, row.Status = t.TurnID, t.Status.String()
+		if t.DueAt != nil {
+			row.DueAt = t.DueAt.UTC().Format("2006-01-02 15:04")
+		}
+	}
+	return row, nil
+}
+
+func CreateGame(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		code := strings.TrimSpace(r.FormValue("code"))
+		if code == "" {
+			http.Error(w, "Missing game code", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		exists, err := client.Game.Query().Where(game.Code(code)).Exist(ctx)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		} else if exists {
+			http.Error(w, fmt.Sprintf("Game %q already exists", code), http.StatusConflict)
+			return
+		}
+
+		g, err := client.Game.
+			Create().
+			SetCode(code).
+			SetName(strings.TrimSpace(r.FormValue("name"))).
+			Save(ctx)
+		if err != nil {
+			log.Printf("%s %s: create %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Failed to create game", http.StatusBadRequest)
+			return
+		}
+
+		renderGameRow(w, r, view, g)
+	}
+}
+
+// AdvanceTurn closes the current turn of a game and opens the next one.
+// For a game with no turns, the form must supply the first turn id.
+func AdvanceTurn(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid ID", http.StatusBadRequest)
+			return
+		}
+
+		dueAt, err := formDue(r)
+		if err != nil {
+			http.Error(w, "Invalid due date", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		g, err := client.Game.Get(ctx, id)
+		if ent.IsNotFound(err) {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		current, err := turns.Current(ctx, g)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		var t *ent.Turn
+		if current == nil {
+			t, err = turns.Create(ctx, client, g, strings.TrimSpace(r.FormValue("turn")), dueAt)
+		} else {
+			t, err = turns.Advance(ctx, client, g, dueAt)
+		}
+		if err != nil {
+			log.Printf("%s %s: advance %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("%s %s: game %q: advanced to turn %s\n", r.Method, r.URL.Path, g.Code, t.TurnID)
+
+		renderGameRow(w, r, view, g)
+	}
+}
+
+// SetTurnDue publishes the report due date for the current turn of a
+// game. An empty date clears it.
+func SetTurnDue(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid ID", http.StatusBadRequest)
+			return
+		}
+		dueAt, err := formDue(r)
+		if err != nil {
+			http.Error(w, "Invalid due date", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		g, err := client.Game.Get(ctx, id)
+		if ent.IsNotFound(err) {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		t, err := turns.SetDue(ctx, client, g, dueAt)
+		if err != nil {
+			log.Printf("%s %s: due %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("%s %s: game %q: turn %s: due %v\n", r.Method, r.URL.Path, g.Code, t.TurnID, t.DueAt)
+
+		renderGameRow(w, r, view, g)
+	}
+}
+
+// formDue returns the "due" form field as the last second of that day,
+// or nil if the field is empty.
+func formDue(r *http.Request) (*time.Time, error) {
+	due := r.FormValue("due")
+	if due == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.DateOnly, due)
+	if err != nil {
+		return nil, err
+	}
+	t = t.Add(24*time.Hour - time.Second)
+	return &t, nil
+}
+
+func renderGameRow(w http.ResponseWriter, r *http.Request, view views.Loader, g *ent.Game) {
+	row, err := newGameRow(r.Context(), g)
+	if err != nil {
+		log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	name := "frags/admin/games_table_row"
+	buf, err := view.Execute(name, row)
+	if err != nil {
+		log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+		http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
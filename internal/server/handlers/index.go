@@ -16,7 +16,6 @@ import (
 	"time"
 
 	"github.com/mdhender/ottomat/ent"
-	"github.com/mdhender/ottomat/ent/user"
 	"github.com/mdhender/ottomat/internal/server/middleware"
 )
 
@@ -43,14 +42,10 @@ func Index(assets fs.FS, client *ent.Client) http.HandlerFunc {
 				log.Printf("%s %s: index: redirect /login\n", r.Method, r.URL.Path)
 				http.Redirect(w, r, "/login", http.StatusSeeOther)
 				return
-			} else if u.Role == user.RoleAdmin { // redirect to admin dashboard
-				log.Printf("%s %s: index: redirect /admin\n", r.Method, r.URL.Path)
-				http.Redirect(w, r, "/admin", http.StatusSeeOther)
-				return
 			}
-			// redirect to user dashboard
-			log.Printf("%s %s: index: redirect /dashboard\n", r.Method, r.URL.Path)
-			http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+			// redirect to the dashboard for the user's role
+			log.Printf("%s %s: index: redirect %s\n", r.Method, r.URL.Path, dashboardPath(u))
+			http.Redirect(w, r, dashboardPath(u), http.StatusSeeOther)
 			return
 		}
 
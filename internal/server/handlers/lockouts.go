@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/throttle"
+)
+
+type lockoutRow struct {
+	ID         int
+	Kind       string
+	Key        string
+	Failures   int
+	LastFailed string
+	Status     string
+}
+
+func newLockoutRow(lt *ent.LoginThrottle) lockoutRow {
+	row := lockoutRow{
+		ID:         lt.ID,
+		Kind:       lt.Kind.String(),
+		Key:        lt.Key,
+		Failures:   lt.Failures,
+		LastFailed: lt.LastFailureAt.UTC().Format("2006-01-02 15:04 MST"),
+		Status:     "OK",
+	}
+	if blocked, until := throttle.Blocked(lt); blocked {
+		row.Status = "Blocked until " + until.UTC().Format("15:04 MST")
+	}
+	return row
+}
+
+// Unlock clears the failed logins for a username or IP address, removing
+// any lockout.
+func Unlock(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid ID", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		err = client.LoginThrottle.DeleteOneID(id).Exec(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			log.Printf("%s %s: delete %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Failed to unlock", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: unlocked by %q\n", r.Method, r.URL.Path, u.Username)
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/hexmap"
+	"github.com/mdhender/ottomat/internal/report"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+)
+
+// MapSVG renders the map for a clan as of the end of a turn.
+// Chiefs only see the map for their own clan. Referees and admins pick
+// the clan with the "clan" query parameter.
+func MapSVG(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		c, turnID, m, ok := loadMap(client, w, r)
+		if !ok {
+			return
+		}
+
+		buf := &bytes.Buffer{}
+		title := fmt.Sprintf("Clan %04d - Turn %s", c.Number, turnID)
+		if err := hexmap.RenderSVG(buf, m, hexmap.RenderOptions{Title: title}); err != nil {
+			log.Printf("%s %s: render %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+// MapWXX downloads the map for a clan as a Worldographer file.
+// It uses the same rules as MapSVG to decide which clan to export.
+func MapWXX(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		c, turnID, m, ok := loadMap(client, w, r)
+		if !ok {
+			return
+		}
+
+		buf := &bytes.Buffer{}
+		if err := hexmap.RenderWXX(buf, m); err != nil {
+			log.Printf("%s %s: render %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%04d.%s.wxx"`, c.Number, turnID))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+// loadMap validates the request and builds the map. If it returns false,
+// an error has already been written to the response.
+func loadMap(client *ent.Client, w http.ResponseWriter, r *http.Request) (*ent.Clan, string, *hexmap.Map, bool) {
+	u, ok := middleware.GetUser(r.Context())
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil, "", nil, false
+	}
+
+	turnID := r.PathValue("turn")
+	if !report.IsTurnID(turnID) {
+		http.Error(w, "Invalid turn", http.StatusBadRequest)
+		return nil, "", nil, false
+	}
+
+	c, status := clanFor(client, r, u, r.URL.Query().Get("clan"))
+	if c == nil {
+		http.Error(w, http.StatusText(status), status)
+		return nil, "", nil, false
+	}
+
+	m, err := hexmap.Load(r.Context(), c, turnID)
+	if err != nil {
+		log.Printf("%s %s: load %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return nil, "", nil, false
+	}
+	for _, warning := range m.Warnings {
+		log.Printf("%s %s: clan %04d: %s\n", r.Method, r.URL.Path, c.Number, warning)
+	}
+	return c, turnID, m, true
+}
+
+// clanFor returns the clan a request is for. Chiefs only get their own
+// clan; referees and admins name the clan with param. If the user may not
+// act for the clan, it returns nil and the HTTP status to respond with.
+func clanFor(client *ent.Client, r *http.Request, u *ent.User, param string) (*ent.Clan, int) {
+	if !actsForAllClans(u) {
+		own := u.Edges.Clan
+		if own == nil || u.Role != user.RoleChief {
+			return nil, http.StatusForbidden
+		} else if param != "" && param != fmt.Sprintf("%04d", own.Number) && param != strconv.Itoa(own.Number) {
+			return nil, http.StatusForbidden
+		}
+		return own, http.StatusOK
+	}
+
+	number, err := strconv.Atoi(param)
+	if err != nil {
+		return nil, http.StatusBadRequest
+	}
+	c, err := client.Clan.Query().Where(clan.Number(number)).WithGame().Only(r.Context())
+	if ent.IsNotFound(err) {
+		return nil, http.StatusNotFound
+	} else if err != nil {
+		log.Printf("%s %s: clan %v\n", r.Method, r.URL.Path, err)
+		return nil, http.StatusInternalServerError
+	}
+	return c, http.StatusOK
+}
+
+// actsForAllClans reports whether the user may see and upload for every
+// clan rather than only their own.
+func actsForAllClans(u *ent.User) bool {
+	return u.Role == user.RoleAdmin || u.Role == user.RoleReferee
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/mdhender/ottomat"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/ent/turnreport"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/turns"
+	"github.com/mdhender/ottomat/internal/views"
+)
+
+// refereeReportLimit is the number of recent reports on the dashboard.
+const refereeReportLimit = 50
+
+type refereeClanRow struct {
+	Number string
+	Name   string
+	Game   string
+	Turn   string // current turn of the clan's game, for the map links
+	Active bool
+}
+
+// RefereeDashboard shows the games with their turn deadlines, every
+// clan's map, and the latest reports from all clans, with a form for
+// uploading a report on behalf of a clan.
+func RefereeDashboard(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		payload := struct {
+			Username   string
+			GameRows   []gameRow
+			ClanRows   []refereeClanRow
+			ReportRows []reportRow
+			CSRFToken  string
+			Version    string
+		}{
+			Username:  u.Username,
+			CSRFToken: middleware.CSRFToken(r.Context()),
+			Version:   ottomat.Version().String(),
+		}
+
+		ctx := r.Context()
+		games, err := client.Game.Query().Order(ent.Asc(game.FieldCode)).All(ctx)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		currentTurn := map[int]string{}
+		for _, g := range games {
+			row, err := newGameRow(ctx, g)
+			if err != nil {
+				log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			payload.GameRows = append(payload.GameRows, row)
+			if t, err := turns.Current(ctx, g); err == nil && t != nil {
+				currentTurn[g.ID] = t.TurnID
+			}
+		}
+
+		clans, err := client.Clan.Query().WithGame().Order(ent.Asc(clan.FieldNumber)).All(ctx)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		for _, c := range clans {
+			row := refereeClanRow{
+				Number: fmt.Sprintf("%04d", c.Number),
+				Name:   c.Name,
+				Active: c.Active,
+			}
+			if g := c.Edges.Game; g != nil {
+				row.Game, row.Turn = g.Code, currentTurn[g.ID]
+			}
+			payload.ClanRows = append(payload.ClanRows, row)
+		}
+
+		reports, err := client.TurnReport.Query().
+			Select(turnreport.FieldTurnID, turnreport.FieldOriginalFilename, turnreport.FieldSha256, turnreport.FieldUploadedAt).
+			WithClan().
+			Order(ent.Desc(turnreport.FieldUploadedAt)).
+			Limit(refereeReportLimit).
+			All(ctx)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		for _, rpt := range reports {
+			payload.ReportRows = append(payload.ReportRows, newReportRow(rpt))
+		}
+
+		name := "pages/referee"
+		buf, err := view.Execute(name, payload)
+		if err != nil {
+			log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+			http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
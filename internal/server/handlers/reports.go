@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/turn"
+	"github.com/mdhender/ottomat/ent/turnreport"
+	"github.com/mdhender/ottomat/internal/report"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/views"
+)
+
+const (
+	// maxReportSize is the largest turn report file we accept.
+	maxReportSize = 4 << 20
+)
+
+type reportRow struct {
+	Clan        string
+	TurnID      string
+	Filename    string
+	SHA256      string
+	ShortSHA256 string
+	UploadedAt  string
+}
+
+// newReportRow returns the row for a report. The report's clan edge
+// must be loaded.
+func newReportRow(rpt *ent.TurnReport) reportRow {
+	row := reportRow{
+		TurnID:      rpt.TurnID,
+		Filename:    rpt.OriginalFilename,
+		SHA256:      rpt.Sha256,
+		ShortSHA256: rpt.Sha256[:12],
+		UploadedAt:  rpt.UploadedAt.UTC().Format("2006-01-02 15:04:05"),
+	}
+	if c := rpt.Edges.Clan; c != nil {
+		row.Clan = fmt.Sprintf("%04d", c.Number)
+	}
+	return row
+}
+
+// UploadReport accepts a turn report file from a chief and stores it
+// against the chief's clan. Referees and admins upload on behalf of the
+// clan named in the "clan" field. Uploading the same file twice is rejected.
+func UploadReport(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxReportSize+1<<20)
+		if err := r.ParseMultipartForm(maxReportSize); err != nil {
+			log.Printf("%s %s: parse %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Report file is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		clan, status := clanFor(client, r, u, strings.TrimSpace(r.FormValue("clan")))
+		if clan == nil {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+
+		turnID := strings.TrimSpace(r.FormValue("turn"))
+		if !report.IsTurnID(turnID) {
+			http.Error(w, "Invalid turn (expected YYYY-MM, e.g. 0901-04)", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+
+		// clans that are playing in a game may only upload for its open turns
+		if g := clan.Edges.Game; g != nil {
+			t, err := g.QueryTurns().Where(turn.TurnID(turnID)).Only(ctx)
+			if ent.IsNotFound(err) {
+				http.Error(w, fmt.Sprintf("Turn %s does not exist in game %s", turnID, g.Code), http.StatusBadRequest)
+				return
+			} else if err != nil {
+				log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			} else if t.Status != turn.StatusOpen {
+				http.Error(w, fmt.Sprintf("Turn %s is closed", turnID), http.StatusBadRequest)
+				return
+			}
+		}
+
+		file, header, err := r.FormFile("report")
+		if err != nil {
+			http.Error(w, "Missing report file", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		filename := filepath.Base(header.Filename)
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".txt", ".docx":
+		default:
+			http.Error(w, "Report must be a .txt or .docx file", http.StatusBadRequest)
+			return
+		}
+
+		raw, err := io.ReadAll(io.LimitReader(file, maxReportSize+1))
+		if err != nil {
+			log.Printf("%s %s: read %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		} else if len(raw) == 0 {
+			http.Error(w, "Report file is empty", http.StatusBadRequest)
+			return
+		} else if len(raw) > maxReportSize {
+			http.Error(w, "Report file is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		sum := sha256.Sum256(raw)
+		hash := hex.EncodeToString(sum[:])
+
+		dup, err := clan.QueryReports().Where(turnreport.Sha256(hash)).Only(ctx)
+		if err == nil {
+			http.Error(w, fmt.Sprintf("Duplicate report: already uploaded as %q for turn %s", dup.OriginalFilename, dup.TurnID), http.StatusConflict)
+			return
+		} else if !ent.IsNotFound(err) {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		rpt, err := client.TurnReport.
+			Create().
+			SetClan(clan).
+			SetTurnID(turnID).
+			SetOriginalFilename(filename).
+			SetRaw(raw).
+			SetSha256(hash).
+			Save(ctx)
+		if err != nil {
+			log.Printf("%s %s: create %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Failed to save report", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: %q: clan %04d: turn %s: saved %q (%d bytes)\n", r.Method, r.URL.Path, u.Username, clan.Number, turnID, filename, len(raw))
+		rpt.Edges.Clan = clan
+
+		name := "frags/reports/table_row"
+		buf, err := view.Execute(name, newReportRow(rpt))
+		if err != nil {
+			log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+			http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
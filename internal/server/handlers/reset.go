@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/mdhender/ottomat"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/internal/audit"
+	"github.com/mdhender/ottomat/internal/auth"
+	"github.com/mdhender/ottomat/internal/resets"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/views"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type resetPayload struct {
+	Token        string
+	Username     string
+	Invalid      bool
+	Done         bool
+	Error        string
+	PasswordType string
+	MinLength    int
+	CSRFToken    string
+	Version      string
+}
+
+// CreateResetLink generates a one-time password reset link for a user and
+// renders it for the admin to copy.
+func CreateResetLink(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid ID", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		target, err := client.User.Get(ctx, id)
+		if ent.IsNotFound(err) {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		token, expiresAt, err := resets.Create(ctx, client, target, resets.DefaultTTL)
+		if err != nil {
+			log.Printf("%s %s: create %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: %q: reset link created by %q\n", r.Method, r.URL.Path, target.Username, u.Username)
+
+		payload := struct {
+			Username  string
+			URL       string
+			ExpiresAt string
+		}{
+			Username:  target.Username,
+			URL:       fmt.Sprintf("%s://%s%s", middleware.Scheme(r), r.Host, resets.Path(token)),
+			ExpiresAt: expiresAt.UTC().Format("2006-01-02 15:04 MST"),
+		}
+		name := "frags/admin/reset_link"
+		buf, err := view.Execute(name, payload)
+		if err != nil {
+			log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+			http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+func ResetPage(client *ent.Client, view views.Loader, visiblePasswords bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, "/reset/{token}")
+		payload := newResetPayload(r, visiblePasswords)
+		u, err := resets.Lookup(r.Context(), client, payload.Token)
+		if errors.Is(err, resets.ErrInvalid) {
+			payload.Invalid = true
+			renderReset(w, r, view, payload, http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: lookup %v\n", r.Method, "/reset/{token}", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		payload.Username = u.Username
+		renderReset(w, r, view, payload, http.StatusOK)
+	}
+}
+
+// PostReset sets a new password using a reset token. The token is consumed
+// and the user is signed out everywhere.
+func PostReset(client *ent.Client, view views.Loader, visiblePasswords bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, "/reset/{token}")
+		payload := newResetPayload(r, visiblePasswords)
+
+		ctx := r.Context()
+		u, err := resets.Lookup(ctx, client, payload.Token)
+		if errors.Is(err, resets.ErrInvalid) {
+			payload.Invalid = true
+			renderReset(w, r, view, payload, http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: lookup %v\n", r.Method, "/reset/{token}", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		payload.Username = u.Username
+
+		password := r.FormValue("new_password")
+		if password != r.FormValue("confirm_password") {
+			payload.Error = "Passwords do not match."
+			renderReset(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		} else if err := auth.CheckPasswordStrength(u.Username, password); err != nil {
+			payload.Error = "New " + err.Error() + "."
+			renderReset(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			log.Printf("%s %s: hash %v\n", r.Method, "/reset/{token}", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if _, err := resets.Consume(ctx, client, payload.Token, string(hash)); errors.Is(err, resets.ErrInvalid) {
+			payload.Invalid = true
+			renderReset(w, r, view, payload, http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: consume %v\n", r.Method, "/reset/{token}", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: %q: password reset\n", r.Method, "/reset/{token}", u.Username)
+		recordSelf(r, client, audit.PasswordReset, u.Username, "reset link used")
+
+		payload.Done = true
+		renderReset(w, r, view, payload, http.StatusOK)
+	}
+}
+
+func newResetPayload(r *http.Request, visiblePasswords bool) resetPayload {
+	payload := resetPayload{
+		Token:        r.PathValue("token"),
+		CSRFToken:    middleware.CSRFToken(r.Context()),
+		PasswordType: "password",
+		MinLength:    auth.MinPasswordLength,
+		Version:      ottomat.Version().String(),
+	}
+	if visiblePasswords {
+		payload.PasswordType = "text"
+	}
+	return payload
+}
+
+func renderReset(w http.ResponseWriter, r *http.Request, view views.Loader, payload resetPayload, status int) {
+	name := "pages/reset"
+	buf, err := view.Execute(name, payload)
+	if err != nil {
+		log.Printf("%s %s: %s: render %v\n", r.Method, "/reset/{token}", name, err)
+		http.Error(w, fmt.Sprintf("%s %s: view error: %v", r.Method, name, err), http.StatusInternalServerError)
+		return
+	}
+	// keep the token out of the Referer header of outbound links
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/mdhender/ottomat"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/session"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/audit"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/sessions"
+	"github.com/mdhender/ottomat/internal/views"
+)
+
+type sessionRow struct {
+	ID        int
+	Current   bool
+	Remember  bool
+	IP        string
+	UserAgent string
+	CreatedAt string
+	LastSeen  string
+	ExpiresAt string
+	RevokeURL string
+}
+
+func newSessionRow(sess *ent.Session, current *ent.Session, revokeURL string) sessionRow {
+	row := sessionRow{
+		ID:        sess.ID,
+		Current:   current != nil && current.ID == sess.ID,
+		Remember:  sess.Remember,
+		IP:        sess.IP,
+		UserAgent: sess.UserAgent,
+		CreatedAt: sess.CreatedAt.UTC().Format("2006-01-02 15:04 MST"),
+		LastSeen:  "N/A",
+		ExpiresAt: sess.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"),
+		RevokeURL: fmt.Sprintf(revokeURL, sess.ID),
+	}
+	if sess.LastSeenAt != nil {
+		row.LastSeen = sess.LastSeenAt.UTC().Format("2006-01-02 15:04 MST")
+	}
+	if row.IP == "" {
+		row.IP = "N/A"
+	}
+	if row.UserAgent == "" {
+		row.UserAgent = "N/A"
+	}
+	return row
+}
+
+type sessionsPayload struct {
+	Username    string
+	Dashboard   string
+	Admin       bool
+	SessionRows []sessionRow
+	CSRFToken   string
+	Version     string
+}
+
+// AccountSessions lists the current user's active sessions.
+func AccountSessions(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		current, _ := middleware.GetSession(r.Context())
+
+		payload, err := newSessionsPayload(r, client, u, current, "/account/sessions/%d")
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		renderSessions(w, r, view, "pages/account/sessions", payload)
+	}
+}
+
+// RevokeAccountSession signs the current user out of one of their sessions.
+// Revoking the current session logs the user out.
+func RevokeAccountSession(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		current, _ := middleware.GetSession(r.Context())
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid ID", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		n, err := client.Session.Delete().
+			Where(session.ID(id), session.HasUserWith(user.ID(u.ID))).
+			Exec(ctx)
+		if err != nil {
+			log.Printf("%s %s: delete %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Failed to revoke session", http.StatusInternalServerError)
+			return
+		} else if n == 0 {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		log.Printf("%s %s: %q: revoked session %d\n", r.Method, r.URL.Path, u.Username, id)
+		recordAudit(r, client, audit.SessionRevoke, u.Username, fmt.Sprintf("session %d", id))
+
+		if current != nil && current.ID == id {
+			sessions.ClearCookie(w)
+			w.Header().Add("HX-Redirect", "/login")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// RevokeOtherAccountSessions signs the current user out everywhere except
+// the session making the request, then renders the remaining sessions.
+func RevokeOtherAccountSessions(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		current, ok := middleware.GetSession(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		n, err := sessions.RevokeOthers(r.Context(), client, u.ID, current.ID)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Failed to revoke sessions", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: %q: revoked %d other session(s)\n", r.Method, r.URL.Path, u.Username, n)
+		recordAudit(r, client, audit.SessionRevoke, u.Username, fmt.Sprintf("%d other session(s)", n))
+
+		payload, err := newSessionsPayload(r, client, u, current, "/account/sessions/%d")
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		renderSessions(w, r, view, "frags/sessions/table", payload)
+	}
+}
+
+// AdminUserSessions lists any user's active sessions for an admin.
+func AdminUserSessions(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		current, _ := middleware.GetSession(r.Context())
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid ID", http.StatusBadRequest)
+			return
+		}
+		target, err := client.User.Get(r.Context(), id)
+		if ent.IsNotFound(err) {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		payload, err := newSessionsPayload(r, client, target, current, "/admin/sessions/%d")
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		payload.Admin = true
+		payload.Dashboard = "/admin"
+		renderSessions(w, r, view, "pages/account/sessions", payload)
+	}
+}
+
+// AdminRevokeSession lets an admin sign any session out.
+func AdminRevokeSession(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid ID", http.StatusBadRequest)
+			return
+		}
+		ctx := r.Context()
+		sess, err := client.Session.Query().Where(session.ID(id)).WithUser().Only(ctx)
+		if ent.IsNotFound(err) {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Failed to revoke session", http.StatusInternalServerError)
+			return
+		}
+		err = client.Session.DeleteOneID(id).Exec(ctx)
+		if ent.IsNotFound(err) {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: delete %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Failed to revoke session", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: session revoked by %q\n", r.Method, r.URL.Path, u.Username)
+		recordAudit(r, client, audit.SessionRevoke, sess.Edges.User.Username, fmt.Sprintf("session %d", id))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func newSessionsPayload(r *http.Request, client *ent.Client, u *ent.User, current *ent.Session, revokeURL string) (sessionsPayload, error) {
+	payload := sessionsPayload{
+		Username:  u.Username,
+		Dashboard: dashboardPath(u),
+		CSRFToken: middleware.CSRFToken(r.Context()),
+		Version:   ottomat.Version().String(),
+	}
+	list, err := sessions.List(r.Context(), client, u.ID)
+	if err != nil {
+		return payload, err
+	}
+	for _, sess := range list {
+		payload.SessionRows = append(payload.SessionRows, newSessionRow(sess, current, revokeURL))
+	}
+	return payload, nil
+}
+
+func renderSessions(w http.ResponseWriter, r *http.Request, view views.Loader, name string, payload sessionsPayload) {
+	buf, err := view.Execute(name, payload)
+	if err != nil {
+		log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+		http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/settings"
+	"github.com/mdhender/ottomat/internal/views"
+)
+
+type settingsRow struct {
+	RequireAdmin2FA bool
+}
+
+func newSettingsRow(ctx context.Context, client *ent.Client) (settingsRow, error) {
+	var row settingsRow
+	var err error
+	row.RequireAdmin2FA, err = settings.Bool(ctx, client, settings.RequireAdmin2FA)
+	return row, err
+}
+
+// UpdateSettings changes site-wide settings. Only the settings present in
+// the form are changed.
+func UpdateSettings(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := r.Context()
+		if value := r.FormValue("require_admin_2fa"); value != "" {
+			if value != "true" && value != "false" {
+				http.Error(w, "Invalid require_admin_2fa value", http.StatusBadRequest)
+				return
+			}
+			if err := settings.SetBool(ctx, client, settings.RequireAdmin2FA, value == "true"); err != nil {
+				log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			log.Printf("%s %s: %q: require_admin_2fa set to %s\n", r.Method, r.URL.Path, u.Username, value)
+		}
+
+		row, err := newSettingsRow(ctx, client)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		name := "frags/admin/settings"
+		buf, err := view.Execute(name, row)
+		if err != nil {
+			log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+			http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mdhender/ottomat"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/apitokens"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/views"
+)
+
+// tokenExpiryDays are the lifetimes offered on the form. Zero means the
+// token never expires.
+var tokenExpiryDays = []int{30, 90, 365, 0}
+
+// scopeDescriptions are shown next to the scope checkboxes.
+var scopeDescriptions = map[string]string{
+	apitokens.ScopeMe:      "Read your profile",
+	apitokens.ScopeClans:   "Read clans",
+	apitokens.ScopeReports: "Read turn reports",
+	apitokens.ScopeUsers:   "Read all users (admins only)",
+}
+
+type tokenRow struct {
+	ID        int
+	Name      string
+	Prefix    string
+	Scopes    string
+	CreatedAt string
+	LastUsed  string
+	ExpiresAt string
+	Expired   bool
+}
+
+func newTokenRow(t *ent.APIToken, now time.Time) tokenRow {
+	row := tokenRow{
+		ID:        t.ID,
+		Name:      t.Name,
+		Prefix:    t.Prefix,
+		Scopes:    strings.Join(t.Scopes, ", "),
+		CreatedAt: t.CreatedAt.UTC().Format("2006-01-02 15:04 MST"),
+		LastUsed:  "Never",
+		ExpiresAt: "Never",
+	}
+	if t.LastUsedAt != nil {
+		row.LastUsed = t.LastUsedAt.UTC().Format("2006-01-02 15:04 MST")
+	}
+	if t.ExpiresAt != nil {
+		row.ExpiresAt = t.ExpiresAt.UTC().Format("2006-01-02 15:04 MST")
+		row.Expired = !t.ExpiresAt.After(now)
+	}
+	return row
+}
+
+type scopeOption struct {
+	Name        string
+	Description string
+}
+
+type tokensPayload struct {
+	Username   string
+	Dashboard  string
+	TokenRows  []tokenRow
+	Scopes     []scopeOption
+	ExpiryDays []int
+	NewToken   string
+	Error      string
+	CSRFToken  string
+	Version    string
+}
+
+// AccountTokens lists the current user's API tokens.
+func AccountTokens(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		payload, err := newTokensPayload(r, client, u)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		renderTokens(w, r, view, payload, http.StatusOK)
+	}
+}
+
+// CreateAccountToken creates an API token for the current user and shows
+// it once.
+func CreateAccountToken(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		name := strings.TrimSpace(r.FormValue("name"))
+		scopes := r.Form["scope"]
+		days, err := strconv.Atoi(r.FormValue("expires"))
+		var problem string
+		if name == "" {
+			problem = "Name is required."
+		} else if len(scopes) == 0 {
+			problem = "Choose at least one scope."
+		} else if err != nil || days < 0 {
+			problem = "Invalid expiration."
+		} else if u.Role != user.RoleAdmin && slices.Contains(scopes, apitokens.ScopeUsers) {
+			problem = "Only admins can use the users scope."
+		}
+		for _, scope := range scopes {
+			if problem == "" && !apitokens.ValidScope(scope) {
+				problem = fmt.Sprintf("Unknown scope %q.", scope)
+			}
+		}
+
+		ctx := r.Context()
+		var token string
+		if problem == "" {
+			var t *ent.APIToken
+			token, t, err = apitokens.Create(ctx, client, u, name, scopes, time.Duration(days)*24*time.Hour)
+			if err != nil {
+				log.Printf("%s %s: create %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			log.Printf("%s %s: %q: created api token %d (%s)\n", r.Method, r.URL.Path, u.Username, t.ID, t.Prefix)
+		}
+
+		payload, err := newTokensPayload(r, client, u)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if problem != "" {
+			payload.Error = problem
+			renderTokens(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		}
+		payload.NewToken = token
+		renderTokens(w, r, view, payload, http.StatusOK)
+	}
+}
+
+// RevokeAccountToken deletes one of the current user's API tokens.
+func RevokeAccountToken(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid ID", http.StatusBadRequest)
+			return
+		}
+
+		found, err := apitokens.Revoke(r.Context(), client, u.ID, id)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Failed to revoke token", http.StatusInternalServerError)
+			return
+		} else if !found {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		log.Printf("%s %s: %q: revoked api token %d\n", r.Method, r.URL.Path, u.Username, id)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func newTokensPayload(r *http.Request, client *ent.Client, u *ent.User) (tokensPayload, error) {
+	ctx := r.Context()
+	payload := tokensPayload{
+		Username:   u.Username,
+		Dashboard:  dashboardPath(u),
+		ExpiryDays: tokenExpiryDays,
+		CSRFToken:  middleware.CSRFToken(ctx),
+		Version:    ottomat.Version().String(),
+	}
+	for _, scope := range apitokens.Scopes {
+		if scope == apitokens.ScopeUsers && u.Role != user.RoleAdmin {
+			continue
+		}
+		payload.Scopes = append(payload.Scopes, scopeOption{Name: scope, Description: scopeDescriptions[scope]})
+	}
+	list, err := apitokens.List(ctx, client, u.ID)
+	if err != nil {
+		return payload, err
+	}
+	now := time.Now()
+	for _, t := range list {
+		payload.TokenRows = append(payload.TokenRows, newTokenRow(t, now))
+	}
+	return payload, nil
+}
+
+func renderTokens(w http.ResponseWriter, r *http.Request, view views.Loader, payload tokensPayload, status int) {
+	name := "pages/account/tokens"
+	buf, err := view.Execute(name, payload)
+	if err != nil {
+		log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+		http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+
+	"github.com/mdhender/ottomat"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/internal/audit"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/throttle"
+	"github.com/mdhender/ottomat/internal/totp"
+	"github.com/mdhender/ottomat/internal/twofactor"
+	"github.com/mdhender/ottomat/internal/views"
+)
+
+type loginTwoFactorPayload struct {
+	Error     string
+	CSRFToken string
+	Version   string
+}
+
+// LoginTwoFactorPage asks for the second factor after the password has
+// been accepted.
+func LoginTwoFactorPage(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		if _, ok := lookupChallenge(w, r, client); !ok {
+			return
+		}
+		payload := loginTwoFactorPayload{
+			CSRFToken: middleware.CSRFToken(r.Context()),
+			Version:   ottomat.Version().String(),
+		}
+		renderLoginTwoFactor(w, r, view, payload, http.StatusOK)
+	}
+}
+
+// PostLoginTwoFactor checks the second factor and completes the login.
+// Wrong codes count as failed logins, so guessing is throttled the same
+// way as guessing passwords.
+func PostLoginTwoFactor(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		ch, ok := lookupChallenge(w, r, client)
+		if !ok {
+			return
+		}
+		u := ch.Edges.User
+		payload := loginTwoFactorPayload{
+			CSRFToken: middleware.CSRFToken(r.Context()),
+			Version:   ottomat.Version().String(),
+		}
+
+		ctx := r.Context()
+		ip := middleware.ClientIP(r)
+		if err := throttle.Check(ctx, client, u.Username, ip); errors.Is(err, throttle.ErrThrottled) {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			if err := twofactor.FinishChallenge(ctx, client, ch); err != nil {
+				log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			}
+			twofactor.ClearChallengeCookie(w)
+			http.Redirect(w, r, "/login?error=throttled", http.StatusSeeOther)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		if err := twofactor.Verify(ctx, client, u, r.FormValue("code")); errors.Is(err, twofactor.ErrInvalid) {
+			log.Printf("%s %s: %q: %v\n", r.Method, r.URL.Path, u.Username, err)
+			recordSelf(r, client, audit.LoginFailed, u.Username, "invalid authentication code")
+			if err := throttle.Fail(ctx, client, u.Username, ip); err != nil {
+				log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+			}
+			payload.Error = "Invalid authentication code."
+			renderLoginTwoFactor(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: verify %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		if err := twofactor.FinishChallenge(ctx, client, ch); err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		}
+		if err := throttle.Succeed(ctx, client, u.Username); err != nil {
+			log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+		}
+		twofactor.ClearChallengeCookie(w)
+		startSession(w, r, client, u, ch.Remember)
+	}
+}
+
+// lookupChallenge returns the login challenge for the request. If there
+// isn't one, it sends the user back to the login page and returns false.
+func lookupChallenge(w http.ResponseWriter, r *http.Request, client *ent.Client) (*ent.LoginChallenge, bool) {
+	cookie, err := r.Cookie(twofactor.ChallengeCookieName)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil, false
+	}
+	ch, err := twofactor.LookupChallenge(r.Context(), client, cookie.Value)
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			log.Printf("%s %s: challenge %v\n", r.Method, r.URL.Path, err)
+		}
+		twofactor.ClearChallengeCookie(w)
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil, false
+	}
+	return ch, true
+}
+
+func renderLoginTwoFactor(w http.ResponseWriter, r *http.Request, view views.Loader, payload loginTwoFactorPayload, status int) {
+	name := "pages/login_2fa"
+	buf, err := view.Execute(name, payload)
+	if err != nil {
+		log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+		http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+type accountTwoFactorPayload struct {
+	Username       string
+	Dashboard      string
+	Enabled        bool
+	Required       bool
+	QRCode         template.HTML
+	Secret         string
+	RecoveryCodes  []string
+	RemainingCodes int
+	Error          string
+	Success        string
+	CSRFToken      string
+	Version        string
+}
+
+// AccountTwoFactorPage shows the current user's 2FA status. If 2FA is off,
+// it shows the QR code and secret for enrolling.
+func AccountTwoFactorPage(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		payload, err := newAccountTwoFactorPayload(r, client, u)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		renderAccountTwoFactor(w, r, view, payload, http.StatusOK)
+	}
+}
+
+// PostAccountTwoFactor confirms enrollment with a code from the user's
+// authenticator app and shows the recovery codes.
+func PostAccountTwoFactor(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		codes, err := twofactor.Confirm(r.Context(), client, u, r.FormValue("code"))
+		if errors.Is(err, twofactor.ErrInvalid) || errors.Is(err, twofactor.ErrEnabled) || errors.Is(err, twofactor.ErrNotEnabled) {
+			payload, perr := newAccountTwoFactorPayload(r, client, u)
+			if perr != nil {
+				log.Printf("%s %s: %v\n", r.Method, r.URL.Path, perr)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			switch {
+			case errors.Is(err, twofactor.ErrEnabled):
+				payload.Error = "Two-factor authentication is already on."
+			default:
+				payload.Error = "That code didn't match. Check the time on your device and try again."
+			}
+			renderAccountTwoFactor(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: confirm %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: %q: 2fa enabled\n", r.Method, r.URL.Path, u.Username)
+
+		u, err = client.User.Get(r.Context(), u.ID)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		payload, err := newAccountTwoFactorPayload(r, client, u)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		payload.RecoveryCodes = codes
+		payload.Success = "Two-factor authentication is on."
+		renderAccountTwoFactor(w, r, view, payload, http.StatusOK)
+	}
+}
+
+// PostAccountRecoveryCodes replaces the current user's recovery codes.
+// A current code is required so that an unattended session can't be used
+// to take over the second factor. Wrong codes count as failed logins.
+func PostAccountRecoveryCodes(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		payload, err := newAccountTwoFactorPayload(r, client, u)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		ctx := r.Context()
+		ip := middleware.ClientIP(r)
+		if err := throttle.Check(ctx, client, u.Username, ip); errors.Is(err, throttle.ErrThrottled) {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			payload.Error = "Too many failed attempts. Please wait and try again."
+			renderAccountTwoFactor(w, r, view, payload, http.StatusTooManyRequests)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if err := twofactor.Verify(ctx, client, u, r.FormValue("code")); errors.Is(err, twofactor.ErrInvalid) || errors.Is(err, twofactor.ErrNotEnabled) {
+			log.Printf("%s %s: %q: %v\n", r.Method, r.URL.Path, u.Username, err)
+			if err := throttle.Fail(ctx, client, u.Username, ip); err != nil {
+				log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+			}
+			payload.Error = "Invalid authentication code."
+			renderAccountTwoFactor(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: verify %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if err := throttle.Succeed(ctx, client, u.Username); err != nil {
+			log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+		}
+		codes, err := twofactor.RegenerateRecoveryCodes(ctx, client, u)
+		if err != nil {
+			log.Printf("%s %s: recovery codes %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: %q: recovery codes replaced\n", r.Method, r.URL.Path, u.Username)
+
+		payload.RecoveryCodes = codes
+		payload.RemainingCodes = len(codes)
+		payload.Success = "New recovery codes have been created. The old codes no longer work."
+		renderAccountTwoFactor(w, r, view, payload, http.StatusOK)
+	}
+}
+
+// PostAccountDisableTwoFactor turns off 2FA for the current user after
+// checking a current code, which is throttled like the login challenge.
+// Admins can't turn it off while the site requires it.
+func PostAccountDisableTwoFactor(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		payload, err := newAccountTwoFactorPayload(r, client, u)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		ctx := r.Context()
+		if payload.Required {
+			payload.Error = "Two-factor authentication is required for your account."
+			renderAccountTwoFactor(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		}
+		ip := middleware.ClientIP(r)
+		if err := throttle.Check(ctx, client, u.Username, ip); errors.Is(err, throttle.ErrThrottled) {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			payload.Error = "Too many failed attempts. Please wait and try again."
+			renderAccountTwoFactor(w, r, view, payload, http.StatusTooManyRequests)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if err := twofactor.Verify(ctx, client, u, r.FormValue("code")); errors.Is(err, twofactor.ErrInvalid) || errors.Is(err, twofactor.ErrNotEnabled) {
+			log.Printf("%s %s: %q: %v\n", r.Method, r.URL.Path, u.Username, err)
+			if err := throttle.Fail(ctx, client, u.Username, ip); err != nil {
+				log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+			}
+			payload.Error = "Invalid authentication code."
+			renderAccountTwoFactor(w, r, view, payload, http.StatusUnprocessableEntity)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: verify %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if err := throttle.Succeed(ctx, client, u.Username); err != nil {
+			log.Printf("%s %s: throttle %v\n", r.Method, r.URL.Path, err)
+		}
+		if err := twofactor.Reset(ctx, client, u.ID); err != nil {
+			log.Printf("%s %s: reset %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: %q: 2fa disabled\n", r.Method, r.URL.Path, u.Username)
+
+		u, err = client.User.Get(ctx, u.ID)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		payload, err = newAccountTwoFactorPayload(r, client, u)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		payload.Success = "Two-factor authentication is off."
+		renderAccountTwoFactor(w, r, view, payload, http.StatusOK)
+	}
+}
+
+// newAccountTwoFactorPayload builds the page for the user's current state.
+// If the user has not enrolled, a pending secret is created so that the QR
+// code can be shown.
+func newAccountTwoFactorPayload(r *http.Request, client *ent.Client, u *ent.User) (accountTwoFactorPayload, error) {
+	ctx := r.Context()
+	payload := accountTwoFactorPayload{
+		Username:  u.Username,
+		Dashboard: dashboardPath(u),
+		Enabled:   twofactor.Enabled(u),
+		CSRFToken: middleware.CSRFToken(ctx),
+		Version:   ottomat.Version().String(),
+	}
+	required, err := twofactor.Required(ctx, client, u)
+	if err != nil {
+		return payload, err
+	}
+	payload.Required = required
+
+	if payload.Enabled {
+		payload.RemainingCodes, err = twofactor.RemainingRecoveryCodes(ctx, client, u)
+		return payload, err
+	}
+	secret, err := twofactor.Enroll(ctx, client, u)
+	if err != nil {
+		return payload, err
+	}
+	svg, err := totp.QRCodeSVG(twofactor.URI(u, secret))
+	if err != nil {
+		return payload, err
+	}
+	// the svg is generated by us and contains no user input
+	payload.QRCode = template.HTML(svg)
+	payload.Secret = secret
+	return payload, nil
+}
+
+func renderAccountTwoFactor(w http.ResponseWriter, r *http.Request, view views.Loader, payload accountTwoFactorPayload, status int) {
+	name := "pages/account/2fa"
+	buf, err := view.Execute(name, payload)
+	if err != nil {
+		log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+		http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
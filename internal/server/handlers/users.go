@@ -0,0 +1,616 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/predicate"
+	"github.com/mdhender/ottomat/ent/session"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/audit"
+	"github.com/mdhender/ottomat/internal/auth"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/users"
+	"github.com/mdhender/ottomat/internal/views"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// userRow is a row in the admin users table. When Editing is set the row
+// is rendered as a form; Error is shown in the row after a failed change.
+type userRow struct {
+	ID             string
+	Username       string
+	Role           string
+	ClanID         string
+	UserID         string
+	DisabledAt     string
+	DisabledReason string
+	Editing        bool
+	Roles          []user.Role
+	Error          string
+}
+
+func newUserRow(u *ent.User) userRow {
+	row := userRow{
+		ID:       fmt.Sprintf("%d", u.ID),
+		Username: u.Username,
+		Role:     u.Role.String(),
+		ClanID:   "N/A",
+		UserID:   fmt.Sprintf("%d", u.ID),
+		Roles:    users.Roles,
+	}
+	if u.Edges.Clan != nil {
+		row.ClanID = fmt.Sprintf("%04d", u.Edges.Clan.Number)
+	}
+	if u.DisabledAt != nil {
+		row.DisabledAt = u.DisabledAt.UTC().Format("2006-01-02 15:04 UTC")
+		row.DisabledReason = u.DisabledReason
+	}
+	return row
+}
+
+// userPageSize is the number of users on a page of the admin users table.
+const userPageSize = 25
+
+// userSorts maps the sort parameter of the users table to the column it
+// orders by. A leading "-" sorts in descending order.
+var userSorts = map[string]func(...sql.OrderTermOption) user.OrderOption{
+	"id":       user.ByID,
+	"username": user.ByUsername,
+	"role":     user.ByRole,
+	"clan": func(opts ...sql.OrderTermOption) user.OrderOption {
+		return user.ByClanField(clan.FieldNumber, opts...)
+	},
+}
+
+// userQuery is the search, filter, sort and page of the admin users table.
+type userQuery struct {
+	Q    string
+	Role string
+	Sort string
+	Page int
+}
+
+// parseUserQuery reads the users table parameters from the request's
+// query string. Missing parameters get their defaults.
+func parseUserQuery(r *http.Request) (userQuery, error) {
+	v := r.URL.Query()
+	uq := userQuery{
+		Q:    strings.TrimSpace(v.Get("q")),
+		Role: v.Get("role"),
+		Sort: v.Get("sort"),
+		Page: 1,
+	}
+	if uq.Role != "" {
+		if err := user.RoleValidator(user.Role(uq.Role)); err != nil {
+			return uq, fmt.Errorf("invalid role %q", uq.Role)
+		}
+	}
+	if uq.Sort == "" {
+		uq.Sort = "username"
+	} else if _, ok := userSorts[strings.TrimPrefix(uq.Sort, "-")]; !ok {
+		return uq, fmt.Errorf("invalid sort %q", uq.Sort)
+	}
+	if s := v.Get("page"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return uq, fmt.Errorf("invalid page %q", s)
+		}
+		uq.Page = n
+	}
+	return uq, nil
+}
+
+// URL returns the link to the users table with these parameters. Defaults
+// are left out so that the same view always has the same URL.
+func (uq userQuery) URL() string {
+	v := url.Values{}
+	if uq.Q != "" {
+		v.Set("q", uq.Q)
+	}
+	if uq.Role != "" {
+		v.Set("role", uq.Role)
+	}
+	if uq.Sort != "username" {
+		v.Set("sort", uq.Sort)
+	}
+	if uq.Page > 1 {
+		v.Set("page", strconv.Itoa(uq.Page))
+	}
+	if len(v) == 0 {
+		return "/admin/users"
+	}
+	return "/admin/users?" + v.Encode()
+}
+
+// SortURL returns the link that sorts the table by column, reversing the
+// order if the table is already sorted by it. It goes back to the first page.
+func (uq userQuery) SortURL(column string) string {
+	sort := column
+	if uq.Sort == column {
+		sort = "-" + column
+	}
+	uq.Sort, uq.Page = sort, 1
+	return uq.URL()
+}
+
+// SortMark returns the arrow shown in the header of the sorted column.
+func (uq userQuery) SortMark(column string) string {
+	switch uq.Sort {
+	case column:
+		return "▲"
+	case "-" + column:
+		return "▼"
+	}
+	return ""
+}
+
+// userTable is one page of the admin users table.
+type userTable struct {
+	userQuery
+	Rows    []userRow
+	Roles   []user.Role
+	Total   int
+	Pages   int
+	PrevURL string
+	NextURL string
+}
+
+// newUserTable loads the page of users matching uq. Pages past the end are
+// moved back to the last page.
+func newUserTable(ctx context.Context, client *ent.Client, uq userQuery) (userTable, error) {
+	var where []predicate.User
+	if uq.Q != "" {
+		match := []predicate.User{user.UsernameContainsFold(uq.Q)}
+		if n, err := strconv.Atoi(uq.Q); err == nil {
+			match = append(match, user.HasClanWith(clan.Number(n)))
+		}
+		where = append(where, user.Or(match...))
+	}
+	if uq.Role != "" {
+		where = append(where, user.RoleEQ(user.Role(uq.Role)))
+	}
+
+	t := userTable{userQuery: uq, Roles: users.Roles}
+	total, err := client.User.Query().Where(where...).Count(ctx)
+	if err != nil {
+		return t, err
+	}
+	t.Total = total
+	t.Pages = max(1, (total+userPageSize-1)/userPageSize)
+	t.Page = min(t.Page, t.Pages)
+
+	by, desc := userSorts[strings.TrimPrefix(uq.Sort, "-")], strings.HasPrefix(uq.Sort, "-")
+	order := by()
+	if desc {
+		order = by(sql.OrderDesc())
+	}
+	list, err := client.User.Query().
+		Where(where...).
+		WithClan().
+		Order(order, user.ByID()).
+		Offset((t.Page - 1) * userPageSize).
+		Limit(userPageSize).
+		All(ctx)
+	if err != nil {
+		return t, err
+	}
+	for _, u := range list {
+		t.Rows = append(t.Rows, newUserRow(u))
+	}
+
+	if t.Page > 1 {
+		prev := t.userQuery
+		prev.Page--
+		t.PrevURL = prev.URL()
+	}
+	if t.Page < t.Pages {
+		next := t.userQuery
+		next.Page++
+		t.NextURL = next.URL()
+	}
+	return t, nil
+}
+
+// AdminUsers returns the admin users table filtered by the q, role, sort
+// and page parameters. HTMX requests get just the table; anything else,
+// such as a bookmarked URL, gets the whole dashboard with the table
+// filtered.
+func AdminUsers(client *ent.Client, view views.Loader) http.HandlerFunc {
+	dashboard := AdminDashboard(client, view)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("HX-Request") != "true" || r.Header.Get("HX-History-Restore-Request") == "true" {
+			dashboard(w, r)
+			return
+		}
+		uq, err := parseUserQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		table, err := newUserTable(r.Context(), client, uq)
+		if err != nil {
+			log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		name := "frags/admin/users_table"
+		buf, err := view.Execute(name, table)
+		if err != nil {
+			log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+			http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+// clanNumber returns the clan's number for the audit log, or "none".
+func clanNumber(c *ent.Clan) string {
+	if c == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%04d", c.Number)
+}
+
+// userDetail describes a new user for the audit log.
+func userDetail(role user.Role, c *ent.Clan) string {
+	return fmt.Sprintf("role %s, clan %s", role, clanNumber(c))
+}
+
+func renderUserRow(w http.ResponseWriter, r *http.Request, view views.Loader, row userRow, status int) {
+	name := "frags/admin/users_table_row"
+	buf, err := view.Execute(name, row)
+	if err != nil {
+		log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+		http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// pathUser loads the user named by the {id} path value, with their clan.
+// It writes the error response and returns nil if the user can't be loaded.
+func pathUser(client *ent.Client, w http.ResponseWriter, r *http.Request) *ent.User {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return nil
+	}
+	u, err := client.User.Query().Where(user.ID(id)).WithClan().Only(r.Context())
+	if ent.IsNotFound(err) {
+		http.NotFound(w, r)
+		return nil
+	} else if err != nil {
+		log.Printf("%s %s: query %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return nil
+	}
+	return u
+}
+
+// UserRow returns a user's row in the admin users table. The edit row's
+// Cancel button uses it to put the row back.
+func UserRow(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := pathUser(client, w, r)
+		if target == nil {
+			return
+		}
+		renderUserRow(w, r, view, newUserRow(target), http.StatusOK)
+	}
+}
+
+// EditUser returns a user's row in the admin users table as a form.
+func EditUser(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := pathUser(client, w, r)
+		if target == nil {
+			return
+		}
+		row := newUserRow(target)
+		row.Editing = true
+		renderUserRow(w, r, view, row, http.StatusOK)
+	}
+}
+
+// UpdateUser changes a user's role, clan and, if one is given, password.
+// An empty clan removes the user from their clan. Setting a password signs
+// the user out everywhere except the admin's own session.
+//
+// Validation errors return the edit row with the error and status 422.
+// The last admin can't be demoted.
+func UpdateUser(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sess, ok := middleware.GetSession(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		target := pathUser(client, w, r)
+		if target == nil {
+			return
+		}
+
+		role := user.Role(r.FormValue("role"))
+		clanStr := r.FormValue("clan_id")
+		password := r.FormValue("password")
+
+		// the edit row keeps what the admin entered so that it can be fixed
+		row := newUserRow(target)
+		row.Editing = true
+		row.Role = role.String()
+		row.ClanID = clanStr
+		if clanStr == "" {
+			row.ClanID = "N/A"
+		}
+		invalid := func(msg string) {
+			row.Error = msg
+			renderUserRow(w, r, view, row, http.StatusUnprocessableEntity)
+		}
+
+		if err := user.RoleValidator(role); err != nil {
+			invalid("Invalid role.")
+			return
+		}
+
+		ctx := r.Context()
+		var newClan *ent.Clan
+		if clanStr != "" {
+			number, err := strconv.Atoi(clanStr)
+			if err != nil || number < 1 || number > 9999 {
+				invalid("Clan must be a number from 1 to 9999.")
+				return
+			}
+			newClan, err = client.Clan.Query().Where(clan.Number(number), clan.Active(true)).Only(ctx)
+			if ent.IsNotFound(err) {
+				invalid(fmt.Sprintf("Clan %04d does not exist or is not active.", number))
+				return
+			} else if err != nil {
+				log.Printf("%s %s: clan %d: %v\n", r.Method, r.URL.Path, number, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		var passwordHash []byte
+		if password != "" {
+			if err := auth.CheckPasswordStrength(target.Username, password); err != nil {
+				invalid("New " + err.Error() + ".")
+				return
+			}
+			var err error
+			passwordHash, err = bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+			if err != nil {
+				log.Printf("%s %s: bcrypt %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			log.Printf("%s %s: tx %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		defer func() {
+			_ = tx.Rollback()
+		}()
+
+		if role != user.RoleAdmin {
+			if err := users.CheckLastAdmin(ctx, tx.Client(), target); errors.Is(err, users.ErrLastAdmin) {
+				invalid("The last admin can't be demoted.")
+				return
+			} else if err != nil {
+				log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		update := tx.User.UpdateOneID(target.ID).SetRole(role)
+		if newClan != nil {
+			update.SetClanID(newClan.ID)
+		} else {
+			update.ClearClan()
+		}
+		if passwordHash != nil {
+			update.SetPasswordHash(string(passwordHash))
+		}
+		updated, err := update.Save(ctx)
+		if err != nil {
+			log.Printf("%s %s: update %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if role != target.Role {
+			recordAudit(r, tx.Client(), audit.UserRole, target.Username, fmt.Sprintf("%s to %s", target.Role, role))
+		}
+		var changes []string
+		if oldClan, newClanNumber := clanNumber(target.Edges.Clan), clanNumber(newClan); oldClan != newClanNumber {
+			changes = append(changes, fmt.Sprintf("clan %s to %s", oldClan, newClanNumber))
+		}
+		if passwordHash != nil {
+			changes = append(changes, "password set")
+		}
+		if len(changes) != 0 {
+			recordAudit(r, tx.Client(), audit.UserUpdate, target.Username, strings.Join(changes, ", "))
+		}
+		if passwordHash != nil {
+			_, err = tx.Session.Delete().
+				Where(session.HasUserWith(user.ID(target.ID)), session.IDNEQ(sess.ID)).
+				Exec(ctx)
+			if err != nil {
+				log.Printf("%s %s: sessions %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			log.Printf("%s %s: commit %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: updated user %q\n", r.Method, r.URL.Path, target.Username)
+
+		updated.Edges.Clan = newClan
+		renderUserRow(w, r, view, newUserRow(updated), http.StatusOK)
+	}
+}
+
+// DisableUser stops a user from signing in and signs them out everywhere.
+// The reason is taken from the reason form value, or from the HX-Prompt
+// header when the admin is asked for it by hx-prompt. Admins can't
+// disable themselves or the last admin.
+func DisableUser(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		u, ok := middleware.GetUser(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		target := pathUser(client, w, r)
+		if target == nil {
+			return
+		}
+		reason := strings.TrimSpace(r.FormValue("reason"))
+		if reason == "" {
+			reason = strings.TrimSpace(r.Header.Get("HX-Prompt"))
+		}
+
+		row := newUserRow(target)
+		if target.ID == u.ID {
+			row.Error = "You can't disable your own account."
+			renderUserRow(w, r, view, row, http.StatusUnprocessableEntity)
+			return
+		}
+		disabled, err := users.Disable(r.Context(), client, target, reason)
+		if errors.Is(err, users.ErrLastAdmin) {
+			row.Error = "The last admin can't be disabled."
+			renderUserRow(w, r, view, row, http.StatusUnprocessableEntity)
+			return
+		} else if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: disabled user %q: %q\n", r.Method, r.URL.Path, target.Username, reason)
+		recordAudit(r, client, audit.UserDisable, target.Username, reason)
+
+		disabled.Edges.Clan = target.Edges.Clan
+		renderUserRow(w, r, view, newUserRow(disabled), http.StatusOK)
+	}
+}
+
+// EnableUser lets a disabled user sign in again.
+func EnableUser(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := pathUser(client, w, r)
+		if target == nil {
+			return
+		}
+		enabled, err := users.Enable(r.Context(), client, target)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("%s %s: enabled user %q\n", r.Method, r.URL.Path, target.Username)
+		recordAudit(r, client, audit.UserEnable, target.Username, "")
+
+		enabled.Edges.Clan = target.Edges.Clan
+		renderUserRow(w, r, view, newUserRow(enabled), http.StatusOK)
+	}
+}
+
+// maxRosterSize is the largest roster that can be uploaded.
+const maxRosterSize = 1 << 20
+
+// ImportUsers creates users from an uploaded CSV or JSON roster, the same
+// as "ottomat db import users". With dry_run set the roster is only
+// checked. The result lists the problems with the roster, or the users
+// created with their generated passwords, which are not shown again.
+func ImportUsers(client *ent.Client, view views.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxRosterSize+1<<20)
+		if err := r.ParseMultipartForm(maxRosterSize); err != nil {
+			log.Printf("%s %s: parse %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Roster file is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		file, header, err := r.FormFile("roster")
+		if err != nil {
+			http.Error(w, "Missing roster file", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		result := struct {
+			Filename string
+			DryRun   bool
+			Error    string
+			Problems []users.ImportError
+			Users    []*users.NewUser
+		}{
+			Filename: filepath.Base(header.Filename),
+			DryRun:   r.FormValue("dry_run") != "",
+		}
+		status := http.StatusOK
+
+		var entries []users.RosterEntry
+		if strings.EqualFold(filepath.Ext(result.Filename), ".json") {
+			entries, err = users.ReadRosterJSON(file)
+		} else {
+			entries, err = users.ReadRosterCSV(file)
+		}
+		ctx := r.Context()
+		if err != nil {
+			result.Error, status = err.Error(), http.StatusUnprocessableEntity
+		} else if result.Users, result.Problems, err = users.PrepareImport(ctx, client, entries); err != nil {
+			log.Printf("%s %s: import %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		} else if len(result.Problems) != 0 {
+			status = http.StatusUnprocessableEntity
+		} else if !result.DryRun {
+			if err := users.CreateUsers(ctx, client, result.Users); err != nil {
+				log.Printf("%s %s: import %v\n", r.Method, r.URL.Path, err)
+				result.Error, status = "No users were created: "+err.Error(), http.StatusUnprocessableEntity
+			} else {
+				log.Printf("%s %s: %s: created %d users\n", r.Method, r.URL.Path, result.Filename, len(result.Users))
+				for _, nu := range result.Users {
+					recordAudit(r, client, audit.UserCreate, nu.Username, fmt.Sprintf("role %s, clan %s, imported from %s", nu.Role, cmp.Or(nu.Clan, "none"), result.Filename))
+				}
+				w.Header().Set("HX-Trigger", "users-changed")
+			}
+		}
+
+		name := "frags/admin/import_result"
+		buf, err := view.Execute(name, result)
+		if err != nil {
+			log.Printf("%s %s: %s: render %v\n", r.Method, r.URL.Path, name, err)
+			http.Error(w, fmt.Sprintf("%s %s: %s: view error: %v", r.Method, r.URL.Path, name, err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(status)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
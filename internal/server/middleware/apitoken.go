@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/internal/apitokens"
+)
+
+const APITokenContextKey contextKey = "apitoken"
+
+// APIToken authenticates JSON API requests with a bearer token from the
+// Authorization header. Session cookies are not accepted. On success the
+// token and its user are added to the request context.
+func APIToken(client *ent.Client) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				apiUnauthorized(w, "missing bearer token")
+				return
+			}
+			t, err := apitokens.Authenticate(r.Context(), client, strings.TrimSpace(token))
+			if errors.Is(err, apitokens.ErrInvalid) {
+				log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+				apiUnauthorized(w, err.Error())
+				return
+			} else if err != nil {
+				log.Printf("%s %s: api token %v\n", r.Method, r.URL.Path, err)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "internal server error"})
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UserContextKey, t.Edges.User)
+			ctx = context.WithValue(ctx, APITokenContextKey, t)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetAPIToken returns the token that authenticated the request.
+func GetAPIToken(ctx context.Context) (*ent.APIToken, bool) {
+	t, ok := ctx.Value(APITokenContextKey).(*ent.APIToken)
+	return t, ok
+}
+
+func apiUnauthorized(w http.ResponseWriter, msg string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="ottomat"`)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -1,12 +1,22 @@
 package middleware
 
 import (
+	"log"
 	"net/http"
+	"strings"
 
-	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/internal/twofactor"
 )
 
-func Auth() func(http.Handler) http.Handler {
+// twoFactorPath is the enrollment page. Users who must enroll are sent
+// here and can't reach anything else until they do.
+const twoFactorPath = "/account/2fa"
+
+// Auth requires a signed-in user and sends users who must enroll in
+// two-factor authentication to the enrollment page. Which users may call
+// a route is decided by the route's Policy; see Authorize.
+func Auth(client *ent.Client) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			u, ok := GetUser(r.Context())
@@ -15,9 +25,19 @@ func Auth() func(http.Handler) http.Handler {
 				return
 			}
 
-			if r.URL.Path == "/admin" || r.URL.Path == "/admin/users" {
-				if u.Role != user.RoleAdmin {
-					http.Error(w, "Forbidden", http.StatusForbidden)
+			if !twofactor.Enabled(u) && !strings.HasPrefix(r.URL.Path, twoFactorPath) {
+				required, err := twofactor.Required(r.Context(), client, u)
+				if err != nil {
+					log.Printf("%s %s: 2fa %v\n", r.Method, r.URL.Path, err)
+					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+					return
+				} else if required {
+					if r.Header.Get("HX-Request") == "true" {
+						w.Header().Add("HX-Redirect", twoFactorPath)
+						w.WriteHeader(http.StatusNoContent)
+						return
+					}
+					http.Redirect(w, r, twoFactorPath, http.StatusSeeOther)
 					return
 				}
 			}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/user"
+)
+
+// Policy says who may call a route. Every route in the server's route
+// table declares one; the zero Policy means the route forgot to.
+type Policy struct {
+	Name   string      // shown by "ottomat routes" and in /openapi.json
+	Public bool        // anyone may call the route, signed in or not
+	Roles  []user.Role // roles allowed; nil allows every signed-in user
+}
+
+var (
+	// Public routes are open to everyone.
+	Public = Policy{Name: "public", Public: true}
+	// SignedIn routes are open to any signed-in user.
+	SignedIn = Policy{Name: "signed-in"}
+	// AdminOnly routes are open to admins.
+	AdminOnly = Roles(user.RoleAdmin)
+)
+
+// Roles returns a policy that admits signed-in users with one of the roles.
+func Roles(roles ...user.Role) Policy {
+	var names []string
+	for _, role := range roles {
+		names = append(names, role.String())
+	}
+	return Policy{Name: strings.Join(names, ","), Roles: roles}
+}
+
+// IsZero reports whether the policy was never set.
+func (p Policy) IsZero() bool {
+	return p.Name == ""
+}
+
+// Allows reports whether the user may call a route with this policy.
+// A nil user is allowed only on public routes.
+func (p Policy) Allows(u *ent.User) bool {
+	if p.Public {
+		return true
+	} else if p.IsZero() || u == nil {
+		return false
+	}
+	return p.Roles == nil || slices.Contains(p.Roles, u.Role)
+}
+
+func (p Policy) String() string {
+	return p.Name
+}
+
+// Authorize enforces a route's policy. It runs inside Session and Auth, or
+// APIToken, which put the caller in the request context. API callers get
+// a JSON error; everyone else gets a redirect to the login page or a 403.
+func Authorize(p Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if p.Public {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, isAPI := GetAPIToken(r.Context())
+			u, ok := GetUser(r.Context())
+			if !ok || u == nil {
+				if isAPI {
+					apiUnauthorized(w, "unauthorized")
+					return
+				}
+				http.Redirect(w, r, "/login", http.StatusSeeOther)
+				return
+			}
+			if !p.Allows(u) {
+				log.Printf("%s %s: %q: role %s is not allowed (%s)\n", r.Method, r.URL.Path, u.Username, u.Role, p)
+				if isAPI {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusForbidden)
+					_ = json.NewEncoder(w).Encode(map[string]string{"error": "role not allowed"})
+					return
+				}
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/user"
+)
+
+func TestPolicyAllows(t *testing.T) {
+	users := map[string]*ent.User{
+		"nobody":  nil,
+		"guest":   {Username: "guest", Role: user.RoleGuest},
+		"chief":   {Username: "chief", Role: user.RoleChief},
+		"referee": {Username: "referee", Role: user.RoleReferee},
+		"admin":   {Username: "admin", Role: user.RoleAdmin},
+	}
+	for _, tc := range []struct {
+		policy Policy
+		allows []string
+	}{
+		{Public, []string{"nobody", "guest", "chief", "referee", "admin"}},
+		{SignedIn, []string{"guest", "chief", "referee", "admin"}},
+		{AdminOnly, []string{"admin"}},
+		{Roles(user.RoleReferee, user.RoleAdmin), []string{"referee", "admin"}},
+		{Roles(user.RoleChief, user.RoleReferee, user.RoleAdmin), []string{"chief", "referee", "admin"}},
+		{Policy{}, nil},
+	} {
+		for name, u := range users {
+			want := false
+			for _, allowed := range tc.allows {
+				want = want || allowed == name
+			}
+			if got := tc.policy.Allows(u); got != want {
+				t.Errorf("policy %q: %s: got %v, want %v", tc.policy, name, got, want)
+			}
+		}
+	}
+}
+
+func TestPolicyIsZero(t *testing.T) {
+	if !(Policy{}).IsZero() {
+		t.Error("zero policy: IsZero got false")
+	}
+	for _, p := range []Policy{Public, SignedIn, AdminOnly, Roles(user.RoleChief)} {
+		if p.IsZero() {
+			t.Errorf("policy %q: IsZero got true", p)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	admin := &ent.User{Username: "admin", Role: user.RoleAdmin}
+	chief := &ent.User{Username: "chief", Role: user.RoleChief}
+	for _, tc := range []struct {
+		name   string
+		policy Policy
+		user   *ent.User
+		status int
+	}{
+		{"public, signed out", Public, nil, http.StatusOK},
+		{"admin only, signed out", AdminOnly, nil, http.StatusSeeOther},
+		{"admin only, chief", AdminOnly, chief, http.StatusForbidden},
+		{"admin only, admin", AdminOnly, admin, http.StatusOK},
+		{"signed in, chief", SignedIn, chief, http.StatusOK},
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		if tc.user != nil {
+			r = r.WithContext(context.WithValue(r.Context(), UserContextKey, tc.user))
+		}
+		w := httptest.NewRecorder()
+		Authorize(tc.policy)(ok).ServeHTTP(w, r)
+		if w.Code != tc.status {
+			t.Errorf("%s: got %d, want %d", tc.name, w.Code, tc.status)
+		}
+	}
+}
@@ -2,45 +2,57 @@ package middleware
 
 import (
 	"context"
+	"log"
+	"net"
 	"net/http"
-	"time"
+	"strings"
 
 	"github.com/mdhender/ottomat/ent"
-	"github.com/mdhender/ottomat/ent/session"
-)
-
-const (
-	sessionCookieName = "ottomat_session"
+	"github.com/mdhender/ottomat/internal/sessions"
 )
 
 type contextKey string
 
-const UserContextKey contextKey = "user"
+const (
+	UserContextKey    contextKey = "user"
+	SessionContextKey contextKey = "session"
+)
 
+// Session loads the user for the session cookie. It also records the
+// session's activity and renews the cookie when the token is replaced.
 func Session(client *ent.Client) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			cookie, err := r.Cookie(sessionCookieName)
+			cookie, err := r.Cookie(sessions.CookieName)
 			if err != nil {
 				next.ServeHTTP(w, r)
 				return
 			}
 
 			ctx := r.Context()
-			sess, err := client.Session.
-				Query().
-				Where(session.Token(cookie.Value)).
-				Where(session.ExpiresAtGT(time.Now())).
-				WithUser().
-				Only(ctx)
+			sess, err := sessions.Lookup(ctx, client, cookie.Value)
 			if err != nil {
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			user := sess.Edges.User
-			if user != nil {
+			sess, renewed, err := sessions.Touch(ctx, client, sess, ClientIP(r), r.UserAgent())
+			if err != nil {
+				log.Printf("%s %s: session %v\n", r.Method, r.URL.Path, err)
+				next.ServeHTTP(w, r)
+				return
+			} else if renewed {
+				sessions.SetCookie(w, sess)
+			}
+
+			user, err := sess.QueryUser().
+				WithClan(func(q *ent.ClanQuery) {
+					q.WithGame()
+				}).
+				Only(ctx)
+			if err == nil {
 				ctx = context.WithValue(ctx, UserContextKey, user)
+				ctx = context.WithValue(ctx, SessionContextKey, sess)
 				r = r.WithContext(ctx)
 			}
 
@@ -53,3 +65,49 @@ func GetUser(ctx context.Context) (*ent.User, bool) {
 	user, ok := ctx.Value(UserContextKey).(*ent.User)
 	return user, ok
 }
+
+// GetSession returns the session the request was authenticated with.
+func GetSession(ctx context.Context) (*ent.Session, bool) {
+	sess, ok := ctx.Value(SessionContextKey).(*ent.Session)
+	return sess, ok
+}
+
+// ClientIP returns the address of the client. Behind the local reverse proxy
+// (see tools/Caddyfile) that is the last entry the proxy appended to
+// X-Forwarded-For; the header is ignored for remote peers since anyone can
+// set it.
+func ClientIP(r *http.Request) string {
+	host, proxied := peer(r)
+	if proxied {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			parts := strings.Split(xff, ",")
+			if last := strings.TrimSpace(parts[len(parts)-1]); last != "" {
+				return last
+			}
+		}
+	}
+	return host
+}
+
+// Scheme returns the scheme the client used, "http" or "https". Like
+// ClientIP, X-Forwarded-Proto is only trusted from the local reverse proxy.
+func Scheme(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+	if _, proxied := peer(r); proxied && r.Header.Get("X-Forwarded-Proto") == "https" {
+		return "https"
+	}
+	return "http"
+}
+
+// peer returns the address of the connection and whether it came from the
+// local reverse proxy.
+func peer(r *http.Request) (string, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	return host, ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http/httptest"
+	"testing"
+)
+
+// Forwarded headers are only trusted from the local reverse proxy.
+func TestForwardedHeaders(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		remote string
+		xff    string
+		proto  string
+		tls    bool
+		ip     string
+		scheme string
+	}{
+		{"direct", "203.0.113.7:4000", "", "", false, "203.0.113.7", "http"},
+		{"direct tls", "203.0.113.7:4000", "", "", true, "203.0.113.7", "https"},
+		{"remote forged", "203.0.113.7:4000", "10.0.0.1", "https", false, "203.0.113.7", "http"},
+		{"proxy", "127.0.0.1:4000", "10.0.0.1, 198.51.100.2", "https", false, "198.51.100.2", "https"},
+		{"proxy ipv6", "[::1]:4000", "198.51.100.2", "http", false, "198.51.100.2", "http"},
+		{"proxy without headers", "127.0.0.1:4000", "", "", false, "127.0.0.1", "http"},
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = tc.remote
+		if tc.xff != "" {
+			r.Header.Set("X-Forwarded-For", tc.xff)
+		}
+		if tc.proto != "" {
+			r.Header.Set("X-Forwarded-Proto", tc.proto)
+		}
+		if tc.tls {
+			r.TLS = &tls.ConnectionState{}
+		}
+		if got := ClientIP(r); got != tc.ip {
+			t.Errorf("%s: client ip: got %q, want %q", tc.name, got, tc.ip)
+		}
+		if got := Scheme(r); got != tc.scheme {
+			t.Errorf("%s: scheme: got %q, want %q", tc.name, got, tc.scheme)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mdhender/ottomat"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/sessions"
+)
+
+// OpenAPI returns an OpenAPI 3 document describing the routes.
+func OpenAPI(table []Route) map[string]any {
+	paths := map[string]any{}
+	for _, rt := range table {
+		item, ok := paths[rt.Path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[rt.Path] = item
+		}
+		item[strings.ToLower(rt.Method)] = operation(rt)
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "OttoMat",
+			"version": ottomat.Version().String(),
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"session": map[string]any{
+					"type": "apiKey",
+					"in":   "cookie",
+					"name": sessions.CookieName,
+				},
+				"token": map[string]any{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "otm_ token from /account/tokens",
+				},
+			},
+		},
+	}
+}
+
+func operation(rt Route) map[string]any {
+	op := map[string]any{
+		"summary": rt.Summary,
+		"tags":    []string{tag(rt.Path)},
+	}
+
+	var params []any
+	for _, segment := range strings.Split(rt.Path, "/") {
+		if name, ok := strings.CutPrefix(segment, "{"); ok {
+			params = append(params, map[string]any{
+				"name":     strings.TrimSuffix(name, "}"),
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+	}
+	for _, f := range rt.Query {
+		params = append(params, map[string]any{
+			"name":        f.Name,
+			"in":          "query",
+			"required":    f.Required,
+			"description": f.Description,
+			"schema":      fieldSchema(f),
+		})
+	}
+	// the CSRF middleware checks every unsafe request outside the API.
+	if rt.Auth != AuthToken && rt.Method != http.MethodGet {
+		params = append(params, map[string]any{
+			"name":        middleware.CSRFHeaderName,
+			"in":          "header",
+			"required":    true,
+			"description": "CSRF token from the page's csrf-token meta tag (forms may send it as the " + middleware.CSRFFieldName + " field instead)",
+			"schema":      map[string]any{"type": "string"},
+		})
+	}
+	if params != nil {
+		op["parameters"] = params
+	}
+
+	if rt.Form != nil {
+		properties := map[string]any{}
+		var required []string
+		for _, f := range rt.Form {
+			schema := fieldSchema(f)
+			schema["description"] = f.Description
+			properties[f.Name] = schema
+			if f.Required {
+				required = append(required, f.Name)
+			}
+		}
+		schema := map[string]any{"type": "object", "properties": properties}
+		if required != nil {
+			schema["required"] = required
+		}
+		contentType := "application/x-www-form-urlencoded"
+		if rt.Multipart {
+			contentType = "multipart/form-data"
+		}
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{contentType: map[string]any{"schema": schema}},
+		}
+	}
+
+	response := map[string]any{"description": rt.Response.Description}
+	if rt.Response.ContentType != "" {
+		schema := map[string]any{}
+		if rt.Response.Schema != nil {
+			schema = jsonSchema(reflect.TypeOf(rt.Response.Schema))
+		} else if rt.Response.ContentType != "application/json" {
+			schema = map[string]any{"type": "string"}
+		}
+		response["content"] = map[string]any{rt.Response.ContentType: map[string]any{"schema": schema}}
+	}
+	responses := map[string]any{strconv.Itoa(rt.Response.Status): response}
+	if !rt.Policy.Public && rt.Policy.Roles != nil {
+		responses[strconv.Itoa(http.StatusForbidden)] = map[string]any{"description": "Caller's role is not " + strings.Join(strings.Split(rt.Policy.String(), ","), " or ")}
+	}
+	op["responses"] = responses
+	op["x-policy"] = rt.Policy.String()
+
+	switch rt.Auth {
+	case AuthSession:
+		op["security"] = []any{map[string]any{"session": []string{}}}
+	case AuthToken:
+		op["security"] = []any{map[string]any{"token": []string{}}}
+	case AuthOptional:
+		op["security"] = []any{map[string]any{}, map[string]any{"session": []string{}}}
+	}
+	return op
+}
+
+// tag groups operations by the first segment of their path.
+func tag(path string) string {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if segment == "" {
+		return "home"
+	}
+	return strings.TrimSuffix(segment, ".json")
+}
+
+func fieldSchema(f Field) map[string]any {
+	var schema map[string]any
+	switch f.Type {
+	case "integer", "boolean":
+		schema = map[string]any{"type": f.Type}
+	case "file":
+		schema = map[string]any{"type": "string", "format": "binary"}
+	default:
+		schema = map[string]any{"type": "string"}
+	}
+	if f.Repeated {
+		return map[string]any{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// jsonSchema describes the JSON encoding of a Go type.
+func jsonSchema(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := jsonSchema(t.Elem())
+		schema["nullable"] = true
+		return schema
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": jsonSchema(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]any{}
+		var required []string
+		addProperties(t, properties, &required)
+		schema := map[string]any{"type": "object", "properties": properties}
+		if required != nil {
+			schema["required"] = required
+		}
+		return schema
+	}
+	return map[string]any{}
+}
+
+// addProperties adds the struct's fields, following encoding/json's rules
+// for names, omitempty, and embedded structs.
+func addProperties(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			addProperties(sf.Type, properties, required)
+			continue
+		} else if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		properties[name] = jsonSchema(sf.Type)
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// serveOpenAPI returns the OpenAPI document for the route table.
+func serveOpenAPI() http.HandlerFunc {
+	document := sync.OnceValues(func() ([]byte, error) {
+		buf, err := json.MarshalIndent(OpenAPI(Routes()), "", "  ")
+		return append(buf, '\n'), err
+	})
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s: entered\n", r.Method, r.URL.Path)
+		buf, err := document()
+		if err != nil {
+			log.Printf("%s %s: json %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf)
+	}
+}
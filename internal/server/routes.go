@@ -0,0 +1,560 @@
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/server/handlers"
+	"github.com/mdhender/ottomat/internal/server/middleware"
+	"github.com/mdhender/ottomat/internal/sessions"
+	"github.com/mdhender/ottomat/internal/views"
+)
+
+// Auth says how a route authenticates its caller. New wraps each handler
+// in the middleware that matches.
+type Auth string
+
+const (
+	AuthNone     Auth = "none"     // public
+	AuthOptional Auth = "optional" // the session is loaded if the caller has one
+	AuthSession  Auth = "session"  // requires a signed-in user
+	AuthToken    Auth = "token"    // requires an API bearer token
+)
+
+// Field describes a query parameter or form field.
+type Field struct {
+	Name        string
+	Type        string // string (default), integer, boolean, or file
+	Required    bool
+	Repeated    bool // the field may be sent more than once
+	Description string
+}
+
+// Body describes the response a route sends on success.
+type Body struct {
+	Status      int
+	ContentType string // empty if there is no body
+	Schema      any    // a value of the JSON response type, for JSON bodies
+	Description string
+}
+
+// Route is a registered route and the metadata published about it in
+// /openapi.json and by "ottomat routes". Policy says who may call it and
+// is enforced by middleware.Authorize.
+type Route struct {
+	Method    string
+	Path      string
+	Summary   string
+	Auth      Auth
+	Policy    middleware.Policy
+	Query     []Field
+	Form      []Field
+	Multipart bool // the form is sent as multipart/form-data
+	Response  Body
+	Handler   http.Handler
+}
+
+// Pattern returns the ServeMux pattern for the route.
+func (rt Route) Pattern() string {
+	return rt.Method + " " + rt.Path
+}
+
+// Routes returns the route table without live dependencies. The handlers
+// must not be called; the table is only good for its metadata.
+func Routes() []Route {
+	return routes(nil, nil, nil, false, false)
+}
+
+// CheckRoutes returns an error for every route that is missing metadata.
+func CheckRoutes(table []Route) []error {
+	var errs []error
+	seen := map[string]bool{}
+	for _, rt := range table {
+		pattern := rt.Pattern()
+		if seen[pattern] {
+			errs = append(errs, fmt.Errorf("%s: registered twice", pattern))
+		}
+		seen[pattern] = true
+		switch rt.Method {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			errs = append(errs, fmt.Errorf("%s: invalid method %q", pattern, rt.Method))
+		}
+		if !strings.HasPrefix(rt.Path, "/") {
+			errs = append(errs, fmt.Errorf("%s: path must start with /", pattern))
+		}
+		if rt.Summary == "" {
+			errs = append(errs, fmt.Errorf("%s: missing summary", pattern))
+		}
+		switch rt.Auth {
+		case AuthNone, AuthOptional, AuthSession, AuthToken:
+		case "":
+			errs = append(errs, fmt.Errorf("%s: missing auth", pattern))
+		default:
+			errs = append(errs, fmt.Errorf("%s: unknown auth %q", pattern, rt.Auth))
+		}
+		if rt.Policy.IsZero() {
+			errs = append(errs, fmt.Errorf("%s: missing policy", pattern))
+		} else if rt.Policy.Public != (rt.Auth == AuthNone || rt.Auth == AuthOptional) {
+			errs = append(errs, fmt.Errorf("%s: policy %s does not match auth %s", pattern, rt.Policy, rt.Auth))
+		}
+		if rt.Response.Status == 0 {
+			errs = append(errs, fmt.Errorf("%s: missing response status", pattern))
+		}
+		if rt.Response.Description == "" {
+			errs = append(errs, fmt.Errorf("%s: missing response description", pattern))
+		}
+		if rt.Handler == nil {
+			errs = append(errs, fmt.Errorf("%s: missing handler", pattern))
+		}
+		for _, f := range append(append([]Field{}, rt.Query...), rt.Form...) {
+			if f.Name == "" || f.Description == "" {
+				errs = append(errs, fmt.Errorf("%s: field %q: missing name or description", pattern, f.Name))
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func page(description string) Body {
+	return Body{Status: http.StatusOK, ContentType: "text/html", Description: description}
+}
+
+func fragment(description string) Body {
+	return Body{Status: http.StatusOK, ContentType: "text/html", Description: description + " (HTML fragment for HTMX)"}
+}
+
+func redirect(description string) Body {
+	return Body{Status: http.StatusSeeOther, Description: description}
+}
+
+func empty(description string) Body {
+	return Body{Status: http.StatusOK, Description: description}
+}
+
+func jsonBody(schema any, description string) Body {
+	return Body{Status: http.StatusOK, ContentType: "application/json", Schema: schema, Description: description}
+}
+
+// routes is the table of every route the server handles.
+func routes(client *ent.Client, view views.Loader, assetsFS fs.FS, avoidAutofill, visiblePasswords bool) []Route {
+	code := Field{Name: "code", Required: true, Description: "TOTP code or recovery code"}
+	return []Route{
+		{
+			Method: "GET", Path: "/login", Auth: AuthNone, Policy: middleware.Public,
+			Summary: "Login page",
+			Query: []Field{
+				{Name: "error", Description: "Message to show: invalid or throttled"},
+			},
+			Response: page("Login form"),
+			Handler:  handlers.LoginPage(view, avoidAutofill, visiblePasswords),
+		},
+		{
+			Method: "POST", Path: "/login", Auth: AuthNone, Policy: middleware.Public,
+			Summary: "Check credentials and start a session",
+			Form: []Field{
+				{Name: "username", Required: true, Description: "Username"},
+				{Name: "password", Required: true, Description: "Password"},
+				{Name: "remember", Description: "Any value keeps the session for " + sessions.RememberTerms()},
+			},
+			Response: redirect("To the dashboard, to /login/2fa for the second factor, or back to /login on failure"),
+			Handler:  handlers.PostLogin(client),
+		},
+		{
+			Method: "GET", Path: "/login/2fa", Auth: AuthNone, Policy: middleware.Public,
+			Summary:  "Second login step for users with two-factor authentication",
+			Response: page("Authentication code form"),
+			Handler:  handlers.LoginTwoFactorPage(client, view),
+		},
+		{
+			Method: "POST", Path: "/login/2fa", Auth: AuthNone, Policy: middleware.Public,
+			Summary:  "Check an authentication or recovery code and finish logging in",
+			Form:     []Field{code},
+			Response: redirect("To the dashboard"),
+			Handler:  handlers.PostLoginTwoFactor(client, view),
+		},
+		{
+			Method: "POST", Path: "/logout", Auth: AuthNone, Policy: middleware.Public,
+			Summary:  "Logout and clear session",
+			Response: redirect("To /login"),
+			Handler:  handlers.PostLogout(client),
+		},
+		{
+			Method: "GET", Path: "/reset/{token}", Auth: AuthNone, Policy: middleware.Public,
+			Summary:  "Password reset form (one-time link from an admin)",
+			Response: page("New password form"),
+			Handler:  handlers.ResetPage(client, view, visiblePasswords),
+		},
+		{
+			Method: "POST", Path: "/reset/{token}", Auth: AuthNone, Policy: middleware.Public,
+			Summary: "Set a new password and consume the link",
+			Form: []Field{
+				{Name: "new_password", Required: true, Description: "New password"},
+				{Name: "confirm_password", Required: true, Description: "New password again"},
+			},
+			Response: page("Result of the reset"),
+			Handler:  handlers.PostReset(client, view, visiblePasswords),
+		},
+		{
+			Method: "GET", Path: "/openapi.json", Auth: AuthNone, Policy: middleware.Public,
+			Summary:  "This OpenAPI description",
+			Response: jsonBody(nil, "OpenAPI 3 document"),
+			Handler:  serveOpenAPI(),
+		},
+
+		{
+			Method: "GET", Path: "/account/password", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "Change password form",
+			Response: page("Change password form"),
+			Handler:  handlers.AccountPasswordPage(view, visiblePasswords),
+		},
+		{
+			Method: "POST", Path: "/account/password", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary: "Change password (requires the current password; signs out the user's other sessions)",
+			Form: []Field{
+				{Name: "current_password", Required: true, Description: "Current password"},
+				{Name: "new_password", Required: true, Description: "New password"},
+				{Name: "confirm_password", Required: true, Description: "New password again"},
+			},
+			Response: page("Result of the change; status 422 if it is invalid, or 429 after too many wrong current passwords"),
+			Handler:  handlers.PostAccountPassword(client, view, visiblePasswords),
+		},
+		{
+			Method: "GET", Path: "/account/2fa", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "Two-factor status, or a QR code for enrolling",
+			Response: page("Two-factor page"),
+			Handler:  handlers.AccountTwoFactorPage(client, view),
+		},
+		{
+			Method: "POST", Path: "/account/2fa", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "Confirm enrollment with a code and show recovery codes",
+			Form:     []Field{{Name: "code", Required: true, Description: "TOTP code"}},
+			Response: page("Two-factor page with recovery codes"),
+			Handler:  handlers.PostAccountTwoFactor(client, view),
+		},
+		{
+			Method: "POST", Path: "/account/2fa/recovery-codes", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "Replace recovery codes",
+			Form:     []Field{code},
+			Response: page("Two-factor page with recovery codes; status 422 for a wrong code, or 429 after too many"),
+			Handler:  handlers.PostAccountRecoveryCodes(client, view),
+		},
+		{
+			Method: "POST", Path: "/account/2fa/disable", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "Turn off two-factor authentication",
+			Form:     []Field{code},
+			Response: page("Two-factor page; status 422 for a wrong code, or 429 after too many"),
+			Handler:  handlers.PostAccountDisableTwoFactor(client, view),
+		},
+		{
+			Method: "GET", Path: "/account/tokens", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "List your API tokens",
+			Response: page("API tokens page"),
+			Handler:  handlers.AccountTokens(client, view),
+		},
+		{
+			Method: "POST", Path: "/account/tokens", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary: "Create an API token",
+			Form: []Field{
+				{Name: "name", Required: true, Description: "Label for the token"},
+				{Name: "scope", Required: true, Repeated: true, Description: "Scope to grant: me, clans, reports, or users"},
+				{Name: "expires", Type: "integer", Required: true, Description: "Lifetime in days; 0 never expires"},
+			},
+			Response: page("API tokens page showing the new token once"),
+			Handler:  handlers.CreateAccountToken(client, view),
+		},
+		{
+			Method: "DELETE", Path: "/account/tokens/{id}", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "Revoke an API token",
+			Response: empty("Token revoked"),
+			Handler:  handlers.RevokeAccountToken(client),
+		},
+		{
+			Method: "GET", Path: "/account/sessions", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "List your active sessions",
+			Response: page("Sessions page"),
+			Handler:  handlers.AccountSessions(client, view),
+		},
+		{
+			Method: "DELETE", Path: "/account/sessions/{id}", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "Sign out one of your sessions",
+			Response: empty("Session revoked; revoking the current session sends HX-Redirect to /login"),
+			Handler:  handlers.RevokeAccountSession(client),
+		},
+		{
+			Method: "POST", Path: "/account/sessions/revoke-others", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "Sign out everywhere else",
+			Response: fragment("Remaining sessions"),
+			Handler:  handlers.RevokeOtherAccountSessions(client, view),
+		},
+
+		{
+			Method: "GET", Path: "/admin", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Admin dashboard",
+			Response: page("Admin dashboard"),
+			Handler:  handlers.AdminDashboard(client, view),
+		},
+		{
+			Method: "GET", Path: "/admin/audit", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary: "Audit log of security and admin actions",
+			Query: []Field{
+				{Name: "actor", Description: "Only events by this username"},
+				{Name: "target", Description: "Only events on this username"},
+				{Name: "action", Description: "Only events with this action (e.g. login.failed)"},
+				{Name: "from", Description: "First day (YYYY-MM-DD, UTC)"},
+				{Name: "to", Description: "Last day (YYYY-MM-DD, UTC)"},
+			},
+			Response: page("Audit log page"),
+			Handler:  handlers.AdminAudit(client, view),
+		},
+		{
+			Method: "GET", Path: "/admin/users", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary: "Search, sort and page through users",
+			Query: []Field{
+				{Name: "q", Description: "Part of a username, or a clan number"},
+				{Name: "role", Description: "Only users with this role"},
+				{Name: "sort", Description: "id, username, role, or clan; a leading - reverses the order (default username)"},
+				{Name: "page", Type: "integer", Description: "Page number, starting at 1"},
+			},
+			Response: fragment("Users table; requests not made by HTMX get the admin dashboard with the table filtered"),
+			Handler:  handlers.AdminUsers(client, view),
+		},
+		{
+			Method: "POST", Path: "/admin/users", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary: "Create new user",
+			Form: []Field{
+				{Name: "username", Required: true, Description: "Username"},
+				{Name: "password", Required: true, Description: "Password"},
+				{Name: "role", Required: true, Description: "guest, chief, referee, or admin"},
+				{Name: "clan_id", Type: "integer", Description: "Clan number"},
+			},
+			Response: fragment("Users table row; status 422 if the password is too weak"),
+			Handler:  handlers.CreateUser(client, view),
+		},
+		{
+			Method: "POST", Path: "/admin/users/import", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary: "Create users from a CSV or JSON roster",
+			Form: []Field{
+				{Name: "roster", Type: "file", Required: true, Description: "Roster with username, role, clan and password columns; .json files are read as JSON"},
+				{Name: "dry_run", Type: "boolean", Description: "Only check the roster"},
+			},
+			Multipart: true,
+			Response:  fragment("Import result; status 422 with the problems if any row is invalid"),
+			Handler:   handlers.ImportUsers(client, view),
+		},
+		{
+			Method: "GET", Path: "/admin/users/{id}", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Get a user's row in the users table",
+			Response: fragment("Users table row"),
+			Handler:  handlers.UserRow(client, view),
+		},
+		{
+			Method: "GET", Path: "/admin/users/{id}/edit", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Get a user's row in the users table as an edit form",
+			Response: fragment("Users table edit row"),
+			Handler:  handlers.EditUser(client, view),
+		},
+		{
+			Method: "PATCH", Path: "/admin/users/{id}", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary: "Update a user's role, clan, or password",
+			Form: []Field{
+				{Name: "role", Required: true, Description: "guest, chief, referee, or admin"},
+				{Name: "clan_id", Type: "integer", Description: "Clan number; empty removes the user from their clan"},
+				{Name: "password", Description: "New password; empty keeps the current one"},
+			},
+			Response: fragment("Users table row; the edit row with an error and status 422 if the change is invalid"),
+			Handler:  handlers.UpdateUser(client, view),
+		},
+		{
+			Method: "POST", Path: "/admin/users/{id}/reset-link", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Generate a one-time password reset link",
+			Response: fragment("Reset link"),
+			Handler:  handlers.CreateResetLink(client, view),
+		},
+		{
+			Method: "GET", Path: "/admin/users/{id}/sessions", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "List a user's active sessions",
+			Response: page("Sessions page"),
+			Handler:  handlers.AdminUserSessions(client, view),
+		},
+		{
+			Method: "DELETE", Path: "/admin/sessions/{id}", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Sign out any session",
+			Response: empty("Session revoked"),
+			Handler:  handlers.AdminRevokeSession(client),
+		},
+		{
+			Method: "POST", Path: "/admin/users/{id}/disable", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Disable a user and sign them out everywhere",
+			Form:     []Field{{Name: "reason", Description: "Why the user is disabled; the HX-Prompt header is used if empty"}},
+			Response: fragment("Users table row; the row with an error and status 422 for yourself or the last admin"),
+			Handler:  handlers.DisableUser(client, view),
+		},
+		{
+			Method: "POST", Path: "/admin/users/{id}/enable", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Let a disabled user sign in again",
+			Response: fragment("Users table row"),
+			Handler:  handlers.EnableUser(client, view),
+		},
+		{
+			Method: "DELETE", Path: "/admin/users/{id}", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Permanently delete a user",
+			Query:    []Field{{Name: "confirm", Description: "The user's username; the HX-Prompt header is used if empty"}},
+			Response: empty("User deleted; the row with an error and status 422 if not confirmed, or for yourself or the last admin"),
+			Handler:  handlers.DeleteUser(client, view),
+		},
+		{
+			Method: "POST", Path: "/admin/clans", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary: "Create new clan",
+			Form: []Field{
+				{Name: "number", Type: "integer", Required: true, Description: "Clan number (1-9999)"},
+				{Name: "name", Description: "Clan name"},
+				{Name: "game", Description: "Code of the game the clan plays in"},
+			},
+			Response: fragment("Clans table row"),
+			Handler:  handlers.CreateClan(client, view),
+		},
+		{
+			Method: "PATCH", Path: "/admin/clans/{id}", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Activate or deactivate clan",
+			Form:     []Field{{Name: "active", Type: "boolean", Required: true, Description: "New state"}},
+			Response: fragment("Clans table row"),
+			Handler:  handlers.UpdateClan(client, view),
+		},
+		{
+			Method: "DELETE", Path: "/admin/lockouts/{id}", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Clear failed logins for a username or IP address",
+			Response: empty("Failures cleared"),
+			Handler:  handlers.Unlock(client),
+		},
+		{
+			Method: "PATCH", Path: "/admin/settings", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary:  "Change site settings",
+			Form:     []Field{{Name: "require_admin_2fa", Type: "boolean", Description: "Require two-factor authentication for admins"}},
+			Response: fragment("Settings panel"),
+			Handler:  handlers.UpdateSettings(client, view),
+		},
+		{
+			Method: "POST", Path: "/admin/games", Auth: AuthSession, Policy: middleware.AdminOnly,
+			Summary: "Create new game",
+			Form: []Field{
+				{Name: "code", Required: true, Description: "Game code"},
+				{Name: "name", Description: "Game name"},
+			},
+			Response: fragment("Games table row"),
+			Handler:  handlers.CreateGame(client, view),
+		},
+		{
+			Method: "POST", Path: "/admin/games/{id}/advance", Auth: AuthSession, Policy: middleware.Roles(user.RoleAdmin, user.RoleReferee),
+			Summary: "Close the current turn and open the next one",
+			Form: []Field{
+				{Name: "turn", Description: "First turn id, for a game with no turns (e.g. 0901-04)"},
+				{Name: "due", Description: "Report due date for the new turn (YYYY-MM-DD)"},
+			},
+			Response: fragment("Games table row"),
+			Handler:  handlers.AdvanceTurn(client, view),
+		},
+		{
+			Method: "PATCH", Path: "/admin/games/{id}/due", Auth: AuthSession, Policy: middleware.Roles(user.RoleAdmin, user.RoleReferee),
+			Summary:  "Publish the report due date for the current turn",
+			Form:     []Field{{Name: "due", Description: "Report due date (YYYY-MM-DD); empty clears it"}},
+			Response: fragment("Games table row"),
+			Handler:  handlers.SetTurnDue(client, view),
+		},
+
+		{
+			Method: "GET", Path: "/dashboard", Auth: AuthSession, Policy: middleware.SignedIn,
+			Summary:  "Chief dashboard",
+			Response: page("Dashboard"),
+			Handler:  handlers.Dashboard(client, view),
+		},
+		{
+			Method: "GET", Path: "/referee", Auth: AuthSession, Policy: middleware.Roles(user.RoleReferee, user.RoleAdmin),
+			Summary:  "Referee dashboard",
+			Response: page("Referee dashboard"),
+			Handler:  handlers.RefereeDashboard(client, view),
+		},
+		{
+			Method: "POST", Path: "/reports", Auth: AuthSession, Policy: middleware.Roles(user.RoleChief, user.RoleReferee, user.RoleAdmin),
+			Summary: "Upload a turn report",
+			Form: []Field{
+				{Name: "clan", Type: "integer", Description: "Clan number (referees and admins upload on behalf of a clan)"},
+				{Name: "turn", Required: true, Description: "Turn id (e.g. 0901-04)"},
+				{Name: "report", Type: "file", Required: true, Description: "Turn report text file"},
+			},
+			Multipart: true,
+			Response:  Body{Status: http.StatusCreated, ContentType: "text/html", Description: "Reports table row (HTML fragment for HTMX)"},
+			Handler:   handlers.UploadReport(client, view),
+		},
+		{
+			Method: "GET", Path: "/maps/{turn}", Auth: AuthSession, Policy: middleware.Roles(user.RoleChief, user.RoleReferee, user.RoleAdmin),
+			Summary:  "SVG map of the chief's clan as of the end of the turn",
+			Query:    []Field{{Name: "clan", Type: "integer", Description: "Clan number (required for referees and admins)"}},
+			Response: Body{Status: http.StatusOK, ContentType: "image/svg+xml", Description: "Map"},
+			Handler:  handlers.MapSVG(client),
+		},
+		{
+			Method: "GET", Path: "/maps/{turn}/wxx", Auth: AuthSession, Policy: middleware.Roles(user.RoleChief, user.RoleReferee, user.RoleAdmin),
+			Summary:  "Same map as a Worldographer download",
+			Query:    []Field{{Name: "clan", Type: "integer", Description: "Clan number (required for referees and admins)"}},
+			Response: Body{Status: http.StatusOK, ContentType: "application/octet-stream", Description: "Worldographer file"},
+			Handler:  handlers.MapWXX(client),
+		},
+
+		{
+			Method: "GET", Path: "/api/v1/me", Auth: AuthToken, Policy: middleware.SignedIn,
+			Summary:  "The token's owner and scopes (scope me)",
+			Response: jsonBody(handlers.APIProfile{}, "Profile"),
+			Handler:  handlers.APIMe(),
+		},
+		{
+			Method: "GET", Path: "/api/v1/users", Auth: AuthToken, Policy: middleware.AdminOnly,
+			Summary:  "All users (scope users, admins only)",
+			Response: jsonBody([]handlers.APIUser{}, "Users"),
+			Handler:  handlers.APIUsers(client),
+		},
+		{
+			Method: "GET", Path: "/api/v1/clans", Auth: AuthToken, Policy: middleware.SignedIn,
+			Summary:  "All clans for referees and admins, otherwise the caller's clan (scope clans)",
+			Response: jsonBody([]handlers.APIClan{}, "Clans"),
+			Handler:  handlers.APIClans(client),
+		},
+		{
+			Method: "GET", Path: "/api/v1/reports", Auth: AuthToken, Policy: middleware.SignedIn,
+			Summary: "Turn report metadata, newest turn first (scope reports)",
+			Query: []Field{
+				{Name: "clan", Type: "integer", Description: "Clan number (referees and admins only)"},
+				{Name: "turn", Description: "Turn id (e.g. 0901-04)"},
+			},
+			Response: jsonBody([]handlers.APITurnReport{}, "Turn reports"),
+			Handler:  handlers.APIReports(client),
+		},
+		{
+			Method: "GET", Path: "/api/v1/reports/{id}", Auth: AuthToken, Policy: middleware.SignedIn,
+			Summary:  "The report text; Word documents are converted to text (scope reports)",
+			Response: Body{Status: http.StatusOK, ContentType: "text/plain", Description: "Report text"},
+			Handler:  handlers.APIReport(client),
+		},
+		{
+			Method: "GET", Path: "/api/", Auth: AuthNone, Policy: middleware.Public,
+			Summary:  "Unknown API paths",
+			Response: Body{Status: http.StatusNotFound, ContentType: "application/json", Schema: handlers.APIError{}, Description: "Not found"},
+			Handler:  handlers.APINotFound(),
+		},
+
+		// home page and assets. per the Go blog, "As a special case, GET also matches HEAD."
+		{
+			Method: "GET", Path: "/", Auth: AuthOptional, Policy: middleware.Public,
+			Summary:  "Redirect to the caller's dashboard (or /login), or serve a static asset",
+			Response: redirect("To the dashboard or /login; other paths return the asset"),
+			Handler:  handlers.Index(assetsFS, client),
+		},
+	}
+}
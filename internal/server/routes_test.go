@@ -0,0 +1,85 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mdhender/ottomat/internal/server/middleware"
+)
+
+func TestRoutesHaveMetadata(t *testing.T) {
+	table := Routes()
+	if len(table) == 0 {
+		t.Fatal("route table is empty")
+	}
+	for _, err := range CheckRoutes(table) {
+		t.Error(err)
+	}
+}
+
+func TestCheckRoutesReportsMissingMetadata(t *testing.T) {
+	valid := Route{
+		Method: "GET", Path: "/widgets", Auth: AuthSession, Policy: middleware.SignedIn,
+		Summary:  "List widgets",
+		Query:    []Field{{Name: "q", Description: "Search text"}},
+		Response: page("Widgets"),
+		Handler:  http.NotFoundHandler(),
+	}
+	if errs := CheckRoutes([]Route{valid}); errs != nil {
+		t.Fatalf("valid route: got %v", errs)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		edit  func(rt *Route)
+		error string
+	}{
+		{"summary", func(rt *Route) { rt.Summary = "" }, "missing summary"},
+		{"response", func(rt *Route) { rt.Response = Body{} }, "missing response status"},
+		{"response description", func(rt *Route) { rt.Response.Description = "" }, "missing response description"},
+		{"auth", func(rt *Route) { rt.Auth = "" }, "missing auth"},
+		{"handler", func(rt *Route) { rt.Handler = nil }, "missing handler"},
+		{"field description", func(rt *Route) { rt.Query = []Field{{Name: "q"}} }, `field "q"`},
+		{"method", func(rt *Route) { rt.Method = "FETCH" }, "invalid method"},
+		{"path", func(rt *Route) { rt.Path = "widgets" }, "path must start with /"},
+		{"policy", func(rt *Route) { rt.Policy = middleware.Policy{} }, "missing policy"},
+		{"public policy", func(rt *Route) { rt.Policy = middleware.Public }, "does not match auth"},
+		{"signed-in policy", func(rt *Route) { rt.Auth = AuthNone }, "does not match auth"},
+	} {
+		rt := valid
+		tc.edit(&rt)
+		errs := CheckRoutes([]Route{valid, rt})
+		found := false
+		for _, err := range errs {
+			if strings.HasPrefix(err.Error(), rt.Pattern()+": ") && strings.Contains(err.Error(), tc.error) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: got %v, want an error for %s containing %q", tc.name, errs, rt.Pattern(), tc.error)
+		}
+	}
+
+	if errs := CheckRoutes([]Route{valid, valid}); len(errs) != 1 || !strings.Contains(errs[0].Error(), "registered twice") {
+		t.Errorf("duplicate: got %v, want one registered twice error", errs)
+	}
+}
+
+// Every route must say who may call it, and routes that don't require a
+// signed-in caller must be the public ones.
+func TestRoutesHavePolicies(t *testing.T) {
+	for _, rt := range Routes() {
+		if rt.Policy.IsZero() {
+			t.Errorf("%s: missing policy", rt.Pattern())
+			continue
+		}
+		open := rt.Auth == AuthNone || rt.Auth == AuthOptional
+		if rt.Policy.Public != open {
+			t.Errorf("%s: policy %s does not match auth %s", rt.Pattern(), rt.Policy, rt.Auth)
+		}
+		if strings.HasPrefix(rt.Path, "/admin/") && rt.Policy.Public {
+			t.Errorf("%s: admin route is public", rt.Pattern())
+		}
+	}
+}
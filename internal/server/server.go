@@ -6,7 +6,6 @@ import (
 	"net/http"
 
 	"github.com/mdhender/ottomat/ent"
-	"github.com/mdhender/ottomat/internal/server/handlers"
 	"github.com/mdhender/ottomat/internal/server/middleware"
 	"github.com/mdhender/ottomat/internal/views"
 )
@@ -33,23 +32,32 @@ func New(addr string, client *ent.Client, devMode bool, avoidAutofill, visiblePa
 		log.Fatalf("server: views: load failed\n")
 	}
 	sessionMW := middleware.Session(client)
-	authMW := middleware.Auth()
+	authMW := middleware.Auth(client)
+	apiMW := middleware.APIToken(client)
 
-	mux := http.NewServeMux()
-
-	mux.HandleFunc("GET /login", handlers.LoginPage(s.viewLoader, avoidAutofill, visiblePasswords))
-	mux.HandleFunc("POST /login", handlers.PostLogin(client))
-	mux.HandleFunc("POST /logout", handlers.PostLogout(client))
-
-	mux.Handle("GET /admin", sessionMW(authMW(handlers.AdminDashboard(client, s.viewLoader))))
-	mux.Handle("POST /admin/users", sessionMW(authMW(handlers.CreateUser(client))))
-	mux.Handle("DELETE /admin/users/{id}", sessionMW(authMW(handlers.DeleteUser(client))))
-	mux.Handle("GET /dashboard", sessionMW(authMW(http.HandlerFunc(handlers.Dashboard))))
+	table := routes(client, s.viewLoader, assetsFS, avoidAutofill, visiblePasswords)
+	if errs := CheckRoutes(table); errs != nil {
+		for _, err := range errs {
+			log.Printf("server: routes: %v\n", err)
+		}
+		log.Fatalf("server: routes: metadata is incomplete\n")
+	}
 
-	// home page and assets. per the Go blog, "As a special case, GET also matches HEAD."
-	mux.Handle("GET /", sessionMW(handlers.Index(assetsFS, client)))
+	mux := http.NewServeMux()
+	for _, rt := range table {
+		h := middleware.Authorize(rt.Policy)(rt.Handler)
+		switch rt.Auth {
+		case AuthOptional:
+			h = sessionMW(h)
+		case AuthSession:
+			h = sessionMW(authMW(h))
+		case AuthToken:
+			h = apiMW(h)
+		}
+		mux.Handle(rt.Pattern(), h)
+	}
 
-	s.Handler = mux
+	s.Handler = middleware.CSRF(client)(mux)
 
 	// Wrap with logging middleware if in development mode
 	if devMode {
@@ -0,0 +1,258 @@
+// Package sessions implements the login session policy.
+//
+// A session has an absolute lifetime that is fixed when it is created and an
+// idle timeout that slides forward whenever the session is used. The token
+// in the cookie is replaced once it is past half of the idle timeout, and the
+// replaced token keeps working for a short grace period so that requests
+// already in flight are not logged out.
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/predicate"
+	"github.com/mdhender/ottomat/ent/session"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/auth"
+)
+
+// CookieName is the name of the session cookie.
+const CookieName = "ottomat_session"
+
+const (
+	// Lifetime is the absolute lifetime of a standard session.
+	Lifetime = 7 * 24 * time.Hour
+	// IdleTimeout ends a standard session that has not been used.
+	IdleTimeout = 24 * time.Hour
+	// RememberLifetime is the absolute lifetime of a "remember me" session.
+	RememberLifetime = 90 * 24 * time.Hour
+	// RememberIdleTimeout ends a "remember me" session that has not been used.
+	RememberIdleTimeout = 30 * 24 * time.Hour
+	// SeenInterval limits how often last_seen_at is written.
+	SeenInterval = time.Minute
+	// RenewGrace is how long a replaced token is still accepted.
+	RenewGrace = time.Minute
+)
+
+// RememberTerms describes how long a "remember me" session lasts, for the
+// login form and the route metadata.
+func RememberTerms() string {
+	const day = 24 * time.Hour
+	return fmt.Sprintf("up to %d days (signed out after %d days without use)", RememberLifetime/day, RememberIdleTimeout/day)
+}
+
+// Create starts a new session for the user.
+func Create(ctx context.Context, client *ent.Client, u *ent.User, remember bool, ip, userAgent string) (*ent.Session, error) {
+	token, err := auth.GenerateSessionToken()
+	if err != nil {
+		return nil, err
+	}
+	csrfToken, err := auth.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+	lifetime := Lifetime
+	if remember {
+		lifetime = RememberLifetime
+	}
+	now := time.Now()
+	sess, err := client.Session.
+		Create().
+		SetToken(token).
+		SetCsrfToken(csrfToken).
+		SetExpiresAt(now.Add(lifetime)).
+		SetLastSeenAt(now).
+		SetIP(ip).
+		SetUserAgent(userAgent).
+		SetRemember(remember).
+		SetUser(u).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return sess, nil
+}
+
+// Lookup returns the live session for a cookie token. It returns an error
+// satisfying ent.IsNotFound if the token is unknown, expired, or idle, or
+// if its user is disabled.
+func Lookup(ctx context.Context, client *ent.Client, token string) (*ent.Session, error) {
+	now := time.Now()
+	sess, err := client.Session.
+		Query().
+		Where(
+			session.Or(
+				session.Token(token),
+				session.And(session.PreviousToken(token), session.RenewedAtGT(now.Add(-RenewGrace))),
+			),
+			session.ExpiresAtGT(now),
+			session.HasUserWith(user.DisabledAtIsNil()),
+		).
+		Only(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if Idle(sess, now) {
+		return nil, &ent.NotFoundError{}
+	}
+	return sess, nil
+}
+
+// Idle reports whether the session has been unused longer than its idle
+// timeout.
+func Idle(sess *ent.Session, now time.Time) bool {
+	lastSeen := sess.CreatedAt
+	if sess.LastSeenAt != nil {
+		lastSeen = *sess.LastSeenAt
+	}
+	return now.Sub(lastSeen) > idleTimeout(sess)
+}
+
+// Touch records that the session was used and renews its token when it is
+// past half-life. It returns the session as updated; if the token changed
+// the caller must send a new cookie.
+func Touch(ctx context.Context, client *ent.Client, sess *ent.Session, ip, userAgent string) (*ent.Session, bool, error) {
+	now := time.Now()
+	issued := sess.CreatedAt
+	if sess.RenewedAt != nil {
+		issued = *sess.RenewedAt
+	}
+	renew := now.Sub(issued) > idleTimeout(sess)/2
+	seen := sess.LastSeenAt == nil || now.Sub(*sess.LastSeenAt) > SeenInterval ||
+		sess.IP != ip || sess.UserAgent != userAgent
+	if !renew && !seen {
+		return sess, false, nil
+	}
+
+	update := client.Session.UpdateOne(sess).
+		SetLastSeenAt(now).
+		SetIP(ip).
+		SetUserAgent(userAgent)
+	if renew {
+		token, err := auth.GenerateSessionToken()
+		if err != nil {
+			return nil, false, err
+		}
+		update.SetToken(token).
+			SetPreviousToken(sess.Token).
+			SetRenewedAt(now)
+	}
+	sess, err := update.Save(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to update session: %w", err)
+	}
+	return sess, renew, nil
+}
+
+// List returns the user's live sessions, most recently used first.
+func List(ctx context.Context, client *ent.Client, userID int) ([]*ent.Session, error) {
+	now := time.Now()
+	all, err := client.Session.
+		Query().
+		Where(session.HasUserWith(user.ID(userID)), session.ExpiresAtGT(now)).
+		Order(ent.Desc(session.FieldLastSeenAt), ent.Desc(session.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	var list []*ent.Session
+	for _, sess := range all {
+		if !Idle(sess, now) {
+			list = append(list, sess)
+		}
+	}
+	return list, nil
+}
+
+// RevokeOthers deletes every session of the user except keepID and returns
+// the number deleted.
+func RevokeOthers(ctx context.Context, client *ent.Client, userID, keepID int) (int, error) {
+	n, err := client.Session.
+		Delete().
+		Where(session.HasUserWith(user.ID(userID)), session.IDNEQ(keepID)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sessions: %w", err)
+	}
+	return n, nil
+}
+
+// Revoke deletes the session for a cookie token.
+func Revoke(ctx context.Context, client *ent.Client, token string) error {
+	_, err := client.Session.
+		Delete().
+		Where(session.Or(session.Token(token), session.PreviousToken(token))).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// Prune deletes sessions that are past their lifetime or idle timeout and
+// returns the number deleted.
+func Prune(ctx context.Context, client *ent.Client) (int, error) {
+	now := time.Now()
+	n, err := client.Session.
+		Delete().
+		Where(session.Or(
+			session.ExpiresAtLTE(now),
+			session.And(session.Remember(false), idleSince(now.Add(-IdleTimeout))),
+			session.And(session.Remember(true), idleSince(now.Add(-RememberIdleTimeout))),
+		)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune sessions: %w", err)
+	}
+	return n, nil
+}
+
+// idleSince matches sessions last used before the cutoff. Sessions that
+// have never been seen are judged by when they were created.
+func idleSince(cutoff time.Time) predicate.Session {
+	return session.Or(
+		session.LastSeenAtLT(cutoff),
+		session.And(session.LastSeenAtIsNil(), session.CreatedAtLT(cutoff)),
+	)
+}
+
+// SetCookie sends the session cookie. "Remember me" sessions persist until
+// they expire; other sessions end when the browser is closed.
+func SetCookie(w http.ResponseWriter, sess *ent.Session) {
+	cookie := &http.Cookie{
+		Name:     CookieName,
+		Value:    sess.Token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if sess.Remember {
+		cookie.Expires = sess.ExpiresAt
+	}
+	http.SetCookie(w, cookie)
+}
+
+// ClearCookie removes the session cookie.
+func ClearCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Now().Add(-1 * time.Hour),
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func idleTimeout(sess *ent.Session) time.Duration {
+	if sess.Remember {
+		return RememberIdleTimeout
+	}
+	return IdleTimeout
+}
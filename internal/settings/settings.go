@@ -0,0 +1,51 @@
+// Package settings stores site-wide options that admins can change while
+// the server is running.
+package settings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/setting"
+)
+
+// RequireAdmin2FA forces every admin to enroll in two-factor
+// authentication before using the site.
+const RequireAdmin2FA = "require_admin_2fa"
+
+// Get returns the value of a setting, or the empty string if it is not set.
+func Get(ctx context.Context, client *ent.Client, key string) (string, error) {
+	s, err := client.Setting.Query().Where(setting.Key(key)).Only(ctx)
+	if ent.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get setting %q: %w", key, err)
+	}
+	return s.Value, nil
+}
+
+// Bool returns true if the setting is "true".
+func Bool(ctx context.Context, client *ent.Client, key string) (bool, error) {
+	value, err := Get(ctx, client, key)
+	return value == "true", err
+}
+
+// Set creates or updates a setting.
+func Set(ctx context.Context, client *ent.Client, key, value string) error {
+	n, err := client.Setting.Update().Where(setting.Key(key)).SetValue(value).Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update setting %q: %w", key, err)
+	} else if n != 0 {
+		return nil
+	}
+	if err := client.Setting.Create().SetKey(key).SetValue(value).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create setting %q: %w", key, err)
+	}
+	return nil
+}
+
+// SetBool stores a boolean setting.
+func SetBool(ctx context.Context, client *ent.Client, key string, value bool) error {
+	return Set(ctx, client, key, fmt.Sprintf("%t", value))
+}
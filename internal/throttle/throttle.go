@@ -0,0 +1,190 @@
+// Package throttle slows down password guessing. Failed logins are counted
+// per username and per client IP address in the database, so restarting the
+// server does not reset them.
+//
+// The first few failures are free. After that each attempt must wait twice
+// as long as the one before, and enough failures lock the key out for a
+// while. Failures older than Window are forgotten.
+package throttle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/loginthrottle"
+)
+
+const (
+	// FreeFailures is the number of failures allowed before backoff starts.
+	FreeFailures = 3
+	// BaseDelay is the wait after the first failure past FreeFailures.
+	BaseDelay = time.Second
+	// MaxDelay caps the backoff.
+	MaxDelay = 5 * time.Minute
+	// MaxUsernameFailures locks a username out.
+	MaxUsernameFailures = 10
+	// MaxIPFailures locks an IP address out. It is higher than the username
+	// limit because players may share an address.
+	MaxIPFailures = 50
+	// LockoutDuration is how long a lockout lasts.
+	LockoutDuration = 30 * time.Minute
+	// Window is how long failures are remembered.
+	Window = 24 * time.Hour
+)
+
+// ErrThrottled is returned by Check when a login must not be attempted yet.
+var ErrThrottled = errors.New("too many failed login attempts")
+
+// Check returns an error wrapping ErrThrottled if either the username or the
+// IP address is locked out or still inside its backoff delay.
+func Check(ctx context.Context, client *ent.Client, username, ip string) error {
+	now := time.Now()
+	for _, k := range keys(username, ip) {
+		lt, err := find(ctx, client, k)
+		if err != nil {
+			return err
+		} else if lt == nil {
+			continue
+		}
+		if until := retryAt(lt); now.Before(until) {
+			return fmt.Errorf("%w for %s %q: retry after %s", ErrThrottled, k.kind, k.key, until.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// Fail records a failed login for the username and the IP address.
+func Fail(ctx context.Context, client *ent.Client, username, ip string) error {
+	now := time.Now()
+	for _, k := range keys(username, ip) {
+		lt, err := find(ctx, client, k)
+		if err != nil {
+			return err
+		}
+		if lt == nil {
+			err = client.LoginThrottle.Create().
+				SetKind(k.kind).
+				SetKey(k.key).
+				SetFailures(1).
+				SetLastFailureAt(now).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed recording login failure: %w", err)
+			}
+			continue
+		}
+
+		failures := lt.Failures + 1
+		if now.Sub(lt.LastFailureAt) > Window {
+			failures = 1
+		}
+		update := lt.Update().
+			SetFailures(failures).
+			SetLastFailureAt(now)
+		if failures >= k.max {
+			update.SetLockedUntil(now.Add(LockoutDuration))
+		} else {
+			update.ClearLockedUntil()
+		}
+		if err := update.Exec(ctx); err != nil {
+			return fmt.Errorf("failed recording login failure: %w", err)
+		}
+	}
+	return nil
+}
+
+// Succeed clears the failures for the username. Failures for the IP address
+// are kept; they expire on their own.
+func Succeed(ctx context.Context, client *ent.Client, username string) error {
+	return Unlock(ctx, client, username)
+}
+
+// Unlock clears the failures and any lockout for the username.
+func Unlock(ctx context.Context, client *ent.Client, username string) error {
+	_, err := client.LoginThrottle.Delete().
+		Where(loginthrottle.KindEQ(loginthrottle.KindUsername), loginthrottle.Key(normalize(username))).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed clearing login failures: %w", err)
+	}
+	return nil
+}
+
+// Prune deletes failure records that are outside the window and not locked
+// and returns the number deleted.
+func Prune(ctx context.Context, client *ent.Client) (int, error) {
+	now := time.Now()
+	n, err := client.LoginThrottle.Delete().
+		Where(
+			loginthrottle.LastFailureAtLT(now.Add(-Window)),
+			loginthrottle.Or(loginthrottle.LockedUntilIsNil(), loginthrottle.LockedUntilLT(now)),
+		).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune login failures: %w", err)
+	}
+	return n, nil
+}
+
+// Blocked reports whether the record currently prevents logins, and until
+// when.
+func Blocked(lt *ent.LoginThrottle) (bool, time.Time) {
+	until := retryAt(lt)
+	return time.Now().Before(until), until
+}
+
+// retryAt returns the earliest time the next attempt is allowed.
+func retryAt(lt *ent.LoginThrottle) time.Time {
+	if time.Since(lt.LastFailureAt) > Window {
+		return time.Time{}
+	}
+	if lt.LockedUntil != nil {
+		return *lt.LockedUntil
+	}
+	if lt.Failures <= FreeFailures {
+		return time.Time{}
+	}
+	delay := MaxDelay
+	if n := lt.Failures - FreeFailures - 1; n < 16 {
+		delay = min(BaseDelay<<n, MaxDelay)
+	}
+	return lt.LastFailureAt.Add(delay)
+}
+
+type key struct {
+	kind loginthrottle.Kind
+	key  string
+	max  int
+}
+
+func keys(username, ip string) []key {
+	var list []key
+	if username = normalize(username); username != "" {
+		list = append(list, key{kind: loginthrottle.KindUsername, key: username, max: MaxUsernameFailures})
+	}
+	if ip != "" {
+		list = append(list, key{kind: loginthrottle.KindIP, key: ip, max: MaxIPFailures})
+	}
+	return list
+}
+
+func find(ctx context.Context, client *ent.Client, k key) (*ent.LoginThrottle, error) {
+	lt, err := client.LoginThrottle.Query().
+		Where(loginthrottle.KindEQ(k.kind), loginthrottle.Key(k.key)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed reading login failures: %w", err)
+	}
+	return lt, nil
+}
+
+// normalize keeps "Alice" and "alice " from having separate counters.
+func normalize(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
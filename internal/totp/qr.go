@@ -0,0 +1,41 @@
+package totp
+
+import (
+	"bytes"
+	"fmt"
+
+	"rsc.io/qr"
+)
+
+// quietZone is the blank border, in modules, that scanners need.
+const quietZone = 4
+
+// QRCodeSVG renders text as a QR code in SVG. Modules are drawn in unit
+// coordinates, so the image scales cleanly to any size.
+func QRCodeSVG(text string) ([]byte, error) {
+	code, err := qr.Encode(text, qr.M)
+	if err != nil {
+		return nil, fmt.Errorf("totp: qr: %w", err)
+	}
+	size := code.Size + 2*quietZone
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="240" height="240" shape-rendering="crispEdges">`, size, size)
+	fmt.Fprintf(buf, `<rect width="%d" height="%d" fill="#fff"/>`, size, size)
+	buf.WriteString(`<path fill="#000" d="`)
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if !code.Black(x, y) {
+				continue
+			}
+			// draw a run of dark modules as one rectangle
+			run := 1
+			for x+run < code.Size && code.Black(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(buf, "M%d %dh%dv1h-%dz", x+quietZone, y+quietZone, run, run)
+			x += run - 1
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes(), nil
+}
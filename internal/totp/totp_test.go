@@ -0,0 +1,176 @@
+package totp
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 key of the RFC 6238 test vectors, the ASCII string
+// "12345678901234567890", in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeRFC6238(t *testing.T) {
+	// RFC 6238 appendix B lists eight digit codes; six digit codes are the
+	// last six digits of those.
+	for _, tc := range []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},          // 94287082
+		{1111111109, "081804"},  // 07081804
+		{1111111111, "050471"},  // 14050471
+		{1234567890, "005924"},  // 89005924
+		{2000000000, "279037"},  // 69279037
+		{20000000000, "353130"}, // 65353130
+	} {
+		got, err := Code(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		if err != nil {
+			t.Fatalf("%d: %v", tc.unix, err)
+		}
+		if got != tc.want {
+			t.Errorf("%d: got %s, want %s", tc.unix, got, tc.want)
+		}
+	}
+
+	// secrets are accepted in lower case too
+	if got, _ := Code(strings.ToLower(rfcSecret), 1); got != "287082" {
+		t.Errorf("lower case secret: got %s, want 287082", got)
+	}
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Error("invalid secret: got nil error")
+	}
+}
+
+func TestVerifySkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+	code := func(step int64) string {
+		c, err := Code(rfcSecret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	for _, tc := range []struct {
+		name string
+		step int64
+		ok   bool
+	}{
+		{"current step", step, true},
+		{"previous step", step - Skew, true},
+		{"next step", step + Skew, true},
+		{"too old", step - Skew - 1, false},
+		{"too new", step + Skew + 1, false},
+	} {
+		got, ok := Verify(rfcSecret, code(tc.step), now, 0)
+		if ok != tc.ok {
+			t.Errorf("%s: got %v, want %v", tc.name, ok, tc.ok)
+		} else if ok && got != tc.step {
+			t.Errorf("%s: matched step %d, want %d", tc.name, got, tc.step)
+		}
+	}
+
+	if _, ok := Verify(rfcSecret, " 050 471 ", now, 0); !ok {
+		t.Error("code with spaces: got false, want true")
+	}
+	for _, c := range []string{"", "05047", "0504711", "999999"} {
+		if _, ok := Verify(rfcSecret, c, now, 0); ok {
+			t.Errorf("code %q: got true, want false", c)
+		}
+	}
+}
+
+func TestVerifyReplay(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	c, err := Code(rfcSecret, Step(now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	step, ok := Verify(rfcSecret, c, now, 0)
+	if !ok {
+		t.Fatal("first use: got false, want true")
+	}
+
+	// the caller saves step as lastStep; the same code is then refused,
+	// even later in the skew window
+	if _, ok := Verify(rfcSecret, c, now, step); ok {
+		t.Error("replay: got true, want false")
+	}
+	if _, ok := Verify(rfcSecret, c, now.Add(Period), step); ok {
+		t.Error("replay in the next step: got true, want false")
+	}
+
+	// codes from earlier steps are refused too
+	earlier, _ := Code(rfcSecret, step-1)
+	if _, ok := Verify(rfcSecret, earlier, now, step); ok {
+		t.Error("earlier step: got true, want false")
+	}
+
+	// the code for the next step is still good
+	next, _ := Code(rfcSecret, step+1)
+	if got, ok := Verify(rfcSecret, next, now.Add(Period), step); !ok || got != step+1 {
+		t.Errorf("next step: got %d, %v; want %d, true", got, ok, step+1)
+	}
+}
+
+func TestURI(t *testing.T) {
+	got := URI("OttoMat", "alice", rfcSecret)
+	for _, want := range []string{"otpauth://totp/OttoMat:alice?", "secret=" + rfcSecret, "issuer=OttoMat", "digits=6", "period=30"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestQRCodeSVG(t *testing.T) {
+	svg, err := QRCodeSVG(URI("OttoMat", "alice", rfcSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the document must parse, with a single svg root holding the
+	// background and the modules
+	dec := xml.NewDecoder(strings.NewReader(string(svg)))
+	var root string
+	var elements []string
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid svg: %v", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				if root != "" {
+					t.Fatalf("second root element %q", tok.Name.Local)
+				}
+				root = tok.Name.Local
+				if tok.Name.Space != "http://www.w3.org/2000/svg" {
+					t.Errorf("namespace: got %q", tok.Name.Space)
+				}
+			} else {
+				elements = append(elements, tok.Name.Local)
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	if root != "svg" {
+		t.Errorf("root: got %q, want svg", root)
+	}
+	if strings.Join(elements, ",") != "rect,path" {
+		t.Errorf("elements: got %v, want [rect path]", elements)
+	}
+	if !strings.Contains(string(svg), `d="M`) {
+		t.Error("path has no modules")
+	}
+}
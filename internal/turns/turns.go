@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package turns implements the game calendar.
+//
+// TribeNet turns are identified by year and month ("0901-04"). The current
+// turn of a game is the latest turn created for it; advancing the game closes
+// the current turn and opens the one after it.
+package turns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/game"
+	"github.com/mdhender/ottomat/ent/turn"
+	"github.com/mdhender/ottomat/internal/report"
+)
+
+// Next returns the turn id that follows id. Month 12 rolls over to
+// month 1 of the next year.
+func Next(id string) (string, error) {
+	if !report.IsTurnID(id) {
+		return "", fmt.Errorf("invalid turn %q", id)
+	}
+	year, _ := strconv.Atoi(id[:4])
+	month, _ := strconv.Atoi(id[5:])
+	if month++; month > 12 {
+		year, month = year+1, 1
+	}
+	if year > 9999 {
+		return "", fmt.Errorf("turn %q: no turn after it", id)
+	}
+	return fmt.Sprintf("%04d-%02d", year, month), nil
+}
+
+// Current returns the current turn for the game, or nil if the game
+// has no turns yet.
+func Current(ctx context.Context, g *ent.Game) (*ent.Turn, error) {
+	return latest(ctx, g.QueryTurns(), g)
+}
+
+func latest(ctx context.Context, q *ent.TurnQuery, g *ent.Game) (*ent.Turn, error) {
+	t, err := q.Order(ent.Desc(turn.FieldTurnID)).First(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("game %s: current turn: %w", g.Code, err)
+	}
+	return t, nil
+}
+
+// Create adds a turn to the game. The turn must be later than the current
+// turn, which is closed; the new turn is open.
+func Create(ctx context.Context, client *ent.Client, g *ent.Game, id string, dueAt *time.Time) (*ent.Turn, error) {
+	if !report.IsTurnID(id) {
+		return nil, fmt.Errorf("invalid turn %q: expected YYYY-MM, e.g. 0901-04", id)
+	}
+
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// the database only has one connection, so every query must use the transaction
+	current, err := latest(ctx, tx.Turn.Query().Where(turn.HasGameWith(game.ID(g.ID))), g)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		if current.TurnID >= id {
+			return nil, fmt.Errorf("game %s: turn %s is not after the current turn %s", g.Code, id, current.TurnID)
+		}
+		if err := tx.Turn.UpdateOne(current).SetStatus(turn.StatusClosed).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("game %s: close turn %s: %w", g.Code, current.TurnID, err)
+		}
+	}
+
+	t, err := tx.Turn.
+		Create().
+		SetGameID(g.ID).
+		SetTurnID(id).
+		SetStatus(turn.StatusOpen).
+		SetNillableDueAt(dueAt).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("game %s: create turn %s: %w", g.Code, id, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return t.Unwrap(), nil
+}
+
+// Advance closes the current turn of the game and opens the next one.
+// If the game has no turns, it is an error; use Create for the first turn.
+func Advance(ctx context.Context, client *ent.Client, g *ent.Game, dueAt *time.Time) (*ent.Turn, error) {
+	current, err := Current(ctx, g)
+	if err != nil {
+		return nil, err
+	} else if current == nil {
+		return nil, fmt.Errorf("game %s: has no turns to advance", g.Code)
+	}
+	next, err := Next(current.TurnID)
+	if err != nil {
+		return nil, err
+	}
+	return Create(ctx, client, g, next, dueAt)
+}
+
+// SetDue publishes the report due date for the current turn of the game.
+// A nil dueAt clears it. Closed turns can't be changed.
+func SetDue(ctx context.Context, client *ent.Client, g *ent.Game, dueAt *time.Time) (*ent.Turn, error) {
+	current, err := Current(ctx, g)
+	if err != nil {
+		return nil, err
+	} else if current == nil {
+		return nil, fmt.Errorf("game %s: has no turns", g.Code)
+	} else if current.Status != turn.StatusOpen {
+		return nil, fmt.Errorf("game %s: turn %s is closed", g.Code, current.TurnID)
+	}
+	update := client.Turn.UpdateOne(current)
+	if dueAt == nil {
+		update.ClearDueAt()
+	} else {
+		update.SetDueAt(*dueAt)
+	}
+	t, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("game %s: turn %s: set due: %w", g.Code, current.TurnID, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,107 @@
+package twofactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/loginchallenge"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/auth"
+)
+
+// ChallengeCookieName is the name of the cookie that carries a login
+// between the password and the second factor.
+const ChallengeCookieName = "ottomat_2fa"
+
+// ChallengeTTL is how long the user has to enter a code after entering
+// their password.
+const ChallengeTTL = 5 * time.Minute
+
+// StartChallenge records that the user has passed the password check and
+// returns the token for the challenge cookie. The remember flag is carried
+// through so that the session created afterward honors "remember me".
+func StartChallenge(ctx context.Context, client *ent.Client, u *ent.User, remember bool) (string, time.Time, error) {
+	token, err := auth.GenerateToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(ChallengeTTL)
+	err = client.LoginChallenge.Create().
+		SetTokenHash(auth.HashToken(token)).
+		SetRemember(remember).
+		SetExpiresAt(expiresAt).
+		SetUserID(u.ID).
+		Exec(ctx)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create login challenge: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// LookupChallenge returns the unexpired challenge for a cookie token with
+// its user loaded. It returns an error satisfying ent.IsNotFound if the
+// token is unknown or expired.
+func LookupChallenge(ctx context.Context, client *ent.Client, token string) (*ent.LoginChallenge, error) {
+	return client.LoginChallenge.Query().
+		Where(loginchallenge.TokenHash(auth.HashToken(token)), loginchallenge.ExpiresAtGT(time.Now())).
+		WithUser().
+		Only(ctx)
+}
+
+// FinishChallenge deletes a challenge once the login has completed.
+func FinishChallenge(ctx context.Context, client *ent.Client, ch *ent.LoginChallenge) error {
+	if err := client.LoginChallenge.DeleteOneID(ch.ID).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete login challenge: %w", err)
+	}
+	return nil
+}
+
+// Prune deletes expired challenges and returns the number deleted.
+func Prune(ctx context.Context, client *ent.Client) (int, error) {
+	n, err := client.LoginChallenge.Delete().
+		Where(loginchallenge.ExpiresAtLTE(time.Now())).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune login challenges: %w", err)
+	}
+	return n, nil
+}
+
+// SetChallengeCookie sends the challenge cookie.
+func SetChallengeCookie(w http.ResponseWriter, token string, expiresAt time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     ChallengeCookieName,
+		Value:    token,
+		Path:     "/login",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// ClearChallengeCookie removes the challenge cookie.
+func ClearChallengeCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     ChallengeCookieName,
+		Value:    "",
+		Path:     "/login",
+		Expires:  time.Now().Add(-1 * time.Hour),
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func deleteChallenges(ctx context.Context, client *ent.Client, userID int) error {
+	_, err := client.LoginChallenge.Delete().
+		Where(loginchallenge.HasUserWith(user.ID(userID))).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete login challenges: %w", err)
+	}
+	return nil
+}
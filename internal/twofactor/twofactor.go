@@ -0,0 +1,251 @@
+// Package twofactor manages TOTP enrollment, recovery codes, and the
+// second step of the login flow.
+//
+// A user enrolls by scanning a secret into an authenticator app and
+// confirming one code. Until then the secret is pending and the user still
+// logs in with only a password. Once enabled, every login must also supply
+// a current code or one of the recovery codes issued at enrollment.
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/recoverycode"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/auth"
+	"github.com/mdhender/ottomat/internal/settings"
+	"github.com/mdhender/ottomat/internal/totp"
+)
+
+// Issuer is the account issuer shown in authenticator apps.
+const Issuer = "OttoMat"
+
+const (
+	// RecoveryCodeCount is the number of recovery codes issued at a time.
+	RecoveryCodeCount = 10
+	// recoveryCodeLength is the number of characters in a recovery code.
+	recoveryCodeLength = 10
+)
+
+var (
+	// ErrInvalid is returned for codes that are wrong or already used.
+	ErrInvalid = errors.New("invalid authentication code")
+	// ErrEnabled is returned when enrolling a user who already has 2FA.
+	ErrEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrNotEnabled is returned when the user has not enrolled.
+	ErrNotEnabled = errors.New("two-factor authentication is not enabled")
+)
+
+// Enabled reports whether the user must supply a second factor to log in.
+func Enabled(u *ent.User) bool {
+	return u.TotpEnabledAt != nil && u.TotpSecret != nil
+}
+
+// Required reports whether site policy requires the user to enroll.
+func Required(ctx context.Context, client *ent.Client, u *ent.User) (bool, error) {
+	if u.Role != user.RoleAdmin {
+		return false, nil
+	}
+	return settings.Bool(ctx, client, settings.RequireAdmin2FA)
+}
+
+// Enroll returns the pending secret for the user, creating one if needed.
+// Reusing the pending secret means that reloading the enrollment page does
+// not invalidate a code the user has already scanned.
+func Enroll(ctx context.Context, client *ent.Client, u *ent.User) (string, error) {
+	if Enabled(u) {
+		return "", ErrEnabled
+	} else if u.TotpSecret != nil {
+		return *u.TotpSecret, nil
+	}
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return "", err
+	}
+	if err := client.User.UpdateOneID(u.ID).SetTotpSecret(secret).SetTotpLastStep(0).Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to save secret: %w", err)
+	}
+	return secret, nil
+}
+
+// URI returns the otpauth URI for the user's secret.
+func URI(u *ent.User, secret string) string {
+	return totp.URI(Issuer, u.Username, secret)
+}
+
+// Confirm checks a code against the pending secret. If it matches, 2FA is
+// enabled and a fresh set of recovery codes is returned. The codes are not
+// stored in the clear, so this is the only time they can be shown.
+func Confirm(ctx context.Context, client *ent.Client, u *ent.User, code string) ([]string, error) {
+	if Enabled(u) {
+		return nil, ErrEnabled
+	} else if u.TotpSecret == nil {
+		return nil, ErrNotEnabled
+	}
+	now := time.Now()
+	step, ok := totp.Verify(*u.TotpSecret, code, now, u.TotpLastStep)
+	if !ok {
+		return nil, ErrInvalid
+	}
+
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.User.UpdateOneID(u.ID).
+		SetTotpEnabledAt(now).
+		SetTotpLastStep(step).
+		Exec(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to enable 2fa: %w", err)
+	}
+	codes, err := replaceRecoveryCodes(ctx, tx.Client(), u.ID)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Verify checks a second factor for the user. The code may be a current
+// TOTP code or an unused recovery code; either is consumed on success.
+func Verify(ctx context.Context, client *ent.Client, u *ent.User, code string) error {
+	if !Enabled(u) {
+		return ErrNotEnabled
+	}
+	// authenticator apps show codes as "123 456"
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	now := time.Now()
+	if len(code) == totp.Digits {
+		step, ok := totp.Verify(*u.TotpSecret, code, now, u.TotpLastStep)
+		if !ok {
+			return ErrInvalid
+		}
+		// only advance the step if no other request has used it first
+		n, err := client.User.Update().
+			Where(user.ID(u.ID), user.TotpLastStepLT(step)).
+			SetTotpLastStep(step).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to save step: %w", err)
+		} else if n == 0 {
+			return ErrInvalid
+		}
+		return nil
+	}
+
+	n, err := client.RecoveryCode.Update().
+		Where(
+			recoverycode.HasUserWith(user.ID(u.ID)),
+			recoverycode.CodeHash(hashRecoveryCode(code)),
+			recoverycode.UsedAtIsNil(),
+		).
+		SetUsedAt(now).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	} else if n == 0 {
+		return ErrInvalid
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes.
+func RegenerateRecoveryCodes(ctx context.Context, client *ent.Client, u *ent.User) ([]string, error) {
+	if !Enabled(u) {
+		return nil, ErrNotEnabled
+	}
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := replaceRecoveryCodes(ctx, tx.Client(), u.ID)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// RemainingRecoveryCodes returns the number of unused recovery codes.
+func RemainingRecoveryCodes(ctx context.Context, client *ent.Client, u *ent.User) (int, error) {
+	return client.RecoveryCode.Query().
+		Where(recoverycode.HasUserWith(user.ID(u.ID)), recoverycode.UsedAtIsNil()).
+		Count(ctx)
+}
+
+// Reset turns off 2FA for the user and removes the secret, recovery codes,
+// and any login in progress.
+func Reset(ctx context.Context, client *ent.Client, userID int) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	err = tx.User.UpdateOneID(userID).
+		ClearTotpSecret().
+		ClearTotpEnabledAt().
+		SetTotpLastStep(0).
+		Exec(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to reset 2fa: %w", err)
+	}
+	if _, err := tx.RecoveryCode.Delete().Where(recoverycode.HasUserWith(user.ID(userID))).Exec(ctx); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if err := deleteChallenges(ctx, tx.Client(), userID); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// replaceRecoveryCodes deletes the user's recovery codes and creates new
+// ones. The caller should run it in a transaction.
+func replaceRecoveryCodes(ctx context.Context, client *ent.Client, userID int) ([]string, error) {
+	if _, err := client.RecoveryCode.Delete().Where(recoverycode.HasUserWith(user.ID(userID))).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	var codes []string
+	var creates []*ent.RecoveryCodeCreate
+	for len(codes) < RecoveryCodeCount {
+		code := newRecoveryCode()
+		codes = append(codes, code)
+		creates = append(creates, client.RecoveryCode.Create().
+			SetCodeHash(hashRecoveryCode(code)).
+			SetUserID(userID))
+	}
+	if _, err := client.RecoveryCode.CreateBulk(creates...).Save(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// newRecoveryCode returns a random base32 code formatted as "xxxxx-xxxxx".
+func newRecoveryCode() string {
+	text := strings.ToLower(rand.Text()[:recoveryCodeLength])
+	half := recoveryCodeLength / 2
+	return text[:half] + "-" + text[half:]
+}
+
+// hashRecoveryCode ignores case, spaces, and dashes so users can type
+// codes the way they wrote them down.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	return auth.HashToken(code)
+}
@@ -0,0 +1,279 @@
+package users
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/clan"
+	"github.com/mdhender/ottomat/ent/user"
+	"github.com/mdhender/ottomat/internal/auth"
+	"github.com/mdhender/phrases/v2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// RosterColumns are the columns of a CSV roster, in the order they are
+// exported. Only username is required when importing.
+var RosterColumns = []string{"username", "role", "clan", "password"}
+
+// A RosterEntry is one user in a roster file. An empty role means guest,
+// an empty clan means no clan, and an empty password means one is
+// generated when the user is imported.
+type RosterEntry struct {
+	Line     int    `json:"-"` // line or array index in the file, for errors
+	Username string `json:"username"`
+	Role     string `json:"role,omitempty"`
+	Clan     string `json:"clan,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// ReadRosterCSV reads a roster from CSV. The first row names the columns,
+// which may be in any order.
+func ReadRosterCSV(r io.Reader) ([]RosterEntry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("roster is empty")
+	} else if err != nil {
+		return nil, err
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, col := range RosterColumns {
+			known = known || name == col
+		}
+		if !known {
+			return nil, fmt.Errorf("line 1: unknown column %q (want %s)", name, strings.Join(RosterColumns, ", "))
+		} else if _, ok := index[name]; ok {
+			return nil, fmt.Errorf("line 1: duplicate column %q", name)
+		}
+		index[name] = i
+	}
+	if _, ok := index["username"]; !ok {
+		return nil, fmt.Errorf("line 1: missing username column")
+	}
+
+	var entries []RosterEntry
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		entries = append(entries, RosterEntry{
+			Line:     line,
+			Username: field("username"),
+			Role:     field("role"),
+			Clan:     field("clan"),
+			Password: field("password"),
+		})
+	}
+	return entries, nil
+}
+
+// ReadRosterJSON reads a roster from a JSON array of entries.
+func ReadRosterJSON(r io.Reader) ([]RosterEntry, error) {
+	var entries []RosterEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].Line = i + 1
+	}
+	return entries, nil
+}
+
+// WriteRosterCSV writes a roster as CSV with a header row.
+func WriteRosterCSV(w io.Writer, entries []RosterEntry) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write(RosterColumns)
+	for _, e := range entries {
+		_ = cw.Write([]string{e.Username, e.Role, e.Clan, e.Password})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteRosterJSON writes a roster as an indented JSON array.
+func WriteRosterJSON(w io.Writer, entries []RosterEntry) error {
+	if entries == nil {
+		entries = []RosterEntry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// Roster returns every user as a roster entry, without passwords, ordered
+// by username.
+func Roster(ctx context.Context, client *ent.Client) ([]RosterEntry, error) {
+	list, err := client.User.Query().WithClan().Order(ent.Asc(user.FieldUsername)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var entries []RosterEntry
+	for _, u := range list {
+		e := RosterEntry{Username: u.Username, Role: u.Role.String()}
+		if u.Edges.Clan != nil {
+			e.Clan = fmt.Sprintf("%04d", u.Edges.Clan.Number)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// An ImportError is a problem with one entry of a roster.
+type ImportError struct {
+	Line     int
+	Username string
+	Err      error
+}
+
+func (e ImportError) Error() string {
+	if e.Username == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d: %s: %v", e.Line, e.Username, e.Err)
+}
+
+// A NewUser is a checked roster entry that is ready to be created.
+type NewUser struct {
+	Username  string
+	Role      user.Role
+	Clan      string // clan number as "0139", or empty
+	Password  string
+	Generated bool // Password is a generated passphrase
+
+	clan *ent.Clan
+	hash string
+}
+
+// PrepareImport checks every entry of a roster against the database and
+// returns the users to create, generating passphrases for entries without
+// a password. Problems with entries are returned as ImportErrors; the
+// error is only set if the database couldn't be read.
+//
+// Nothing is written, so PrepareImport is also the dry run.
+func PrepareImport(ctx context.Context, client *ent.Client, entries []RosterEntry) ([]*NewUser, []ImportError, error) {
+	var list []*NewUser
+	var problems []ImportError
+	seen := map[string]int{}
+	clans := map[int]*ent.Clan{}
+	for _, e := range entries {
+		fail := func(format string, args ...any) {
+			problems = append(problems, ImportError{Line: e.Line, Username: e.Username, Err: fmt.Errorf(format, args...)})
+		}
+
+		if e.Username == "" {
+			fail("username is required")
+			continue
+		} else if line, ok := seen[e.Username]; ok {
+			fail("username is also on line %d", line)
+			continue
+		}
+		seen[e.Username] = e.Line
+		exists, err := client.User.Query().Where(user.Username(e.Username)).Exist(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check for existing user: %w", err)
+		} else if exists {
+			fail("user already exists")
+			continue
+		}
+
+		nu := &NewUser{Username: e.Username, Role: user.RoleGuest, Password: e.Password}
+		if e.Role != "" {
+			nu.Role = user.Role(strings.ToLower(e.Role))
+			if err := user.RoleValidator(nu.Role); err != nil {
+				fail("invalid role %q (guest, chief, referee, admin)", e.Role)
+				continue
+			}
+		}
+
+		if e.Clan != "" {
+			number, err := strconv.Atoi(e.Clan)
+			if err != nil || number < 1 || number > 9999 {
+				fail("invalid clan %q", e.Clan)
+				continue
+			}
+			c, ok := clans[number]
+			if !ok {
+				c, err = client.Clan.Query().Where(clan.Number(number)).Only(ctx)
+				if ent.IsNotFound(err) {
+					c = nil
+				} else if err != nil {
+					return nil, nil, fmt.Errorf("failed to find clan %04d: %w", number, err)
+				}
+				clans[number] = c
+			}
+			if c == nil {
+				fail("clan %04d does not exist", number)
+				continue
+			} else if !c.Active {
+				fail("clan %04d is not active", number)
+				continue
+			}
+			nu.clan, nu.Clan = c, fmt.Sprintf("%04d", c.Number)
+		}
+
+		if nu.Password == "" {
+			nu.Password, nu.Generated = phrases.Generate(6), true
+		} else if err := auth.CheckPasswordStrength(nu.Username, nu.Password); err != nil {
+			fail("%v", err)
+			continue
+		}
+
+		list = append(list, nu)
+	}
+	return list, problems, nil
+}
+
+// CreateUsers creates the users returned by PrepareImport in a single
+// transaction; if any of them can't be created, none are.
+func CreateUsers(ctx context.Context, client *ent.Client, list []*NewUser) error {
+	for _, nu := range list {
+		hash, err := bcrypt.GenerateFromPassword([]byte(nu.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("%s: failed to hash password: %w", nu.Username, err)
+		}
+		nu.hash = string(hash)
+	}
+
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	for _, nu := range list {
+		create := tx.User.Create().
+			SetUsername(nu.Username).
+			SetPasswordHash(nu.hash).
+			SetRole(nu.Role)
+		if nu.clan != nil {
+			create.SetClanID(nu.clan.ID)
+		}
+		if _, err := create.Save(ctx); err != nil {
+			return fmt.Errorf("%s: failed to create user: %w", nu.Username, err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,96 @@
+// Package users holds the rules for changing user accounts that are shared
+// by the admin dashboard and the command line.
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mdhender/ottomat/ent"
+	"github.com/mdhender/ottomat/ent/session"
+	"github.com/mdhender/ottomat/ent/user"
+)
+
+// Roles lists every role, from least to most powerful.
+var Roles = []user.Role{user.RoleGuest, user.RoleChief, user.RoleReferee, user.RoleAdmin}
+
+// ErrLastAdmin is returned when a change would leave the site without an
+// admin.
+var ErrLastAdmin = errors.New("the last admin can't be removed, disabled or demoted")
+
+// CheckLastAdmin returns ErrLastAdmin if u is the only enabled admin. Call
+// it with the transaction's client before demoting, disabling or deleting
+// u, so that two admins can't remove each other at the same time.
+func CheckLastAdmin(ctx context.Context, client *ent.Client, u *ent.User) error {
+	if u.Role != user.RoleAdmin || u.DisabledAt != nil {
+		return nil
+	}
+	others, err := client.User.Query().Where(user.RoleEQ(user.RoleAdmin), user.IDNEQ(u.ID), user.DisabledAtIsNil()).Count(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count admins: %w", err)
+	} else if others == 0 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// Disable stops u from signing in and signs them out of every session.
+// Their API tokens and reset links stop working but are kept, so that they
+// work again if u is enabled. Disabling a disabled user updates the reason.
+func Disable(ctx context.Context, client *ent.Client, u *ent.User, reason string) (*ent.User, error) {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if err := CheckLastAdmin(ctx, tx.Client(), u); err != nil {
+		return nil, err
+	}
+	update := tx.User.UpdateOneID(u.ID).SetDisabledReason(reason)
+	if u.DisabledAt == nil {
+		update.SetDisabledAt(time.Now())
+	}
+	u, err = update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to disable user: %w", err)
+	}
+	if _, err := tx.Session.Delete().Where(session.HasUserWith(user.ID(u.ID))).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to remove sessions: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Enable lets a disabled user sign in again.
+func Enable(ctx context.Context, client *ent.Client, u *ent.User) (*ent.User, error) {
+	u, err := client.User.UpdateOneID(u.ID).ClearDisabledAt().ClearDisabledReason().Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable user: %w", err)
+	}
+	return u, nil
+}
+
+// Purge deletes u along with their sessions, tokens and reset links. It
+// can't be undone; disable users who should only lose access.
+func Purge(ctx context.Context, client *ent.Client, u *ent.User) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if err := CheckLastAdmin(ctx, tx.Client(), u); err != nil {
+		return err
+	}
+	if err := tx.User.DeleteOneID(u.ID).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return tx.Commit()
+}